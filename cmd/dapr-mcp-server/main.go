@@ -2,32 +2,46 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 	dapr "github.com/dapr/go-sdk/client"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/protobuf/reflect/protoregistry"
 
 	actor "github.com/dapr/dapr-mcp-server/pkg/actors"
+	"github.com/dapr/dapr-mcp-server/pkg/approval"
 	"github.com/dapr/dapr-mcp-server/pkg/auth"
 	binding "github.com/dapr/dapr-mcp-server/pkg/bindings"
+	"github.com/dapr/dapr-mcp-server/pkg/catalog"
+	"github.com/dapr/dapr-mcp-server/pkg/configuration"
 	conversation "github.com/dapr/dapr-mcp-server/pkg/conversation"
 	crypto "github.com/dapr/dapr-mcp-server/pkg/crypto"
+	"github.com/dapr/dapr-mcp-server/pkg/dryrun"
 	"github.com/dapr/dapr-mcp-server/pkg/health"
 	invoke "github.com/dapr/dapr-mcp-server/pkg/invoke"
+	"github.com/dapr/dapr-mcp-server/pkg/jobs"
 	lock "github.com/dapr/dapr-mcp-server/pkg/lock"
 	metadata "github.com/dapr/dapr-mcp-server/pkg/metadata"
 	pubsub "github.com/dapr/dapr-mcp-server/pkg/pubsub"
+	"github.com/dapr/dapr-mcp-server/pkg/resiliency"
+	"github.com/dapr/dapr-mcp-server/pkg/resources"
 	secret "github.com/dapr/dapr-mcp-server/pkg/secrets"
+	"github.com/dapr/dapr-mcp-server/pkg/sidecar"
 	state "github.com/dapr/dapr-mcp-server/pkg/state"
 	"github.com/dapr/dapr-mcp-server/pkg/telemetry"
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+	"github.com/dapr/dapr-mcp-server/pkg/workflow"
 )
 
 var (
@@ -120,34 +134,114 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Dry-run mode plans mutating calls instead of sending them to the sidecar
+	dryRunMode := os.Getenv("DAPR_MCP_SERVER_DRY_RUN") == "true"
+	dryrun.SetEnabled(dryRunMode)
+	if dryRunMode {
+		logger.Warn("Dry-run mode enabled: tools not annotated read-only will describe their calls without executing them")
+	}
+
+	// Destructive tools may ask the client for approval through elicitation
+	approvalConfig, err := approval.DefaultConfig()
+	if err == nil {
+		err = approvalConfig.Validate()
+	}
+	if err != nil {
+		logger.Error("Invalid approval configuration", "error", err)
+		os.Exit(1)
+	}
+	approval.Configure(approvalConfig)
+	if approvalConfig.Enabled {
+		logger.Info("Approval required for destructive tools",
+			"rules", approvalConfig.Rules,
+			"fallback", approvalConfig.Fallback,
+		)
+	}
+
 	// Build server instructions
 	var instructions strings.Builder
 	instructions.WriteString("You are an expert AI assistant for Dapr microservices. Your role is to translate user requests into precise, deterministic, and safe Dapr MCP tool calls.\n\n")
 
 	instructions.WriteString("### Global Safety Rules\n")
-	instructions.WriteString("- **Clarity Before Acting**: If ANY required argument is missing (store name, key, topic, etc.), you **MUST run the get_components tool to enrich the information before proceeding**. If arguments are still missing first try the tool with sensible defaults, if this fails ask the user for clarification.\n")
+	instructions.WriteString("- **Clarity Before Acting**: If ANY required argument is missing (store name, key, topic, etc.), you **MUST run the get_components tool (or get_actor_types for the actor types hosted by this server's own app) to enrich the information before proceeding**. If arguments are still missing first try the tool with sensible defaults, if this fails ask the user for clarification.\n")
 	instructions.WriteString("- **Serialization**: Metadata fields MUST be a dictionary/map (e.g., `{}`) and NEVER a quoted string (e.g., `\"{}\"`).\n")
 	instructions.WriteString("- **Multi-Step Workflow**: When multiple operations are requested, execute them sequentially — **one tool call at a time**.\n")
 	instructions.WriteString("- **Forbidden Actions**: NEVER invent component names, keys, topics, or cryptographic parameters.\n\n")
 	instructions.WriteString("### Tool Call Validity\n")
 	instructions.WriteString("Consult the tool's Description for specific component rules (e.g., key formatting, security warnings).\n")
+	if dryRunMode {
+		instructions.WriteString("\n### Dry-Run Mode\n")
+		instructions.WriteString("This server runs in dry-run mode: only read-only tools are executed. Every other tool returns a plan instead; delete_state, execute_transaction, import_state, invoke_actor_method and release_lock validate the request they would send, the rest only echo their arguments. Tell the user that no changes were made.\n")
+	}
+
+	// Resource subscriptions are routed to the package serving the resource
+	resourceRouter := resources.NewRouter()
 
 	opts := &mcp.ServerOptions{
-		Instructions:      instructions.String(),
-		CompletionHandler: complete,
-		HasTools:          true,
+		Instructions:       instructions.String(),
+		CompletionHandler:  complete,
+		HasTools:           true,
+		SubscribeHandler:   resourceRouter.Subscribe,
+		UnsubscribeHandler: resourceRouter.Unsubscribe,
 	}
 	logger.Debug("Server instructions configured", "instructions", instructions.String())
 
 	server := mcp.NewServer(&mcp.Implementation{Name: "dapr-mcp-server", Version: Version}, opts)
 
+	// Run tool calls under deadlines, retries and circuit breakers
+	resiliencyConfig, err := resiliency.LoadConfig(os.Getenv("DAPR_MCP_SERVER_RESILIENCY_FILE"))
+	if err != nil {
+		logger.Error("Invalid resiliency configuration", "error", err)
+		os.Exit(1)
+	}
+	toolResiliency := resiliency.New(resiliencyConfig)
+	server.AddReceivingMiddleware(toolResiliency.Middleware)
+
+	// Ask for approval before destructive calls, outside the retries so that
+	// the user is asked once per call
+	server.AddReceivingMiddleware(approval.Middleware)
+
+	// Keep dry runs from reaching the sidecar, outside approval so that the
+	// user is not asked about calls that are only planned
+	server.AddReceivingMiddleware(dryrun.Middleware)
+
+	// Load authentication configuration and the optional state access policy
+	authConfig := auth.DefaultConfig()
+	if err := authConfig.Validate(); err != nil {
+		logger.Error("Invalid authentication configuration", "error", err)
+		os.Exit(1)
+	}
+	statePolicy, err := auth.LoadStatePolicy(authConfig.StatePolicyFile)
+	if err != nil {
+		logger.Error("Invalid state access policy", "error", err)
+		os.Exit(1)
+	}
+	if statePolicy != nil {
+		logger.Info("State access policy loaded", "file", authConfig.StatePolicyFile, "rules", len(statePolicy.Rules))
+	}
+
 	// Register core tools
 	metadata.RegisterTools(server, DaprClient)
-	invoke.RegisterTools(server, DaprClient)
+	metadata.RegisterActorTools(server, runtimev1pb.NewDaprClient(DaprClient.GrpcClientConn()))
+	invoke.SetResponseLimits(invoke.DefaultResponseLimits())
+	sidecarClient := sidecar.NewClient(sidecar.DefaultEndpoint(), nil)
+	invoke.RegisterTools(server, sidecarClient)
+	var grpcDescriptors *protoregistry.Files
+	if path := os.Getenv("DAPR_MCP_SERVER_GRPC_DESCRIPTOR_SET"); path != "" {
+		grpcDescriptors, err = invoke.LoadDescriptorSet(path)
+		if err != nil {
+			logger.Error("Invalid gRPC descriptor set", "error", err)
+			os.Exit(1)
+		}
+		logger.Info("gRPC descriptor set loaded", "file", path, "files", grpcDescriptors.NumFiles())
+	}
+	invoke.RegisterGRPCTools(server, DaprClient.GrpcClientConn(), grpcDescriptors)
 	actor.RegisterTools(server, DaprClient)
+	jobs.RegisterTools(server, DaprClient)
 
 	// Discover components and register conditional tools
 	componentPresence := make(map[string]bool)
+	var configurationStores []string
 	components, err := metadata.GetLiveComponentList(ctx, DaprClient)
 	if err != nil {
 		logger.Error("Fatal error: could not get components", "error", err)
@@ -156,6 +250,12 @@ func main() {
 	for _, comp := range components {
 		if strings.HasPrefix(comp.Type, "state.") {
 			componentPresence["state"] = true
+			if comp.HasCapability(metadata.CapabilityQueryAPI) {
+				componentPresence["state_query"] = true
+			}
+			if comp.HasCapability(metadata.CapabilityActor) {
+				componentPresence["workflow"] = true
+			}
 		} else if strings.HasPrefix(comp.Type, "pubsub.") {
 			componentPresence["pubsub"] = true
 		} else if strings.HasPrefix(comp.Type, "bindings.") {
@@ -164,6 +264,9 @@ func main() {
 			componentPresence["secrets"] = true
 		} else if strings.HasPrefix(comp.Type, "lock.") {
 			componentPresence["lock"] = true
+		} else if strings.HasPrefix(comp.Type, "configuration.") {
+			componentPresence["configuration"] = true
+			configurationStores = append(configurationStores, comp.Name)
 		} else if strings.HasPrefix(comp.Type, "conversation.") {
 			componentPresence["conversation"] = true
 		} else if strings.HasPrefix(comp.Type, "crypto.") {
@@ -173,18 +276,52 @@ func main() {
 
 	logger.Info("Discovered Dapr components", "components", componentPresence)
 
+	var topicBridge *pubsub.TopicBridge
 	if componentPresence["pubsub"] {
 		pubsub.RegisterTools(server, DaprClient)
+		bufferSize, _ := strconv.Atoi(os.Getenv("DAPR_MCP_SERVER_TOPIC_BUFFER_SIZE"))
+		topicBridge = pubsub.NewTopicBridge(server, DaprClient, bufferSize)
+		deadLetterTopics, err := pubsub.ParseDeadLetterTopics(os.Getenv("DAPR_MCP_SERVER_DEAD_LETTER_TOPICS"))
+		if err != nil {
+			logger.Error("Invalid dead-letter topic configuration", "error", err)
+			os.Exit(1)
+		}
+		pubsub.SetDeadLetterTopics(deadLetterTopics)
+		pubsub.RegisterResources(server, resourceRouter, topicBridge)
 	}
 	if componentPresence["bindings"] {
 		binding.RegisterTools(server, DaprClient)
 	}
 	if componentPresence["state"] {
+		state.SetPolicy(statePolicy)
 		state.RegisterTools(server, DaprClient)
 	}
+	if componentPresence["state_query"] {
+		state.RegisterQueryTools(server, DaprClient)
+	}
 	if componentPresence["secrets"] {
 		secret.RegisterTools(server, DaprClient)
 	}
+	if componentPresence["configuration"] {
+		configuration.RegisterTools(server, DaprClient)
+		configurationWatcher := configuration.NewWatcher(server, DaprClient)
+		configuration.RegisterResources(server, resourceRouter, configurationWatcher)
+		refreshInterval := configuration.DefaultRefreshInterval
+		if intervalStr := os.Getenv("DAPR_MCP_SERVER_CONFIGURATION_REFRESH_INTERVAL"); intervalStr != "" {
+			d, err := time.ParseDuration(intervalStr)
+			if err != nil || d <= 0 {
+				logger.Error("Invalid DAPR_MCP_SERVER_CONFIGURATION_REFRESH_INTERVAL", "value", intervalStr)
+				os.Exit(1)
+			}
+			refreshInterval = d
+		}
+		for _, storeName := range configurationStores {
+			if err := configurationWatcher.ListItems(ctx, storeName); err != nil {
+				logger.Warn("Configuration keys are not listed as resources", "store", storeName, "error", err)
+			}
+		}
+		go configurationWatcher.Run(ctx, configurationStores, refreshInterval)
+	}
 	if componentPresence["conversation"] {
 		conversation.RegisterTools(server, DaprClient)
 	}
@@ -194,18 +331,32 @@ func main() {
 	if componentPresence["lock"] {
 		lock.RegisterTools(server, DaprClient)
 	}
+	if componentPresence["workflow"] {
+		workflow.RegisterTools(server, workflow.NewClient(DaprClient.GrpcClientConn()))
+		workflow.RegisterHistoryTools(server, DaprClient)
+	}
+
+	// Build the service catalog from the OpenAPI documents of the configured
+	// apps. It starts after the built-in tools are registered so that generated
+	// tools never replace them, and fetches the documents in the background.
+	if catalogConfig := catalog.DefaultConfig(); len(catalogConfig.Apps) > 0 {
+		catalogConfig.ReservedTools, err = toolcall.Names(ctx, server)
+		if err != nil {
+			logger.Error("Failed to list the built-in tools", "error", err)
+			os.Exit(1)
+		}
+		serviceCatalog := catalog.New(server, sidecarClient, catalogConfig)
+		catalog.RegisterResources(server, resourceRouter, serviceCatalog)
+		go serviceCatalog.Run(ctx)
+		logger.Info("Service catalog enabled", "apps", len(catalogConfig.Apps), "refresh_interval", catalogConfig.RefreshInterval, "tools", catalogConfig.GenerateTools)
+	}
 
 	if *httpAddr != "" {
 		// Initialize health checker
 		healthChecker := health.NewHandler(DaprClient, Version)
+		healthChecker.AddCheck(toolResiliency.Check)
 
 		// Initialize authentication
-		authConfig := auth.DefaultConfig()
-		if err := authConfig.Validate(); err != nil {
-			logger.Error("Invalid authentication configuration", "error", err)
-			os.Exit(1)
-		}
-
 		var authMiddleware func(http.Handler) http.Handler
 		if authConfig.Enabled && authConfig.Mode != auth.ModeDisabled {
 			logger.Info("Starting authentication initialization", "mode", authConfig.Mode)
@@ -247,10 +398,34 @@ func main() {
 			mcpHandler.ServeHTTP(w, r)
 		})))
 
+		// Declarative subscriptions feed the topic resources. The sidecar
+		// authenticates its deliveries with the app API token, so they are only
+		// accepted when APP_API_TOKEN is set.
+		subscriptions := []pubsub.DeclarativeSubscription{}
+		appAPIToken := os.Getenv("APP_API_TOKEN")
+		if topicBridge != nil && appAPIToken == "" && os.Getenv("DAPR_MCP_SERVER_PUBSUB_TOPICS") != "" {
+			logger.Error("Declarative topic subscriptions require APP_API_TOKEN; DAPR_MCP_SERVER_PUBSUB_TOPICS is ignored")
+		}
+		if topicBridge != nil && appAPIToken != "" {
+			for _, entry := range strings.Split(os.Getenv("DAPR_MCP_SERVER_PUBSUB_TOPICS"), ",") {
+				pubsubName, topic, ok := strings.Cut(strings.TrimSpace(entry), "/")
+				if !ok || pubsubName == "" || topic == "" {
+					if entry != "" {
+						logger.Warn("Ignoring invalid pubsub topic subscription", "entry", entry)
+					}
+					continue
+				}
+				topicBridge.Declare(pubsubName, topic)
+			}
+			subscriptions = topicBridge.DeclarativeSubscriptions()
+			mux.Handle("/dapr/events/", auth.AppAPITokenMiddleware(appAPIToken)(topicBridge))
+			logger.Info("Declarative topic subscriptions", "count", len(subscriptions))
+		}
+
 		// Handle Dapr subscription endpoint
 		mux.HandleFunc("/dapr/subscribe", func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Content-Type", "application/json")
-			_, _ = w.Write([]byte(`[]`))
+			_ = json.NewEncoder(w).Encode(subscriptions)
 		})
 
 		// Route all other requests to MCP handler
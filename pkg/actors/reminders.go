@@ -0,0 +1,188 @@
+package actors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+type RegisterActorReminderArgs struct {
+	ActorType string `json:"actorType" jsonschema:"The registered actor type (e.g., 'cart')."`
+	ActorID   string `json:"actorID" jsonschema:"The unique ID of the actor instance (e.g., 'user-1001')."`
+	Name      string `json:"name" jsonschema:"The reminder name, unique per actor (e.g., 'checkout-timeout')."`
+	DueTime   string `json:"dueTime,omitempty" jsonschema:"Optional delay before the first call, as a Go duration ('30s') or ISO 8601 duration ('PT30S')."`
+	Period    string `json:"period,omitempty" jsonschema:"Optional interval between calls, as a Go duration, an ISO 8601 duration, or an ISO 8601 repetition ('R5/PT1M'). Omit for a one-shot reminder."`
+	TTL       string `json:"ttl,omitempty" jsonschema:"Optional time after which the reminder expires, as a duration or an RFC 3339 time."`
+	Data      string `json:"data,omitempty" jsonschema:"Optional payload passed to the actor on every call."`
+}
+
+type RegisterActorTimerArgs struct {
+	ActorType string `json:"actorType" jsonschema:"The registered actor type (e.g., 'cart')."`
+	ActorID   string `json:"actorID" jsonschema:"The unique ID of the actor instance (e.g., 'user-1001')."`
+	Name      string `json:"name" jsonschema:"The timer name, unique per actor (e.g., 'refresh-prices')."`
+	Callback  string `json:"callback" jsonschema:"The actor method called when the timer fires (e.g., 'RefreshPrices')."`
+	DueTime   string `json:"dueTime,omitempty" jsonschema:"Optional delay before the first call, as a Go duration ('30s') or ISO 8601 duration ('PT30S')."`
+	Period    string `json:"period,omitempty" jsonschema:"Optional interval between calls, as a Go duration, an ISO 8601 duration, or an ISO 8601 repetition ('R5/PT1M'). Omit for a one-shot timer."`
+	TTL       string `json:"ttl,omitempty" jsonschema:"Optional time after which the timer expires, as a duration or an RFC 3339 time."`
+	Data      string `json:"data,omitempty" jsonschema:"Optional payload passed to the callback on every call."`
+}
+
+type UnregisterActorScheduleArgs struct {
+	ActorType string `json:"actorType" jsonschema:"The registered actor type (e.g., 'cart')."`
+	ActorID   string `json:"actorID" jsonschema:"The unique ID of the actor instance (e.g., 'user-1001')."`
+	Name      string `json:"name" jsonschema:"The name the reminder or timer was registered with."`
+}
+
+// scheduleResult reports a registered or unregistered reminder or timer.
+func scheduleResult(kind, action string, args UnregisterActorScheduleArgs, extra map[string]interface{}) (*mcp.CallToolResult, any, error) {
+	message := fmt.Sprintf("Successfully %s %s '%s' on actor '%s' of type '%s'.", action, kind, args.Name, args.ActorID, args.ActorType)
+	log.Println(message)
+
+	structuredResult := map[string]interface{}{
+		"actor_type": args.ActorType,
+		"actor_id":   args.ActorID,
+		"name":       args.Name,
+	}
+	for k, v := range extra {
+		if v != "" {
+			structuredResult[k] = v
+		}
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message}},
+	}, structuredResult, nil
+}
+
+func invalidArgumentResult(err error) (*mcp.CallToolResult, any, error) {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}},
+		IsError: true,
+	}, nil, nil
+}
+
+func scheduleError(operation string, err error) (*mcp.CallToolResult, any, error) {
+	log.Printf("Dapr %s failed: %v", operation, err)
+	toolErrorMessage := fmt.Errorf("dapr %s failed: %w", operation, err).Error()
+	return toolcall.WithStatus(&mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
+		IsError: true,
+	}, err), nil, nil
+}
+
+func registerActorReminderTool(ctx context.Context, req *mcp.CallToolRequest, args RegisterActorReminderArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "register_actor_reminder")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "register_actor_reminder"),
+		attribute.String("dapr.actor_type", args.ActorType),
+	)
+
+	if args.ActorType == "" || args.ActorID == "" || args.Name == "" {
+		return invalidArgumentResult(errors.New("actorType, actorID and name are required"))
+	}
+
+	err := actorClient.RegisterActorReminder(ctx, &dapr.RegisterActorReminderRequest{
+		ActorType: args.ActorType,
+		ActorID:   args.ActorID,
+		Name:      args.Name,
+		DueTime:   args.DueTime,
+		Period:    args.Period,
+		TTL:       args.TTL,
+		Data:      []byte(args.Data),
+	})
+	if err != nil {
+		return scheduleError("RegisterActorReminder", err)
+	}
+	return scheduleResult("reminder", "registered", UnregisterActorScheduleArgs{ActorType: args.ActorType, ActorID: args.ActorID, Name: args.Name}, map[string]interface{}{
+		"due_time": args.DueTime,
+		"period":   args.Period,
+		"ttl":      args.TTL,
+	})
+}
+
+func unregisterActorReminderTool(ctx context.Context, req *mcp.CallToolRequest, args UnregisterActorScheduleArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "unregister_actor_reminder")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "unregister_actor_reminder"),
+		attribute.String("dapr.actor_type", args.ActorType),
+	)
+
+	if args.ActorType == "" || args.ActorID == "" || args.Name == "" {
+		return invalidArgumentResult(errors.New("actorType, actorID and name are required"))
+	}
+
+	err := actorClient.UnregisterActorReminder(ctx, &dapr.UnregisterActorReminderRequest{
+		ActorType: args.ActorType,
+		ActorID:   args.ActorID,
+		Name:      args.Name,
+	})
+	if err != nil {
+		return scheduleError("UnregisterActorReminder", err)
+	}
+	return scheduleResult("reminder", "unregistered", args, nil)
+}
+
+func registerActorTimerTool(ctx context.Context, req *mcp.CallToolRequest, args RegisterActorTimerArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "register_actor_timer")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "register_actor_timer"),
+		attribute.String("dapr.actor_type", args.ActorType),
+	)
+
+	if args.ActorType == "" || args.ActorID == "" || args.Name == "" || args.Callback == "" {
+		return invalidArgumentResult(errors.New("actorType, actorID, name and callback are required"))
+	}
+
+	err := actorClient.RegisterActorTimer(ctx, &dapr.RegisterActorTimerRequest{
+		ActorType: args.ActorType,
+		ActorID:   args.ActorID,
+		Name:      args.Name,
+		DueTime:   args.DueTime,
+		Period:    args.Period,
+		TTL:       args.TTL,
+		Data:      []byte(args.Data),
+		CallBack:  args.Callback,
+	})
+	if err != nil {
+		return scheduleError("RegisterActorTimer", err)
+	}
+	return scheduleResult("timer", "registered", UnregisterActorScheduleArgs{ActorType: args.ActorType, ActorID: args.ActorID, Name: args.Name}, map[string]interface{}{
+		"callback": args.Callback,
+		"due_time": args.DueTime,
+		"period":   args.Period,
+		"ttl":      args.TTL,
+	})
+}
+
+func unregisterActorTimerTool(ctx context.Context, req *mcp.CallToolRequest, args UnregisterActorScheduleArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "unregister_actor_timer")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "unregister_actor_timer"),
+		attribute.String("dapr.actor_type", args.ActorType),
+	)
+
+	if args.ActorType == "" || args.ActorID == "" || args.Name == "" {
+		return invalidArgumentResult(errors.New("actorType, actorID and name are required"))
+	}
+
+	err := actorClient.UnregisterActorTimer(ctx, &dapr.UnregisterActorTimerRequest{
+		ActorType: args.ActorType,
+		ActorID:   args.ActorID,
+		Name:      args.Name,
+	})
+	if err != nil {
+		return scheduleError("UnregisterActorTimer", err)
+	}
+	return scheduleResult("timer", "unregistered", args, nil)
+}
@@ -0,0 +1,142 @@
+package actors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterActorReminderTool(t *testing.T) {
+	mockActor := new(mockActorClient)
+	mockActor.On("RegisterActorReminder", mock.Anything, &dapr.RegisterActorReminderRequest{
+		ActorType: "shopping-cart",
+		ActorID:   "user-1",
+		Name:      "checkout-timeout",
+		DueTime:   "30m",
+		Period:    "R3/PT10M",
+		Data:      []byte(`{"notify": true}`),
+	}).Return(nil)
+	actorClient = mockActor
+
+	result, structured, err := registerActorReminderTool(context.Background(), &mcp.CallToolRequest{}, RegisterActorReminderArgs{
+		ActorType: "shopping-cart",
+		ActorID:   "user-1",
+		Name:      "checkout-timeout",
+		DueTime:   "30m",
+		Period:    "R3/PT10M",
+		Data:      `{"notify": true}`,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "Successfully registered reminder 'checkout-timeout' on actor 'user-1' of type 'shopping-cart'.")
+	assert.Equal(t, map[string]interface{}{
+		"actor_type": "shopping-cart",
+		"actor_id":   "user-1",
+		"name":       "checkout-timeout",
+		"due_time":   "30m",
+		"period":     "R3/PT10M",
+	}, structured)
+	mockActor.AssertExpectations(t)
+}
+
+func TestRegisterActorTimerTool(t *testing.T) {
+	mockActor := new(mockActorClient)
+	mockActor.On("RegisterActorTimer", mock.Anything, mock.MatchedBy(func(req *dapr.RegisterActorTimerRequest) bool {
+		return req.Name == "refresh" && req.CallBack == "RefreshPrices" && req.Period == "1m"
+	})).Return(nil)
+	actorClient = mockActor
+
+	result, structured, err := registerActorTimerTool(context.Background(), &mcp.CallToolRequest{}, RegisterActorTimerArgs{
+		ActorType: "shopping-cart",
+		ActorID:   "user-1",
+		Name:      "refresh",
+		Callback:  "RefreshPrices",
+		Period:    "1m",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, "RefreshPrices", structured.(map[string]interface{})["callback"])
+	mockActor.AssertExpectations(t)
+}
+
+func TestUnregisterActorScheduleTools(t *testing.T) {
+	args := UnregisterActorScheduleArgs{ActorType: "shopping-cart", ActorID: "user-1", Name: "checkout-timeout"}
+
+	t.Run("reminder", func(t *testing.T) {
+		mockActor := new(mockActorClient)
+		mockActor.On("UnregisterActorReminder", mock.Anything, &dapr.UnregisterActorReminderRequest{
+			ActorType: "shopping-cart", ActorID: "user-1", Name: "checkout-timeout",
+		}).Return(nil)
+		actorClient = mockActor
+
+		result, _, err := unregisterActorReminderTool(context.Background(), &mcp.CallToolRequest{}, args)
+
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "Successfully unregistered reminder 'checkout-timeout'")
+	})
+
+	t.Run("timer error", func(t *testing.T) {
+		mockActor := new(mockActorClient)
+		mockActor.On("UnregisterActorTimer", mock.Anything, mock.Anything).Return(errors.New("actor not found"))
+		actorClient = mockActor
+
+		result, structured, err := unregisterActorTimerTool(context.Background(), &mcp.CallToolRequest{}, args)
+
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Nil(t, structured)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "dapr UnregisterActorTimer failed: actor not found")
+	})
+}
+
+func TestActorToolsRequireArguments(t *testing.T) {
+	tests := []struct {
+		name        string
+		call        func() (*mcp.CallToolResult, any, error)
+		wantContent string
+	}{
+		{"get_actor_state", func() (*mcp.CallToolResult, any, error) {
+			return getActorStateTool(context.Background(), &mcp.CallToolRequest{}, GetActorStateArgs{ActorType: "cart", ActorID: "user-1"})
+		}, "actorType, actorID and key are required"},
+		{"register_actor_reminder", func() (*mcp.CallToolResult, any, error) {
+			return registerActorReminderTool(context.Background(), &mcp.CallToolRequest{}, RegisterActorReminderArgs{ActorType: "cart", Name: "checkout-timeout"})
+		}, "actorType, actorID and name are required"},
+		{"unregister_actor_reminder", func() (*mcp.CallToolResult, any, error) {
+			return unregisterActorReminderTool(context.Background(), &mcp.CallToolRequest{}, UnregisterActorScheduleArgs{ActorID: "user-1", Name: "checkout-timeout"})
+		}, "actorType, actorID and name are required"},
+		{"register_actor_timer", func() (*mcp.CallToolResult, any, error) {
+			return registerActorTimerTool(context.Background(), &mcp.CallToolRequest{}, RegisterActorTimerArgs{ActorType: "cart", ActorID: "user-1", Name: "refresh-prices"})
+		}, "actorType, actorID, name and callback are required"},
+		{"unregister_actor_timer", func() (*mcp.CallToolResult, any, error) {
+			return unregisterActorTimerTool(context.Background(), &mcp.CallToolRequest{}, UnregisterActorScheduleArgs{ActorType: "cart", ActorID: "user-1"})
+		}, "actorType, actorID and name are required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockActor := new(mockActorClient)
+			actorClient = mockActor
+
+			result, structured, err := tt.call()
+
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Nil(t, structured)
+			assert.Equal(t, tt.wantContent, result.Content[0].(*mcp.TextContent).Text)
+			mockActor.AssertNotCalled(t, "GetActorState", mock.Anything, mock.Anything)
+			mockActor.AssertNotCalled(t, "RegisterActorReminder", mock.Anything, mock.Anything)
+			mockActor.AssertNotCalled(t, "UnregisterActorReminder", mock.Anything, mock.Anything)
+			mockActor.AssertNotCalled(t, "RegisterActorTimer", mock.Anything, mock.Anything)
+			mockActor.AssertNotCalled(t, "UnregisterActorTimer", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package actors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+type GetActorStateArgs struct {
+	ActorType string `json:"actorType" jsonschema:"The registered actor type (e.g., 'cart')."`
+	ActorID   string `json:"actorID" jsonschema:"The unique ID of the actor instance (e.g., 'user-1001')."`
+	Key       string `json:"key" jsonschema:"The state key saved by the actor (e.g., 'items')."`
+}
+
+func getActorStateTool(ctx context.Context, req *mcp.CallToolRequest, args GetActorStateArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "get_actor_state")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "get_actor_state"),
+		attribute.String("dapr.actor_type", args.ActorType),
+	)
+
+	if args.ActorType == "" || args.ActorID == "" || args.Key == "" {
+		return invalidArgumentResult(errors.New("actorType, actorID and key are required"))
+	}
+
+	resp, err := actorClient.GetActorState(ctx, &dapr.GetActorStateRequest{
+		ActorType: args.ActorType,
+		ActorID:   args.ActorID,
+		KeyName:   args.Key,
+	})
+	if err != nil {
+		log.Printf("Dapr GetActorState failed: %v", err)
+		toolErrorMessage := fmt.Errorf("dapr GetActorState failed: %w", err).Error()
+		return toolcall.WithStatus(&mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
+			IsError: true,
+		}, err), nil, nil
+	}
+
+	structuredResult := map[string]interface{}{
+		"actor_type": args.ActorType,
+		"actor_id":   args.ActorID,
+		"key":        args.Key,
+		"found":      len(resp.Data) > 0,
+	}
+	if len(resp.Data) == 0 {
+		message := fmt.Sprintf("Actor '%s' of type '%s' has no state under key '%s'.", args.ActorID, args.ActorType, args.Key)
+		log.Println(message)
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: message}},
+		}, structuredResult, nil
+	}
+
+	var value any
+	if err := json.Unmarshal(resp.Data, &value); err == nil {
+		structuredResult["value"] = value
+	} else {
+		structuredResult["value"] = string(resp.Data)
+	}
+
+	message := fmt.Sprintf("Successfully read key '%s' of actor '%s' of type '%s'.", args.Key, args.ActorID, args.ActorType)
+	log.Println(message)
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message + "\n\nValue:\n" + string(resp.Data)}},
+	}, structuredResult, nil
+}
@@ -0,0 +1,81 @@
+package actors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetActorStateTool(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           []byte
+		err            error
+		wantErr        bool
+		wantContent    string
+		wantStructured map[string]interface{}
+	}{
+		{
+			name:        "JSON state",
+			data:        []byte(`{"items": 2}`),
+			wantContent: "Successfully read key 'cart' of actor 'user-1' of type 'shopping-cart'.",
+			wantStructured: map[string]interface{}{
+				"actor_type": "shopping-cart", "actor_id": "user-1", "key": "cart", "found": true,
+				"value": map[string]interface{}{"items": float64(2)},
+			},
+		},
+		{
+			name: "text state",
+			data: []byte("pending"),
+			wantStructured: map[string]interface{}{
+				"actor_type": "shopping-cart", "actor_id": "user-1", "key": "cart", "found": true, "value": "pending",
+			},
+		},
+		{
+			name:        "missing key",
+			wantContent: "has no state under key 'cart'",
+			wantStructured: map[string]interface{}{
+				"actor_type": "shopping-cart", "actor_id": "user-1", "key": "cart", "found": false,
+			},
+		},
+		{
+			name:        "sidecar error",
+			err:         errors.New("actor type not registered"),
+			wantErr:     true,
+			wantContent: "dapr GetActorState failed: actor type not registered",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockActor := new(mockActorClient)
+			var resp *dapr.GetActorStateResponse
+			if tt.err == nil {
+				resp = &dapr.GetActorStateResponse{Data: tt.data}
+			}
+			mockActor.On("GetActorState", mock.Anything, &dapr.GetActorStateRequest{ActorType: "shopping-cart", ActorID: "user-1", KeyName: "cart"}).
+				Return(resp, tt.err)
+			actorClient = mockActor
+
+			result, structured, err := getActorStateTool(context.Background(), &mcp.CallToolRequest{}, GetActorStateArgs{
+				ActorType: "shopping-cart",
+				ActorID:   "user-1",
+				Key:       "cart",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantErr, result.IsError)
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, tt.wantContent)
+			if tt.wantStructured != nil {
+				assert.Equal(t, tt.wantStructured, structured)
+			}
+			mockActor.AssertExpectations(t)
+		})
+	}
+}
@@ -2,17 +2,28 @@ package actors
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
 	dapr "github.com/dapr/go-sdk/client"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.opentelemetry.io/otel"
+
+	"github.com/dapr/dapr-mcp-server/pkg/dryrun"
+	"github.com/dapr/dapr-mcp-server/pkg/metadata"
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
 )
 
 // ActorClient defines the interface for actor operations.
 type ActorClient interface {
+	metadata.MetadataClient
 	InvokeActor(ctx context.Context, req *dapr.InvokeActorRequest) (*dapr.InvokeActorResponse, error)
+	GetActorState(ctx context.Context, req *dapr.GetActorStateRequest) (*dapr.GetActorStateResponse, error)
+	RegisterActorReminder(ctx context.Context, req *dapr.RegisterActorReminderRequest) error
+	UnregisterActorReminder(ctx context.Context, req *dapr.UnregisterActorReminderRequest) error
+	RegisterActorTimer(ctx context.Context, req *dapr.RegisterActorTimerRequest) error
+	UnregisterActorTimer(ctx context.Context, req *dapr.UnregisterActorTimerRequest) error
 }
 
 type InvokeActorMethodArgs struct {
@@ -20,6 +31,7 @@ type InvokeActorMethodArgs struct {
 	ActorID   string `json:"actorID" jsonschema:"The unique ID of the actor instance (e.g., 'user-1001')."`
 	Method    string `json:"method" jsonschema:"The method name on the actor to call (e.g., 'ProcessOrder')."`
 	Data      string `json:"data" jsonschema:"The payload to pass to the actor method (e.g., order details)."`
+	DryRun    bool   `json:"dryRun,omitempty" jsonschema:"If true, validate the request and describe what would be sent to the sidecar without invoking the actor."`
 }
 
 var actorClient ActorClient
@@ -28,6 +40,10 @@ func invokeActorMethodTool(ctx context.Context, req *mcp.CallToolRequest, args I
 	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "invoke_actor")
 	defer span.End()
 
+	if dryrun.Enabled(args.DryRun) {
+		return planInvokeActor(ctx, args)
+	}
+
 	actorReq := &dapr.InvokeActorRequest{
 		ActorType: args.ActorType,
 		ActorID:   args.ActorID,
@@ -39,10 +55,10 @@ func invokeActorMethodTool(ctx context.Context, req *mcp.CallToolRequest, args I
 	if err != nil {
 		log.Printf("Dapr InvokeActor failed: %v", err)
 		toolErrorMessage := fmt.Errorf("dapr InvokeActor failed: %w", err).Error()
-		return &mcp.CallToolResult{
+		return toolcall.WithStatus(&mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
 			IsError: true,
-		}, nil, nil
+		}, err), nil, nil
 	}
 
 	resultData := string(resp.Data)
@@ -64,11 +80,51 @@ func invokeActorMethodTool(ctx context.Context, req *mcp.CallToolRequest, args I
 	}, structuredResult, nil
 }
 
+// planInvokeActor describes the actor invocation without sending it. The actor
+// type is checked against the types hosted by this sidecar's app.
+func planInvokeActor(ctx context.Context, args InvokeActorMethodArgs) (*mcp.CallToolResult, any, error) {
+	if args.ActorType == "" || args.ActorID == "" || args.Method == "" {
+		return dryrun.Invalid("invoke_actor_method", errors.New("actorType, actorID and method are required"))
+	}
+
+	var warnings []string
+	meta, err := actorClient.GetMetadata(ctx)
+	if err != nil {
+		return dryrun.Invalid("invoke_actor_method", fmt.Errorf("failed to fetch Dapr metadata: %w", err))
+	}
+	hosted := false
+	for _, actor := range meta.ActiveActorsCount {
+		if actor.Type == args.ActorType {
+			hosted = true
+			break
+		}
+	}
+	if !hosted {
+		warnings = append(warnings, fmt.Sprintf("actor type '%s' is not hosted by this sidecar's app; the call only succeeds if another app in the cluster hosts it", args.ActorType))
+	}
+
+	return dryrun.Result(dryrun.Plan{
+		Tool:      "invoke_actor_method",
+		Operation: "InvokeActor",
+		Request: map[string]interface{}{
+			"actorType": args.ActorType,
+			"actorID":   args.ActorID,
+			"method":    args.Method,
+			"data":      args.Data,
+		},
+		Warnings: warnings,
+	})
+}
+
 func RegisterTools(server *mcp.Server, client ActorClient) {
 	actorClient = client
+	dryrun.Plans("invoke_actor_method")
 
 	isDestructive := true
+	notDestructive := false
+	isReadOnly := true
 	notReadOnly := false
+	isIdempotent := true
 	notIdempotent := false
 	isOpenWorld := true
 
@@ -82,7 +138,8 @@ func RegisterTools(server *mcp.Server, client ActorClient) {
 			"**ARGUMENT RULES:**\n" +
 			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `ActorType`, `ActorID`, `Method`, and `Data`.\n" +
 			"2. **NEVER INVENT**: You must NOT invent the `ActorType`, `ActorID`, or `Method` names; they must be provided by the user or discovered via another tool.\n" +
-			"3. **CLARIFICATION**: If any required input is missing, you MUST ask the user for clarification before generating the tool call.\n\n" +
+			"3. **CLARIFICATION**: If any required input is missing, you MUST ask the user for clarification before generating the tool call.\n" +
+			"4. **DRY RUN**: Set `DryRun` to preview the invocation without calling the actor.\n\n" +
 			"**DATA FORMAT**: The `Data` payload MUST be a single string (often JSON) representing the input parameters for the actor method.",
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: &isDestructive,
@@ -91,4 +148,90 @@ func RegisterTools(server *mcp.Server, client ActorClient) {
 			OpenWorldHint:   &isOpenWorld,
 		},
 	}, invokeActorMethodTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "get_actor_state",
+		Title: "Read Actor State",
+		Description: "Reads one key of a Dapr Virtual Actor's saved state without activating the actor or calling its methods. Use this tool to inspect what an actor has stored.\n\n" +
+			"**SCOPE**: This only works for actor types hosted by the app of the connected sidecar. For actors hosted by other apps the sidecar returns an error; call one of the actor's methods with `invoke_actor_method` instead.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Use `get_actor_types` to find the `ActorType`. The `Key` is the name the actor used when saving its state; it is defined by the actor's code.\n" +
+			"2. The result reports `found: false` when the actor has no state under the key.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `ActorType`, `ActorID`, and `Key`.\n" +
+			"2. **NEVER INVENT**: You must NOT invent the `ActorType`, `ActorID`, or `Key`; they must be provided by the user or discovered via another tool.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: &notDestructive,
+			ReadOnlyHint:    isReadOnly,
+			IdempotentHint:  isIdempotent,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, getActorStateTool)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "register_actor_reminder",
+		Title: "Register Actor Reminder",
+		Description: "Registers a persistent reminder that calls a Dapr Virtual Actor on a schedule, even after the actor is deactivated or the app restarts. **Registering a reminder with an existing name replaces it, and the replaced reminder's schedule and data are lost.**\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Use a reminder for work that must happen even if the actor is idle; use `register_actor_timer` for work tied to an active actor.\n" +
+			"2. `DueTime` delays the first call; `Period` repeats it, for example '1h', 'PT1H' or 'R5/PT1M' for five calls one minute apart. Omit `Period` for a single call.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `ActorType`, `ActorID`, and `Name`.\n" +
+			"2. **NEVER INVENT**: You must NOT invent the `ActorType` or `ActorID`; they must be provided by the user or discovered via another tool.\n" +
+			"3. **CLARIFICATION**: If the schedule is unclear, you MUST ask the user before registering the reminder.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: &isDestructive,
+			ReadOnlyHint:    notReadOnly,
+			IdempotentHint:  isIdempotent,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, registerActorReminderTool)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "unregister_actor_reminder",
+		Title: "Unregister Actor Reminder",
+		Description: "Deletes a Dapr Virtual Actor reminder so it is never called again. **This is a DESTRUCTIVE action: the reminder's schedule and data are lost.**\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `ActorType`, `ActorID`, and `Name`.\n" +
+			"2. **NEVER INVENT**: The reminder `Name` must be provided by the user; do NOT guess it.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: &isDestructive,
+			ReadOnlyHint:    notReadOnly,
+			IdempotentHint:  isIdempotent,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, unregisterActorReminderTool)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "register_actor_timer",
+		Title: "Register Actor Timer",
+		Description: "Registers a timer that calls a method of an active Dapr Virtual Actor on a schedule. Timers are not persisted: they stop when the actor is deactivated or the app restarts. **Registering a timer with an existing name replaces it, and the replaced timer's schedule and data are lost.**\n\n" +
+			"**SCOPE**: This only works for actors that are active on the app of the connected sidecar. For actors hosted by other apps, use `register_actor_reminder` instead.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. `Callback` is the actor method to call; it must exist on the actor.\n" +
+			"2. `DueTime` delays the first call; `Period` repeats it. Omit `Period` for a single call.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `ActorType`, `ActorID`, `Name`, and `Callback`.\n" +
+			"2. **NEVER INVENT**: You must NOT invent the `ActorType`, `ActorID`, or `Callback`; they must be provided by the user or discovered via another tool.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: &isDestructive,
+			ReadOnlyHint:    notReadOnly,
+			IdempotentHint:  isIdempotent,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, registerActorTimerTool)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "unregister_actor_timer",
+		Title: "Unregister Actor Timer",
+		Description: "Stops a Dapr Virtual Actor timer. **This is a DESTRUCTIVE action: the timer's schedule is lost.**\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `ActorType`, `ActorID`, and `Name`.\n" +
+			"2. **NEVER INVENT**: The timer `Name` must be provided by the user; do NOT guess it.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: &isDestructive,
+			ReadOnlyHint:    notReadOnly,
+			IdempotentHint:  isIdempotent,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, unregisterActorTimerTool)
 }
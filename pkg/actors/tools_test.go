@@ -9,6 +9,7 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/dapr/dapr-mcp-server/test/mocks"
 )
@@ -160,6 +161,29 @@ func TestRegisterTools(t *testing.T) {
 	assert.Equal(t, mockClient, actorClient)
 }
 
+func TestRegisterToolsMarksReplacingToolsDestructive(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil)
+	RegisterTools(server, new(mocks.MockDaprClient))
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	defer serverSession.Close()
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	destructive := map[string]bool{}
+	for tool, err := range session.Tools(ctx, nil) {
+		require.NoError(t, err)
+		destructive[tool.Name] = tool.Annotations.DestructiveHint != nil && *tool.Annotations.DestructiveHint
+	}
+	// Registering with an existing name replaces the reminder or timer.
+	assert.True(t, destructive["register_actor_reminder"])
+	assert.True(t, destructive["register_actor_timer"])
+}
+
 // mockActorClient implements ActorClient for testing
 type mockActorClient struct {
 	mock.Mock
@@ -173,6 +197,38 @@ func (m *mockActorClient) InvokeActor(ctx context.Context, req *dapr.InvokeActor
 	return args.Get(0).(*dapr.InvokeActorResponse), args.Error(1)
 }
 
+func (m *mockActorClient) GetMetadata(ctx context.Context) (*dapr.GetMetadataResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dapr.GetMetadataResponse), args.Error(1)
+}
+
+func (m *mockActorClient) GetActorState(ctx context.Context, req *dapr.GetActorStateRequest) (*dapr.GetActorStateResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dapr.GetActorStateResponse), args.Error(1)
+}
+
+func (m *mockActorClient) RegisterActorReminder(ctx context.Context, req *dapr.RegisterActorReminderRequest) error {
+	return m.Called(ctx, req).Error(0)
+}
+
+func (m *mockActorClient) UnregisterActorReminder(ctx context.Context, req *dapr.UnregisterActorReminderRequest) error {
+	return m.Called(ctx, req).Error(0)
+}
+
+func (m *mockActorClient) RegisterActorTimer(ctx context.Context, req *dapr.RegisterActorTimerRequest) error {
+	return m.Called(ctx, req).Error(0)
+}
+
+func (m *mockActorClient) UnregisterActorTimer(ctx context.Context, req *dapr.UnregisterActorTimerRequest) error {
+	return m.Called(ctx, req).Error(0)
+}
+
 func TestInvokeActorMethodToolWithInterfaceMock(t *testing.T) {
 	mockActor := new(mockActorClient)
 	mockActor.On("InvokeActor", mock.Anything, mock.AnythingOfType("*client.InvokeActorRequest")).
@@ -228,3 +284,38 @@ func TestInvokeActorMethodToolRequestContent(t *testing.T) {
 
 	mockActor.AssertExpectations(t)
 }
+
+func TestInvokeActorMethodDryRun(t *testing.T) {
+	mockActor := new(mockActorClient)
+	mockActor.On("GetMetadata", mock.Anything).Return(&dapr.GetMetadataResponse{
+		ActiveActorsCount: []*dapr.MetadataActiveActorsCount{{Type: "cart", Count: 2}},
+	}, nil)
+	actorClient = mockActor
+
+	result, _, err := invokeActorMethodTool(context.Background(), &mcp.CallToolRequest{}, InvokeActorMethodArgs{
+		ActorType: "payment-processor",
+		ActorID:   "user-1001",
+		Method:    "ProcessOrder",
+		Data:      `{"orderId": "order-123"}`,
+		DryRun:    true,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsError)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "'invoke_actor_method' would call InvokeActor")
+	assert.Contains(t, text, "actor type 'payment-processor' is not hosted by this sidecar's app")
+	mockActor.AssertNotCalled(t, "InvokeActor")
+}
+
+func TestInvokeActorMethodDryRunValidation(t *testing.T) {
+	mockActor := new(mockActorClient)
+	actorClient = mockActor
+
+	result, _, err := invokeActorMethodTool(context.Background(), &mcp.CallToolRequest{}, InvokeActorMethodArgs{ActorType: "cart", DryRun: true})
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "actorType, actorID and method are required")
+	mockActor.AssertNotCalled(t, "GetMetadata")
+}
@@ -0,0 +1,280 @@
+// Package approval asks the connected MCP client to confirm destructive tool
+// calls through elicitation before they are executed. Approval is enforced by
+// receiving middleware, so it applies to every tool, including tools generated
+// at runtime.
+package approval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dapr/dapr-mcp-server/pkg/dryrun"
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+// Fallback decides calls that require approval when the client cannot be asked.
+type Fallback string
+
+const (
+	// FallbackAllow executes the call without confirmation.
+	FallbackAllow Fallback = "allow"
+	// FallbackDeny rejects the call.
+	FallbackDeny Fallback = "deny"
+)
+
+// ErrNotApproved is returned when the user declined a call or could not be asked.
+var ErrNotApproved = errors.New("call was not approved")
+
+// DefaultTools are the tools that require approval when none are configured.
+var DefaultTools = []string{"delete_state", "execute_transaction", "decrypt_data", "get_bulk_secrets", "drain_dead_letters"}
+
+// Rule selects calls that require approval.
+type Rule struct {
+	// Tool is the tool name, or "*" for every tool not annotated read-only.
+	Tool string
+	// Component restricts the rule to one component. Empty matches any component.
+	Component string
+}
+
+// Config holds the approval configuration.
+type Config struct {
+	// Enabled determines if destructive calls require approval.
+	Enabled bool
+	// Rules select the calls that require approval.
+	Rules []Rule
+	// Fallback applies when the client does not support elicitation.
+	Fallback Fallback
+	// Timeout bounds how long to wait for the user's answer.
+	Timeout time.Duration
+}
+
+// DefaultConfig returns configuration from environment variables.
+func DefaultConfig() (Config, error) {
+	tools := os.Getenv("DAPR_MCP_SERVER_APPROVAL_TOOLS")
+	if tools == "" {
+		tools = strings.Join(DefaultTools, ",")
+	}
+
+	fallback := Fallback(strings.ToLower(os.Getenv("DAPR_MCP_SERVER_APPROVAL_FALLBACK")))
+	if fallback == "" {
+		fallback = FallbackDeny
+	}
+
+	timeout := 2 * time.Minute
+	if timeoutStr := os.Getenv("DAPR_MCP_SERVER_APPROVAL_TIMEOUT"); timeoutStr != "" {
+		d, err := time.ParseDuration(timeoutStr)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid DAPR_MCP_SERVER_APPROVAL_TIMEOUT '%s': %w", timeoutStr, err)
+		}
+		timeout = d
+	}
+
+	return Config{
+		Enabled:  os.Getenv("DAPR_MCP_SERVER_APPROVAL_ENABLED") == "true",
+		Rules:    ParseRules(tools),
+		Fallback: fallback,
+		Timeout:  timeout,
+	}, nil
+}
+
+// ParseRules parses a comma-separated list of "tool" or "tool:component" entries.
+func ParseRules(s string) []Rule {
+	var rules []Rule
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		tool, component, _ := strings.Cut(entry, ":")
+		rules = append(rules, Rule{Tool: strings.TrimSpace(tool), Component: strings.TrimSpace(component)})
+	}
+	return rules
+}
+
+// Validate validates the configuration.
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Fallback != FallbackAllow && c.Fallback != FallbackDeny {
+		return fmt.Errorf("approval fallback must be '%s' or '%s', got '%s'", FallbackAllow, FallbackDeny, c.Fallback)
+	}
+	if c.Timeout <= 0 {
+		return errors.New("approval timeout must be positive")
+	}
+	for _, rule := range c.Rules {
+		if rule.Tool == "" {
+			return errors.New("approval rule has an empty tool name")
+		}
+	}
+	return nil
+}
+
+// requires reports whether a call of tool on component needs approval.
+// readOnly reports whether the tool is annotated read-only, which exempts it
+// from "*" rules.
+func (c *Config) requires(tool, component string, readOnly func() bool) bool {
+	if !c.Enabled {
+		return false
+	}
+	for _, rule := range c.Rules {
+		if rule.Tool != tool && (rule.Tool != "*" || readOnly()) {
+			continue
+		}
+		if rule.Component == "" || rule.Component == component {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	mu     sync.RWMutex
+	config Config
+)
+
+// Configure sets the approval configuration used by every tool.
+func Configure(cfg Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	config = cfg
+}
+
+func current() Config {
+	mu.RLock()
+	defer mu.RUnlock()
+	return config
+}
+
+// maxSummaryBytes bounds the arguments shown to the user in an approval request.
+const maxSummaryBytes = 2048
+
+// Middleware asks the client to approve tools/call requests selected by the
+// configured rules before they reach the tool, and passes every other request
+// through. Calls that are not approved return Denied. Dry runs are not
+// executed, so they are never asked about.
+func Middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	annotations := toolcall.NewAnnotations()
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		call, ok := toolcall.Call(method, req)
+		if !ok {
+			return next(ctx, method, req)
+		}
+		if dryrun.Planning(ctx) {
+			return next(ctx, method, req)
+		}
+		tool := call.Params.Name
+		component := toolcall.Component(call.Params.Arguments)
+		cfg := current()
+		readOnly := func() bool { return annotations.ReadOnly(ctx, next, call) }
+		if !cfg.requires(tool, component, readOnly) {
+			return next(ctx, method, req)
+		}
+		if err := request(ctx, call, cfg, tool, component, summarize(call.Params.Arguments)); err != nil {
+			return Denied(err), nil
+		}
+		return next(ctx, method, req)
+	}
+}
+
+// summarize shows the call's arguments to the user.
+func summarize(arguments json.RawMessage) string {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, arguments, "", "  "); err != nil || indented.Len() == 0 || indented.String() == "{}" {
+		return "The call has no arguments."
+	}
+	summary := indented.String()
+	if len(summary) > maxSummaryBytes {
+		summary = strings.ToValidUTF8(summary[:maxSummaryBytes], "") + "\n... (truncated)"
+	}
+	return "Arguments:\n" + summary
+}
+
+// request asks the client to approve a call of tool on component. It returns
+// nil when the call may proceed and an error wrapping ErrNotApproved otherwise.
+// summary tells the user what the call is about to do.
+func request(ctx context.Context, req *mcp.CallToolRequest, cfg Config, tool, component, summary string) error {
+	if !supportsElicitation(req) {
+		if cfg.Fallback == FallbackAllow {
+			record(ctx, tool, component, "fallback_allowed")
+			return nil
+		}
+		record(ctx, tool, component, "fallback_denied")
+		return fmt.Errorf("%w: '%s' requires approval but the client does not support elicitation", ErrNotApproved, tool)
+	}
+
+	elicitCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	res, err := req.Session.Elicit(elicitCtx, &mcp.ElicitParams{
+		Message: approvalMessage(tool, component, summary),
+		RequestedSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"approve": map[string]any{
+					"type":        "boolean",
+					"title":       "Approve",
+					"description": "Run this operation against the Dapr sidecar.",
+				},
+			},
+			"required": []string{"approve"},
+		},
+	})
+	if err != nil {
+		record(ctx, tool, component, "failed")
+		return fmt.Errorf("%w: approval request for '%s' failed: %v", ErrNotApproved, tool, err)
+	}
+	// Only an explicit approve=true approves the call; a missing or malformed
+	// answer is treated as a refusal.
+	if res.Action != "accept" || res.Content["approve"] != true {
+		record(ctx, tool, component, "declined")
+		return fmt.Errorf("%w: the user declined '%s'", ErrNotApproved, tool)
+	}
+
+	record(ctx, tool, component, "approved")
+	return nil
+}
+
+func approvalMessage(tool, component, summary string) string {
+	if component == "" {
+		return fmt.Sprintf("Approve '%s'?\n\n%s", tool, summary)
+	}
+	return fmt.Sprintf("Approve '%s' on component '%s'?\n\n%s", tool, component, summary)
+}
+
+// Denied builds the tool error returned when a call was not approved.
+func Denied(err error) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}},
+		IsError: true,
+	}
+}
+
+func supportsElicitation(req *mcp.CallToolRequest) bool {
+	if req == nil || req.Session == nil {
+		return false
+	}
+	params := req.Session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+func record(ctx context.Context, tool, component, outcome string) {
+	trace.SpanFromContext(ctx).AddEvent("tool.approval", trace.WithAttributes(
+		attribute.String("tool.name", tool),
+		attribute.String("dapr.component", component),
+		attribute.String("approval.outcome", outcome),
+	))
+	log.Printf("Approval for '%s' on component '%s': %s", tool, component, outcome)
+}
@@ -0,0 +1,243 @@
+package approval
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr-mcp-server/pkg/dryrun"
+)
+
+func withConfig(t *testing.T, cfg Config) {
+	t.Helper()
+	Configure(cfg)
+	t.Cleanup(func() { Configure(Config{}) })
+}
+
+func TestParseRules(t *testing.T) {
+	rules := ParseRules(" delete_state , decrypt_data:vault,, *:prod-store ")
+
+	assert.Equal(t, []Rule{
+		{Tool: "delete_state"},
+		{Tool: "decrypt_data", Component: "vault"},
+		{Tool: "*", Component: "prod-store"},
+	}, rules)
+}
+
+func TestConfigRequires(t *testing.T) {
+	cfg := Config{Enabled: true, Rules: ParseRules("delete_state,decrypt_data:vault,*:prod-store")}
+	mutating := func() bool { return false }
+	readOnly := func() bool { return true }
+
+	assert.True(t, cfg.requires("delete_state", "statestore", mutating))
+	assert.True(t, cfg.requires("delete_state", "statestore", readOnly))
+	assert.True(t, cfg.requires("decrypt_data", "vault", mutating))
+	assert.False(t, cfg.requires("decrypt_data", "localstorage", mutating))
+	assert.True(t, cfg.requires("execute_transaction", "prod-store", mutating))
+	assert.False(t, cfg.requires("get_state", "prod-store", readOnly))
+	assert.False(t, cfg.requires("execute_transaction", "statestore", mutating))
+
+	cfg.Enabled = false
+	assert.False(t, cfg.requires("delete_state", "statestore", mutating))
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"disabled", Config{}, false},
+		{"valid", Config{Enabled: true, Fallback: FallbackDeny, Timeout: time.Minute, Rules: ParseRules("delete_state")}, false},
+		{"bad fallback", Config{Enabled: true, Fallback: "maybe", Timeout: time.Minute}, true},
+		{"zero timeout", Config{Enabled: true, Fallback: FallbackAllow}, true},
+		{"empty tool", Config{Enabled: true, Fallback: FallbackAllow, Timeout: time.Minute, Rules: []Rule{{Component: "x"}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDefaultConfig(t *testing.T) {
+	t.Setenv("DAPR_MCP_SERVER_APPROVAL_ENABLED", "true")
+	t.Setenv("DAPR_MCP_SERVER_APPROVAL_FALLBACK", "ALLOW")
+	t.Setenv("DAPR_MCP_SERVER_APPROVAL_TIMEOUT", "30s")
+
+	cfg, err := DefaultConfig()
+
+	require.NoError(t, err)
+	assert.True(t, cfg.Enabled)
+	assert.Equal(t, FallbackAllow, cfg.Fallback)
+	assert.Equal(t, 30*time.Second, cfg.Timeout)
+	assert.Len(t, cfg.Rules, len(DefaultTools))
+
+	t.Setenv("DAPR_MCP_SERVER_APPROVAL_TIMEOUT", "2 minutes")
+	_, err = DefaultConfig()
+	assert.ErrorContains(t, err, "invalid DAPR_MCP_SERVER_APPROVAL_TIMEOUT '2 minutes'")
+}
+
+// newServer returns a server running Middleware with a destructive
+// delete_state tool, a read-only get_state tool and a tool without
+// annotations, as generated by the service catalog.
+func newServer() *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	isDestructive := true
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "done"}}}, nil, nil
+	}
+	mcp.AddTool(server, &mcp.Tool{Name: "delete_state", Annotations: &mcp.ToolAnnotations{DestructiveHint: &isDestructive}}, handler)
+	mcp.AddTool(server, &mcp.Tool{Name: "get_state", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}, handler)
+	mcp.AddTool(server, &mcp.Tool{Name: "orders_createOrder"}, handler)
+	server.AddReceivingMiddleware(Middleware)
+	return server
+}
+
+// connect opens a client session on server. A nil handler leaves out the
+// elicitation capability.
+func connect(t *testing.T, server *mcp.Server, handler func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error)) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+	clientSession, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, &mcp.ClientOptions{ElicitationHandler: handler}).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = clientSession.Close() })
+	return clientSession
+}
+
+func callTool(t *testing.T, session *mcp.ClientSession, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	require.NoError(t, err)
+	return res
+}
+
+func TestMiddlewareFallback(t *testing.T) {
+	t.Run("not required", func(t *testing.T) {
+		withConfig(t, Config{Enabled: true, Fallback: FallbackDeny, Rules: ParseRules("delete_state")})
+		session := connect(t, newServer(), nil)
+		assert.False(t, callTool(t, session, "get_state", map[string]any{"storeName": "statestore"}).IsError)
+	})
+
+	t.Run("deny", func(t *testing.T) {
+		withConfig(t, Config{Enabled: true, Fallback: FallbackDeny, Rules: ParseRules("delete_state")})
+		session := connect(t, newServer(), nil)
+		res := callTool(t, session, "delete_state", map[string]any{"storeName": "statestore"})
+		assert.True(t, res.IsError)
+		assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "does not support elicitation")
+	})
+
+	t.Run("allow", func(t *testing.T) {
+		withConfig(t, Config{Enabled: true, Fallback: FallbackAllow, Rules: ParseRules("delete_state")})
+		session := connect(t, newServer(), nil)
+		assert.False(t, callTool(t, session, "delete_state", map[string]any{"storeName": "statestore"}).IsError)
+	})
+}
+
+func TestMiddlewareRules(t *testing.T) {
+	withConfig(t, Config{Enabled: true, Fallback: FallbackDeny, Rules: ParseRules("orders_createOrder,*:prod-store")})
+	session := connect(t, newServer(), nil)
+
+	// Any tool can be named, including tools generated at runtime.
+	assert.True(t, callTool(t, session, "orders_createOrder", map[string]any{"appID": "orders"}).IsError)
+	// "*" selects every tool not annotated read-only.
+	assert.True(t, callTool(t, session, "delete_state", map[string]any{"storeName": "prod-store"}).IsError)
+	assert.False(t, callTool(t, session, "get_state", map[string]any{"storeName": "prod-store"}).IsError)
+	assert.False(t, callTool(t, session, "delete_state", map[string]any{"storeName": "statestore"}).IsError)
+}
+
+func TestMiddlewareSkipsDryRuns(t *testing.T) {
+	withConfig(t, Config{Enabled: true, Fallback: FallbackDeny, Rules: ParseRules("delete_state")})
+	dryrun.Plans("delete_state")
+	server := newServer()
+	server.AddReceivingMiddleware(dryrun.Middleware)
+	session := connect(t, server, nil)
+
+	assert.False(t, callTool(t, session, "delete_state", map[string]any{"storeName": "statestore", "dryRun": true}).IsError)
+	assert.True(t, callTool(t, session, "delete_state", map[string]any{"storeName": "statestore"}).IsError)
+}
+
+func TestSummarize(t *testing.T) {
+	assert.Equal(t, "The call has no arguments.", summarize(nil))
+	assert.Equal(t, "The call has no arguments.", summarize([]byte(`{}`)))
+	assert.Equal(t, "Arguments:\n{\n  \"key\": \"order-1\"\n}", summarize([]byte(`{"key":"order-1"}`)))
+
+	summary := summarize([]byte(`{"value":"` + strings.Repeat("x", 3*maxSummaryBytes) + `"}`))
+	assert.Less(t, len(summary), maxSummaryBytes+64)
+	assert.True(t, strings.HasSuffix(summary, "... (truncated)"))
+}
+
+// callWithElicitation calls delete_state over an in-memory session whose
+// client answers every elicitation with result.
+func callWithElicitation(t *testing.T, result *mcp.ElicitResult) (string, *mcp.CallToolResult) {
+	t.Helper()
+	var message string
+	session := connect(t, newServer(), func(_ context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+		message = req.Params.Message
+		return result, nil
+	})
+	res := callTool(t, session, "delete_state", map[string]any{"storeName": "statestore", "key": "order-1"})
+	return message, res
+}
+func TestRequestElicitation(t *testing.T) {
+	withConfig(t, Config{Enabled: true, Fallback: FallbackAllow, Timeout: time.Minute, Rules: ParseRules("delete_state")})
+
+	t.Run("accepted", func(t *testing.T) {
+		message, res := callWithElicitation(t, &mcp.ElicitResult{Action: "accept", Content: map[string]any{"approve": true}})
+
+		assert.Contains(t, message, "Approve 'delete_state' on component 'statestore'?")
+		assert.Contains(t, message, `"key": "order-1"`)
+		assert.False(t, res.IsError)
+	})
+
+	t.Run("accepted with approve unchecked", func(t *testing.T) {
+		_, res := callWithElicitation(t, &mcp.ElicitResult{Action: "accept", Content: map[string]any{"approve": false}})
+
+		assert.True(t, res.IsError)
+		assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "declined")
+	})
+
+	t.Run("accepted without approve", func(t *testing.T) {
+		_, res := callWithElicitation(t, &mcp.ElicitResult{Action: "accept", Content: map[string]any{}})
+
+		assert.True(t, res.IsError)
+	})
+
+	t.Run("accepted with non-boolean approve", func(t *testing.T) {
+		for _, value := range []any{"true", 1.0, nil} {
+			_, res := callWithElicitation(t, &mcp.ElicitResult{Action: "accept", Content: map[string]any{"approve": value}})
+
+			assert.True(t, res.IsError, "%v", value)
+		}
+	})
+
+	t.Run("declined", func(t *testing.T) {
+		_, res := callWithElicitation(t, &mcp.ElicitResult{Action: "decline", Content: map[string]any{"approve": false}})
+
+		assert.True(t, res.IsError)
+		assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "the user declined 'delete_state'")
+	})
+
+	t.Run("declined without content", func(t *testing.T) {
+		// The SDK checks the answer against the requested schema, so a bare
+		// decline fails the request; either way the call is not approved.
+		_, res := callWithElicitation(t, &mcp.ElicitResult{Action: "decline"})
+
+		assert.True(t, res.IsError)
+		assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, ErrNotApproved.Error())
+	})
+}
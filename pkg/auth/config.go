@@ -25,6 +25,9 @@ type Config struct {
 
 	// DaprSentry configuration
 	DaprSentry DaprSentryConfig
+
+	// StatePolicyFile is the path to a JSON StatePolicy restricting state access (optional).
+	StatePolicyFile string
 }
 
 // OIDCConfig holds OIDC-specific configuration.
@@ -115,6 +118,8 @@ func DefaultConfig() Config {
 		OIDC:       oidcConfig,
 		SPIFFE:     spiffeConfig,
 		DaprSentry: daprSentryConfig,
+
+		StatePolicyFile: os.Getenv("AUTH_STATE_POLICY_FILE"),
 	}
 }
 
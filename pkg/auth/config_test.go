@@ -29,6 +29,7 @@ func clearAuthEnvVars() {
 		"DAPR_SENTRY_AUDIENCE",
 		"DAPR_SENTRY_TOKEN_HEADER",
 		"DAPR_SENTRY_JWKS_REFRESH_INTERVAL",
+		"AUTH_STATE_POLICY_FILE",
 	}
 	for _, v := range envVars {
 		os.Unsetenv(v)
@@ -63,6 +64,17 @@ func TestDefaultConfigWithEnvVars(t *testing.T) {
 	assert.Contains(t, cfg.SkipPaths, "/metrics")
 }
 
+func TestDefaultConfigStatePolicyFile(t *testing.T) {
+	clearAuthEnvVars()
+
+	os.Setenv("AUTH_STATE_POLICY_FILE", "/etc/dapr-mcp/state-policy.json")
+	defer clearAuthEnvVars()
+
+	cfg := DefaultConfig()
+
+	assert.Equal(t, "/etc/dapr-mcp/state-policy.json", cfg.StatePolicyFile)
+}
+
 func TestDefaultConfigAutoEnablesAuthMethod(t *testing.T) {
 	tests := []struct {
 		name             string
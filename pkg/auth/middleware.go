@@ -2,6 +2,7 @@
 package auth
 
 import (
+	"crypto/subtle"
 	"io"
 	"log/slog"
 	"net/http"
@@ -206,3 +207,23 @@ func safeTokenPrefix(token string) string {
 func NoopMiddleware(next http.Handler) http.Handler {
 	return next
 }
+
+// AppAPITokenHeader carries the app API token on requests the Dapr sidecar
+// sends to the app.
+const AppAPITokenHeader = "dapr-api-token"
+
+// AppAPITokenMiddleware only lets through requests carrying the app API token
+// (APP_API_TOKEN), which the sidecar sends when it calls the app. It protects
+// routes the sidecar delivers to, such as pubsub events, from other callers.
+func AppAPITokenMiddleware(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got := r.Header.Get(AppAPITokenHeader)
+			if token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				http.Error(w, "invalid or missing "+AppAPITokenHeader, http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -535,3 +535,30 @@ func TestMiddlewareHandler_BearerCaseInsensitive(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
+
+func TestAppAPITokenMiddleware(t *testing.T) {
+	handler := AppAPITokenMiddleware("app-secret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for token, want := range map[string]int{
+		"app-secret":  http.StatusOK,
+		"app-secret2": http.StatusUnauthorized,
+		"":            http.StatusUnauthorized,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/dapr/events/pubsub/orders", nil)
+		if token != "" {
+			req.Header.Set(AppAPITokenHeader, token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, want, rec.Code, token)
+	}
+
+	// Without a configured token every request is rejected.
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/dapr/events/pubsub/orders", nil)
+	req.Header.Set(AppAPITokenHeader, "")
+	AppAPITokenMiddleware("")(http.NotFoundHandler()).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
@@ -0,0 +1,227 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// StateOperation is a kind of state access checked by a StatePolicy.
+type StateOperation string
+
+const (
+	// StateRead covers get, bulk get and query operations.
+	StateRead StateOperation = "read"
+	// StateWrite covers save, bulk save and transactional upserts.
+	StateWrite StateOperation = "write"
+	// StateDelete covers delete operations, including transactional deletes.
+	StateDelete StateOperation = "delete"
+)
+
+// SubjectPlaceholder is replaced by the caller's subject in StateRule key patterns.
+const SubjectPlaceholder = "{subject}"
+
+const defaultRoleClaim = "roles"
+
+// ErrAccessDenied is returned when a policy does not allow an operation.
+var ErrAccessDenied = errors.New("access denied")
+
+// StatePolicy restricts which state stores and keys each identity may access.
+// Requests are denied unless at least one rule allows them.
+type StatePolicy struct {
+	// RoleClaim is the token claim holding the caller's roles (default: roles).
+	RoleClaim string `json:"roleClaim,omitempty"`
+	// Rules grant access to stores and keys.
+	Rules []StateRule `json:"rules"`
+}
+
+// StateRule grants operations on a set of stores and key patterns.
+// A rule with neither Subjects nor Roles applies to every caller,
+// including unauthenticated ones.
+type StateRule struct {
+	// Subjects are the identity subjects the rule applies to. "*" matches any authenticated identity.
+	Subjects []string `json:"subjects,omitempty"`
+	// Roles are the role names, read from the policy's role claim, the rule applies to.
+	Roles []string `json:"roles,omitempty"`
+	// Stores are the state store names the rule covers. "*" matches every store.
+	Stores []string `json:"stores"`
+	// Keys are key patterns. A trailing "*" matches any suffix and "{subject}"
+	// is replaced by the caller's subject. An empty list matches every key.
+	Keys []string `json:"keys,omitempty"`
+	// Operations are the allowed operations: read, write and delete.
+	Operations []StateOperation `json:"operations"`
+}
+
+// LoadStatePolicy reads a JSON state policy from path. An empty path returns
+// a nil policy, which allows all access.
+func LoadStatePolicy(path string) (*StatePolicy, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state policy: %w", err)
+	}
+
+	var policy StatePolicy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse state policy: %w", err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Validate validates the state policy.
+func (p *StatePolicy) Validate() error {
+	for i, rule := range p.Rules {
+		if len(rule.Stores) == 0 {
+			return fmt.Errorf("state policy rule %d: at least one store is required", i)
+		}
+		if len(rule.Operations) == 0 {
+			return fmt.Errorf("state policy rule %d: at least one operation is required", i)
+		}
+		for _, op := range rule.Operations {
+			switch op {
+			case StateRead, StateWrite, StateDelete:
+			default:
+				return fmt.Errorf("state policy rule %d: unsupported operation %q", i, op)
+			}
+		}
+		for _, pattern := range rule.Keys {
+			if strings.Contains(strings.TrimSuffix(pattern, "*"), "*") {
+				return fmt.Errorf("state policy rule %d: key pattern %q may only end with '*'", i, pattern)
+			}
+		}
+	}
+	return nil
+}
+
+// Authorize returns an error wrapping ErrAccessDenied unless a rule allows the
+// identity to perform op on key in store. A nil policy allows everything.
+func (p *StatePolicy) Authorize(id *Identity, op StateOperation, store, key string) error {
+	if p == nil {
+		return nil
+	}
+	for _, rule := range p.Rules {
+		if p.ruleApplies(rule, id, op, store) && rule.matchesKey(id, key) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s of key %q in state store %q is not allowed for %s", ErrAccessDenied, op, key, store, describeIdentity(id))
+}
+
+// AuthorizeStore returns an error wrapping ErrAccessDenied unless a rule allows
+// the identity to perform op on at least some keys in store.
+func (p *StatePolicy) AuthorizeStore(id *Identity, op StateOperation, store string) error {
+	if p == nil {
+		return nil
+	}
+	for _, rule := range p.Rules {
+		if p.ruleApplies(rule, id, op, store) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s on state store %q is not allowed for %s", ErrAccessDenied, op, store, describeIdentity(id))
+}
+
+func (p *StatePolicy) ruleApplies(rule StateRule, id *Identity, op StateOperation, store string) bool {
+	if !containsOperation(rule.Operations, op) || !matchesName(rule.Stores, store) {
+		return false
+	}
+	if len(rule.Subjects) == 0 && len(rule.Roles) == 0 {
+		return true
+	}
+	if id == nil {
+		return false
+	}
+	for _, subject := range rule.Subjects {
+		if subject == "*" || subject == id.Subject {
+			return true
+		}
+	}
+	roles := p.roles(id)
+	for _, role := range rule.Roles {
+		for _, have := range roles {
+			if role == have {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r StateRule) matchesKey(id *Identity, key string) bool {
+	if len(r.Keys) == 0 {
+		return true
+	}
+	for _, pattern := range r.Keys {
+		if strings.Contains(pattern, SubjectPlaceholder) {
+			if id == nil || id.Subject == "" {
+				continue
+			}
+			pattern = strings.ReplaceAll(pattern, SubjectPlaceholder, id.Subject)
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// roles returns the role names carried in the identity's role claim. The claim
+// may be a list of strings or a single space-separated string.
+func (p *StatePolicy) roles(id *Identity) []string {
+	claim := p.RoleClaim
+	if claim == "" {
+		claim = defaultRoleClaim
+	}
+	switch v := id.Claims[claim].(type) {
+	case string:
+		return strings.Fields(v)
+	case []string:
+		return v
+	case []interface{}:
+		roles := make([]string, 0, len(v))
+		for _, r := range v {
+			if s, ok := r.(string); ok {
+				roles = append(roles, s)
+			}
+		}
+		return roles
+	}
+	return nil
+}
+
+func containsOperation(ops []StateOperation, op StateOperation) bool {
+	for _, have := range ops {
+		if have == op {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesName(names []string, name string) bool {
+	for _, have := range names {
+		if have == "*" || have == name {
+			return true
+		}
+	}
+	return false
+}
+
+func describeIdentity(id *Identity) string {
+	if id == nil || id.Subject == "" {
+		return "unauthenticated caller"
+	}
+	return fmt.Sprintf("subject %q", id.Subject)
+}
@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStatePolicy() *StatePolicy {
+	return &StatePolicy{
+		RoleClaim: "groups",
+		Rules: []StateRule{
+			{
+				Subjects:   []string{"*"},
+				Stores:     []string{"statestore"},
+				Keys:       []string{"{subject}||*"},
+				Operations: []StateOperation{StateRead, StateWrite, StateDelete},
+			},
+			{
+				Roles:      []string{"admin"},
+				Stores:     []string{"*"},
+				Operations: []StateOperation{StateRead, StateWrite, StateDelete},
+			},
+			{
+				Stores:     []string{"public"},
+				Keys:       []string{"announcement"},
+				Operations: []StateOperation{StateRead},
+			},
+		},
+	}
+}
+
+func TestStatePolicyAuthorize(t *testing.T) {
+	alice := &Identity{Subject: "alice"}
+	admin := &Identity{Subject: "bob", Claims: map[string]interface{}{"groups": []interface{}{"dev", "admin"}}}
+
+	tests := []struct {
+		name    string
+		id      *Identity
+		op      StateOperation
+		store   string
+		key     string
+		allowed bool
+	}{
+		{name: "own prefix", id: alice, op: StateWrite, store: "statestore", key: "alice||cart", allowed: true},
+		{name: "other subject prefix", id: alice, op: StateRead, store: "statestore", key: "bob||cart", allowed: false},
+		{name: "other store", id: alice, op: StateRead, store: "orders", key: "alice||cart", allowed: false},
+		{name: "admin role any store", id: admin, op: StateDelete, store: "orders", key: "anything", allowed: true},
+		{name: "public read unauthenticated", id: nil, op: StateRead, store: "public", key: "announcement", allowed: true},
+		{name: "public write denied", id: nil, op: StateWrite, store: "public", key: "announcement", allowed: false},
+		{name: "unauthenticated has no subject prefix", id: nil, op: StateRead, store: "statestore", key: "||cart", allowed: false},
+	}
+
+	policy := testStatePolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Authorize(tt.id, tt.op, tt.store, tt.key)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrAccessDenied)
+			}
+		})
+	}
+}
+
+func TestStatePolicyAuthorizeStore(t *testing.T) {
+	policy := testStatePolicy()
+
+	assert.NoError(t, policy.AuthorizeStore(&Identity{Subject: "alice"}, StateRead, "statestore"))
+	assert.ErrorIs(t, policy.AuthorizeStore(&Identity{Subject: "alice"}, StateRead, "orders"), ErrAccessDenied)
+	assert.ErrorIs(t, policy.AuthorizeStore(nil, StateDelete, "public"), ErrAccessDenied)
+}
+
+func TestNilStatePolicyAllowsAll(t *testing.T) {
+	var policy *StatePolicy
+
+	assert.NoError(t, policy.Authorize(nil, StateDelete, "statestore", "key"))
+	assert.NoError(t, policy.AuthorizeStore(nil, StateWrite, "statestore"))
+}
+
+func TestStatePolicyRolesFromStringClaim(t *testing.T) {
+	policy := &StatePolicy{
+		Rules: []StateRule{{Roles: []string{"writer"}, Stores: []string{"*"}, Operations: []StateOperation{StateWrite}}},
+	}
+	id := &Identity{Subject: "svc", Claims: map[string]interface{}{"roles": "reader writer"}}
+
+	assert.NoError(t, policy.Authorize(id, StateWrite, "statestore", "key"))
+}
+
+func TestStatePolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    StateRule
+		wantErr string
+	}{
+		{name: "missing stores", rule: StateRule{Operations: []StateOperation{StateRead}}, wantErr: "at least one store"},
+		{name: "missing operations", rule: StateRule{Stores: []string{"*"}}, wantErr: "at least one operation"},
+		{name: "unknown operation", rule: StateRule{Stores: []string{"*"}, Operations: []StateOperation{"list"}}, wantErr: "unsupported operation"},
+		{name: "inner wildcard", rule: StateRule{Stores: []string{"*"}, Keys: []string{"a*b"}, Operations: []StateOperation{StateRead}}, wantErr: "may only end with"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &StatePolicy{Rules: []StateRule{tt.rule}}
+			assert.ErrorContains(t, policy.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoadStatePolicy(t *testing.T) {
+	policy, err := LoadStatePolicy("")
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+
+	path := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules":[{"subjects":["*"],"stores":["statestore"],"keys":["{subject}||*"],"operations":["read"]}]}`), 0o600))
+
+	policy, err = LoadStatePolicy(path)
+	require.NoError(t, err)
+	require.Len(t, policy.Rules, 1)
+	assert.Equal(t, []StateOperation{StateRead}, policy.Rules[0].Operations)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules":[{"stores":["statestore"],"operations":["purge"]}]}`), 0o600))
+	_, err = LoadStatePolicy(path)
+	assert.ErrorContains(t, err, "unsupported operation")
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
 )
 
 // BindingsClient defines the interface for bindings operations.
@@ -56,10 +58,10 @@ func invokeOutputBindingTool(ctx context.Context, req *mcp.CallToolRequest, args
 	if err != nil {
 		log.Printf("Dapr InvokeOutputBinding failed for binding %s: %v", args.BindingName, err)
 		toolErrorMessage := fmt.Sprintf("Failed to invoke binding '%s' with operation '%s'. Dapr Error: %v", args.BindingName, args.Operation, err)
-		return &mcp.CallToolResult{
+		return toolcall.WithStatus(&mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
 			IsError: true,
-		}, nil, nil
+		}, err), nil, nil
 	}
 
 	resultData := ""
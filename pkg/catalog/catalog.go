@@ -0,0 +1,556 @@
+// Package catalog discovers the HTTP operations of Dapr apps from their
+// OpenAPI documents, exposes them as MCP resources and optionally as tools.
+package catalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/dapr/dapr-mcp-server/pkg/invoke"
+	"github.com/dapr/dapr-mcp-server/pkg/resources"
+	"github.com/dapr/dapr-mcp-server/pkg/sidecar"
+)
+
+const (
+	// URIPrefix prefixes the URIs of app catalog resources.
+	URIPrefix = "dapr://catalog/"
+	// DefaultDocumentPath is the method an app serves its OpenAPI document on.
+	DefaultDocumentPath = "openapi.json"
+	// DefaultRefreshInterval is how often OpenAPI documents are fetched again.
+	DefaultRefreshInterval = 5 * time.Minute
+	// fetchTimeout bounds a single OpenAPI document fetch.
+	fetchTimeout = 30 * time.Second
+	// maxToolNameLength keeps generated tool names within client limits.
+	maxToolNameLength = 64
+	// bodyProperty is the tool argument holding an operation's request body.
+	bodyProperty = "body"
+)
+
+// App is a Dapr app whose OpenAPI document is served on Path.
+type App struct {
+	AppID string
+	Path  string
+}
+
+// Config configures the service catalog.
+type Config struct {
+	Apps            []App
+	RefreshInterval time.Duration
+	GenerateTools   bool
+	// ReservedTools are the names of tools generated tools must not replace,
+	// such as the server's built-in tools.
+	ReservedTools []string
+}
+
+// DefaultConfig reads the catalog configuration from the environment.
+func DefaultConfig() Config {
+	path := os.Getenv("DAPR_MCP_SERVER_CATALOG_PATH")
+	if path == "" {
+		path = DefaultDocumentPath
+	}
+
+	refresh := DefaultRefreshInterval
+	if refreshStr := os.Getenv("DAPR_MCP_SERVER_CATALOG_REFRESH_INTERVAL"); refreshStr != "" {
+		if d, err := time.ParseDuration(refreshStr); err == nil && d > 0 {
+			refresh = d
+		}
+	}
+
+	return Config{
+		Apps:            ParseApps(os.Getenv("DAPR_MCP_SERVER_CATALOG_APPS"), path),
+		RefreshInterval: refresh,
+		GenerateTools:   os.Getenv("DAPR_MCP_SERVER_CATALOG_TOOLS") == "true",
+	}
+}
+
+// ParseApps parses a comma-separated list of "appID" or "appID:path" entries.
+// Entries without a path use defaultPath.
+func ParseApps(s, defaultPath string) []App {
+	var apps []App
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		appID, path, _ := strings.Cut(entry, ":")
+		path = strings.TrimSpace(path)
+		if path == "" {
+			path = defaultPath
+		}
+		apps = append(apps, App{AppID: strings.TrimSpace(appID), Path: strings.TrimPrefix(path, "/")})
+	}
+	return apps
+}
+
+// URI returns the resource URI of an app's catalog.
+func URI(appID string) string {
+	return URIPrefix + url.PathEscape(appID)
+}
+
+type appCatalog struct {
+	app       App
+	document  *Document
+	digest    [sha256.Size]byte
+	fetchedAt time.Time
+	lastError string
+	tools     []string
+	skipped   []string
+}
+
+// Catalog fetches the OpenAPI documents of the configured apps through Dapr
+// service invocation. Each app is listed as a resource describing its
+// operations; when tool generation is enabled every operation also becomes a
+// tool calling the app through invoke_service. A document that fails to fetch
+// keeps its last good version.
+type Catalog struct {
+	server *mcp.Server
+	client invoke.InvokeClient
+	config Config
+
+	mu       sync.Mutex
+	apps     map[string]*appCatalog
+	reserved map[string]bool
+}
+
+// New creates a Catalog for the apps in config.
+func New(server *mcp.Server, client invoke.InvokeClient, config Config) *Catalog {
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = DefaultRefreshInterval
+	}
+	c := &Catalog{
+		server:   server,
+		client:   client,
+		config:   config,
+		apps:     make(map[string]*appCatalog, len(config.Apps)),
+		reserved: make(map[string]bool, len(config.ReservedTools)),
+	}
+	for _, app := range config.Apps {
+		c.apps[app.AppID] = &appCatalog{app: app}
+	}
+	for _, name := range config.ReservedTools {
+		c.reserved[name] = true
+	}
+	return c
+}
+
+// Run refreshes every document immediately and then on the configured
+// interval until ctx is done.
+func (c *Catalog) Run(ctx context.Context) {
+	c.Refresh(ctx)
+	ticker := time.NewTicker(c.config.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Refresh(ctx)
+		}
+	}
+}
+
+// Refresh fetches every app's document once.
+func (c *Catalog) Refresh(ctx context.Context) {
+	for _, app := range c.config.Apps {
+		c.refreshApp(ctx, app)
+	}
+}
+
+func (c *Catalog) refreshApp(ctx context.Context, app App) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "catalog_refresh")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "catalog_refresh"),
+		attribute.String("dapr.app_id", app.AppID),
+	)
+
+	data, err := c.fetch(ctx, app)
+	var doc *Document
+	if err == nil {
+		doc, err = ParseDocument(data)
+	}
+	uri := URI(app.AppID)
+
+	c.mu.Lock()
+	entry := c.apps[app.AppID]
+	if err != nil {
+		log.Printf("Failed to refresh OpenAPI document of app '%s': %v", app.AppID, err)
+		changed := entry.lastError != err.Error()
+		entry.lastError = err.Error()
+		c.mu.Unlock()
+		if changed {
+			c.notify(uri)
+		}
+		return
+	}
+	digest := sha256.Sum256(data)
+	changed := entry.document == nil || entry.digest != digest || entry.lastError != ""
+	entry.fetchedAt = time.Now().UTC()
+	entry.lastError = ""
+	if !changed {
+		c.mu.Unlock()
+		return
+	}
+	entry.document = doc
+	entry.digest = digest
+	var tools []generatedTool
+	var stale []string
+	if c.config.GenerateTools {
+		tools, stale = generateTools(entry, c.takenTools(app.AppID))
+	}
+	c.mu.Unlock()
+
+	for _, tool := range tools {
+		c.server.AddTool(tool.tool, operationHandler(app.AppID, tool.op))
+	}
+	if len(stale) > 0 {
+		c.server.RemoveTools(stale...)
+	}
+	log.Printf("Loaded %d operations from the OpenAPI document of app '%s'", len(doc.Operations), app.AppID)
+	c.notify(uri)
+}
+
+// fetch retrieves an app's OpenAPI document.
+func (c *Catalog) fetch(ctx context.Context, app App) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+	resp, err := c.client.InvokeService(ctx, &sidecar.InvokeRequest{
+		AppID:   app.AppID,
+		Method:  app.Path,
+		Verb:    http.MethodGet,
+		Headers: map[string]string{"Accept": "application/json, application/yaml;q=0.9"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch '%s': %w", app.Path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch '%s': status %d %s", app.Path, resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return resp.Body, nil
+}
+
+func (c *Catalog) notify(uri string) {
+	if err := c.server.ResourceUpdated(context.Background(), &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+		log.Printf("Failed to notify subscribers of '%s': %v", uri, err)
+	}
+}
+
+// generatedTool is a tool built from one operation of an app's document.
+type generatedTool struct {
+	tool *mcp.Tool
+	op   Operation
+}
+
+// takenTools returns the reserved tool names and the names of the tools
+// generated for apps other than appID. The caller must hold c.mu.
+func (c *Catalog) takenTools(appID string) map[string]bool {
+	taken := make(map[string]bool, len(c.reserved))
+	for name := range c.reserved {
+		taken[name] = true
+	}
+	for id, entry := range c.apps {
+		if id == appID {
+			continue
+		}
+		for _, name := range entry.tools {
+			taken[name] = true
+		}
+	}
+	return taken
+}
+
+// generateTools builds a tool for every operation of entry's document and
+// returns the names of previously generated tools that no longer exist.
+// Operations whose tool name is taken, or repeats an earlier operation's, are
+// skipped. The caller must hold c.mu.
+func generateTools(entry *appCatalog, taken map[string]bool) ([]generatedTool, []string) {
+	current := make(map[string]bool, len(entry.document.Operations))
+	var tools []generatedTool
+	var names []string
+	entry.skipped = nil
+	for _, op := range entry.document.Operations {
+		tool := operationTool(entry.app.AppID, op)
+		if current[tool.Name] || taken[tool.Name] {
+			log.Printf("Skipping operation '%s' of app '%s': tool '%s' already exists", op.OperationID, entry.app.AppID, tool.Name)
+			entry.skipped = append(entry.skipped, op.OperationID)
+			continue
+		}
+		current[tool.Name] = true
+		names = append(names, tool.Name)
+		tools = append(tools, generatedTool{tool: tool, op: op})
+	}
+
+	var stale []string
+	for _, name := range entry.tools {
+		if !current[name] {
+			stale = append(stale, name)
+		}
+	}
+	entry.tools = names
+	return tools, stale
+}
+
+// Subscribe accepts subscriptions to app catalogs; updates are sent whenever
+// a refresh changes an app's document or its error.
+func (c *Catalog) Subscribe(ctx context.Context, uri string) error {
+	if _, err := c.lookup(uri); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Unsubscribe is a no-op: catalog refreshes run whether or not anyone listens.
+func (c *Catalog) Unsubscribe(ctx context.Context, uri string) error {
+	return nil
+}
+
+func (c *Catalog) lookup(uri string) (string, error) {
+	appID, err := url.PathUnescape(strings.TrimPrefix(uri, URIPrefix))
+	if err != nil || !strings.HasPrefix(uri, URIPrefix) {
+		return "", fmt.Errorf("'%s' is not a catalog resource", uri)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.apps[appID]; !ok {
+		return "", fmt.Errorf("app '%s' is not in the catalog", appID)
+	}
+	return appID, nil
+}
+
+func (c *Catalog) readResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	appID, err := c.lookup(req.Params.URI)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(req.Params.URI)
+	}
+
+	c.mu.Lock()
+	entry := c.apps[appID]
+	result := map[string]interface{}{
+		"app_id":     appID,
+		"path":       entry.app.Path,
+		"operations": []Operation{},
+	}
+	if entry.document != nil {
+		result["title"] = entry.document.Title
+		result["version"] = entry.document.Version
+		result["operations"] = entry.document.Operations
+		result["fetched_at"] = entry.fetchedAt.Format(time.RFC3339)
+	}
+	if entry.lastError != "" {
+		result["last_error"] = entry.lastError
+	}
+	if len(entry.tools) > 0 {
+		result["tools"] = entry.tools
+	}
+	if len(entry.skipped) > 0 {
+		result["skipped_operations"] = entry.skipped
+	}
+	c.mu.Unlock()
+
+	return resources.JSONResult(req.Params.URI, result)
+}
+
+// toolName builds the name of the tool generated for an operation.
+func toolName(appID, operationID string) string {
+	name := strings.Trim(nonIdentifierChars.ReplaceAllString(appID+"_"+operationID, "_"), "_")
+	if len(name) > maxToolNameLength {
+		name = name[:maxToolNameLength]
+	}
+	return name
+}
+
+// operationTool describes an operation as a tool whose input schema holds
+// its path, query and header parameters, and its request body.
+func operationTool(appID string, op Operation) *mcp.Tool {
+	properties := make(map[string]any, len(op.Parameters)+1)
+	required := []string{}
+	for _, param := range op.Parameters {
+		if _, ok := properties[param.Name]; ok {
+			continue
+		}
+		schema := make(map[string]any, len(param.Schema)+1)
+		for k, v := range param.Schema {
+			schema[k] = v
+		}
+		description := param.Description
+		if description == "" {
+			description = fmt.Sprintf("The '%s' %s parameter.", param.Name, param.In)
+		}
+		schema["description"] = description
+		properties[param.Name] = schema
+		if param.Required || param.In == "path" {
+			required = append(required, param.Name)
+		}
+	}
+	if op.RequestContentType != "" {
+		if _, ok := properties[bodyProperty]; !ok {
+			body := op.RequestBody
+			if body == nil {
+				body = map[string]any{}
+			}
+			properties[bodyProperty] = body
+			if op.RequestRequired {
+				required = append(required, bodyProperty)
+			}
+		}
+	}
+	sort.Strings(required)
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	description := op.Summary
+	if op.Description != "" {
+		description = strings.TrimSpace(description + "\n\n" + op.Description)
+	}
+	description = strings.TrimSpace(fmt.Sprintf("Calls `%s %s` on the Dapr app '%s'. %s", op.Method, op.Path, appID, description))
+	if op.Deprecated {
+		description += "\n\n**DEPRECATED**: The service marks this operation as deprecated."
+	}
+
+	readOnly := op.Method == http.MethodGet || op.Method == http.MethodHead || op.Method == http.MethodOptions
+	destructive := op.Method == http.MethodDelete
+	idempotent := readOnly || op.Method == http.MethodPut || destructive
+	isOpenWorld := true
+	return &mcp.Tool{
+		Name:        toolName(appID, op.OperationID),
+		Title:       strings.TrimSpace(op.Summary),
+		Description: description,
+		InputSchema: schema,
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    readOnly,
+			DestructiveHint: &destructive,
+			IdempotentHint:  idempotent,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}
+}
+
+// operationHandler maps a generated tool's arguments onto a service invocation.
+func operationHandler(appID string, op Operation) mcp.ToolHandler {
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := map[string]any{}
+		if req.Params != nil && len(req.Params.Arguments) > 0 {
+			if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("invalid arguments: %v", err)}},
+					IsError: true,
+				}, nil
+			}
+		}
+
+		invokeArgs, err := invokeArgs(appID, op, args)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}},
+				IsError: true,
+			}, nil
+		}
+		result, structured, err := invoke.CallService(ctx, req, invokeArgs)
+		if result != nil && structured != nil {
+			result.StructuredContent = structured
+		}
+		return result, err
+	}
+}
+
+// invokeArgs substitutes path parameters into the operation's path and sends
+// query and header parameters and the body alongside it.
+func invokeArgs(appID string, op Operation, args map[string]any) (invoke.InvokeServiceArgs, error) {
+	path := op.Path
+	query := map[string]string{}
+	headers := map[string]string{}
+	for _, param := range op.Parameters {
+		value, ok := args[param.Name]
+		if !ok || value == nil {
+			if param.Required || param.In == "path" {
+				return invoke.InvokeServiceArgs{}, fmt.Errorf("missing required %s parameter '%s'", param.In, param.Name)
+			}
+			continue
+		}
+		formatted := formatParameter(value)
+		switch param.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", url.PathEscape(formatted))
+		case "query":
+			query[param.Name] = formatted
+		case "header":
+			headers[param.Name] = formatted
+		}
+	}
+
+	invokeArgs := invoke.InvokeServiceArgs{
+		AppID:       appID,
+		Method:      strings.TrimPrefix(path, "/"),
+		HTTPVerb:    op.Method,
+		QueryParams: query,
+		Metadata:    headers,
+	}
+	if body, ok := args[bodyProperty]; ok && op.RequestContentType != "" {
+		if s, isString := body.(string); isString && !strings.Contains(op.RequestContentType, "json") {
+			invokeArgs.Data = s
+		} else {
+			data, err := json.Marshal(body)
+			if err != nil {
+				return invoke.InvokeServiceArgs{}, fmt.Errorf("invalid request body: %w", err)
+			}
+			invokeArgs.Data = string(data)
+		}
+		invokeArgs.ContentType = op.RequestContentType
+	} else if op.RequestRequired {
+		return invoke.InvokeServiceArgs{}, fmt.Errorf("missing required request body '%s'", bodyProperty)
+	}
+	return invokeArgs, nil
+}
+
+// formatParameter renders a parameter value; arrays use the OpenAPI default
+// comma-separated form.
+func formatParameter(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatParameter(item)
+		}
+		return strings.Join(parts, ",")
+	case map[string]any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// RegisterResources lists each configured app's catalog as a subscribable resource.
+func RegisterResources(server *mcp.Server, router *resources.Router, c *Catalog) {
+	for _, app := range c.config.Apps {
+		server.AddResource(&mcp.Resource{
+			URI:         URI(app.AppID),
+			Name:        "catalog-" + app.AppID,
+			Title:       fmt.Sprintf("Operations of app '%s'", app.AppID),
+			Description: "The HTTP operations the app describes in its OpenAPI document, with their parameters and request body schemas. Subscribe to be notified when a refresh changes the document. Call them with invoke_service.",
+			MIMEType:    "application/json",
+		}, c.readResource)
+	}
+	router.Handle(URIPrefix, c)
+}
@@ -0,0 +1,224 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr-mcp-server/pkg/invoke"
+	"github.com/dapr/dapr-mcp-server/pkg/resources"
+	"github.com/dapr/dapr-mcp-server/pkg/sidecar"
+)
+
+// fakeInvokeClient serves documents by path and records every other request.
+type fakeInvokeClient struct {
+	documents map[string]string
+	err       error
+	requests  []*sidecar.InvokeRequest
+}
+
+func (f *fakeInvokeClient) InvokeService(ctx context.Context, req *sidecar.InvokeRequest) (*sidecar.InvokeResponse, error) {
+	if doc, ok := f.documents[req.AppID+"/"+req.Method]; ok {
+		if f.err != nil {
+			return nil, f.err
+		}
+		return &sidecar.InvokeResponse{StatusCode: http.StatusOK, ContentType: "application/json", Body: []byte(doc)}, nil
+	}
+	f.requests = append(f.requests, req)
+	return &sidecar.InvokeResponse{StatusCode: http.StatusOK, ContentType: "application/json", Body: []byte(`{"ok":true}`)}, nil
+}
+
+// connect returns a client session listing the server's tools and resources.
+func connect(t *testing.T, server *mcp.Server) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+	clientSession, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = clientSession.Close() })
+	return clientSession
+}
+
+func toolNames(t *testing.T, session *mcp.ClientSession) []string {
+	t.Helper()
+	res, err := session.ListTools(context.Background(), nil)
+	require.NoError(t, err)
+	var names []string
+	for _, tool := range res.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func readCatalog(t *testing.T, session *mcp.ClientSession, appID string) map[string]interface{} {
+	t.Helper()
+	res, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: URI(appID)})
+	require.NoError(t, err)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(res.Contents[0].Text), &body))
+	return body
+}
+
+func TestParseApps(t *testing.T) {
+	apps := ParseApps(" orders , payments:/v3/api-docs,,", DefaultDocumentPath)
+	assert.Equal(t, []App{
+		{AppID: "orders", Path: "openapi.json"},
+		{AppID: "payments", Path: "v3/api-docs"},
+	}, apps)
+	assert.Empty(t, ParseApps("", DefaultDocumentPath))
+}
+
+func TestDefaultConfig(t *testing.T) {
+	t.Setenv("DAPR_MCP_SERVER_CATALOG_APPS", "orders")
+	t.Setenv("DAPR_MCP_SERVER_CATALOG_PATH", "swagger.yaml")
+	t.Setenv("DAPR_MCP_SERVER_CATALOG_REFRESH_INTERVAL", "30s")
+	t.Setenv("DAPR_MCP_SERVER_CATALOG_TOOLS", "true")
+
+	config := DefaultConfig()
+	assert.Equal(t, []App{{AppID: "orders", Path: "swagger.yaml"}}, config.Apps)
+	assert.Equal(t, 30*time.Second, config.RefreshInterval)
+	assert.True(t, config.GenerateTools)
+}
+
+func TestCatalogResources(t *testing.T) {
+	client := &fakeInvokeClient{documents: map[string]string{"orders/openapi.json": ordersDocument}}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	router := resources.NewRouter()
+	c := New(server, client, Config{Apps: []App{{AppID: "orders", Path: "openapi.json"}}})
+	RegisterResources(server, router, c)
+	session := connect(t, server)
+
+	body := readCatalog(t, session, "orders")
+	assert.Empty(t, body["operations"], "nothing is listed before the first refresh")
+
+	c.Refresh(context.Background())
+	body = readCatalog(t, session, "orders")
+	assert.Equal(t, "Orders", body["title"])
+	assert.Len(t, body["operations"], 3)
+	assert.NotContains(t, body, "last_error")
+	assert.Empty(t, toolNames(t, session), "tools are only generated when enabled")
+
+	// A failed refresh keeps the last good document
+	client.err = errors.New("app unavailable")
+	c.Refresh(context.Background())
+	body = readCatalog(t, session, "orders")
+	assert.Len(t, body["operations"], 3)
+	assert.Contains(t, body["last_error"], "app unavailable")
+
+	require.NoError(t, router.Subscribe(context.Background(), &mcp.SubscribeRequest{Params: &mcp.SubscribeParams{URI: URI("orders")}}))
+	assert.Error(t, router.Subscribe(context.Background(), &mcp.SubscribeRequest{Params: &mcp.SubscribeParams{URI: URI("payments")}}))
+}
+
+func TestCatalogTools(t *testing.T) {
+	client := &fakeInvokeClient{documents: map[string]string{"orders/openapi.json": ordersDocument}}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	invoke.RegisterTools(server, client)
+	c := New(server, client, Config{Apps: []App{{AppID: "orders", Path: "openapi.json"}}, GenerateTools: true})
+	RegisterResources(server, resources.NewRouter(), c)
+	session := connect(t, server)
+	ctx := context.Background()
+
+	c.Refresh(ctx)
+	assert.ElementsMatch(t, []string{"invoke_service", "orders_createOrder", "orders_getOrder", "orders_deleteOrder"}, toolNames(t, session))
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "orders_getOrder", Arguments: map[string]any{
+		"orderId":  "a/1",
+		"expand":   []string{"items", "customer"},
+		"X-Tenant": "acme",
+	}})
+	require.NoError(t, err)
+	require.False(t, res.IsError, res.Content[0].(*mcp.TextContent).Text)
+	assert.Equal(t, float64(http.StatusOK), res.StructuredContent.(map[string]any)["status_code"])
+	require.Len(t, client.requests, 1)
+	got := client.requests[0]
+	assert.Equal(t, "orders/a%2F1", got.Method)
+	assert.Equal(t, "GET", got.Verb)
+	assert.Equal(t, "items,customer", got.Query.Get("expand"))
+	assert.Equal(t, "acme", got.Headers["X-Tenant"])
+
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "orders_createOrder", Arguments: map[string]any{
+		"body": map[string]any{"item": "book", "quantity": 2},
+	}})
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+	got = client.requests[1]
+	assert.Equal(t, "orders", got.Method)
+	assert.Equal(t, "POST", got.Verb)
+	assert.Equal(t, "application/json", got.ContentType)
+	assert.JSONEq(t, `{"item":"book","quantity":2}`, string(got.Body))
+
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "orders_createOrder", Arguments: map[string]any{}})
+	require.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "missing required request body")
+
+	// Operations removed from the document lose their tools
+	client.documents["orders/openapi.json"] = strings.Replace(ordersDocument, `"delete": {"operationId": "deleteOrder"}`, `"head": {"operationId": "orderExists"}`, 1)
+	c.Refresh(ctx)
+	assert.ElementsMatch(t, []string{"invoke_service", "orders_createOrder", "orders_getOrder", "orders_orderExists"}, toolNames(t, session))
+}
+
+func TestOperationTool(t *testing.T) {
+	doc, err := ParseDocument([]byte(ordersDocument))
+	require.NoError(t, err)
+
+	tool := operationTool("orders", doc.Operations[1])
+	assert.Equal(t, "orders_getOrder", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.False(t, *tool.Annotations.DestructiveHint)
+	schema := tool.InputSchema.(map[string]any)
+	assert.Equal(t, []string{"X-Tenant", "orderId"}, schema["required"])
+	assert.Contains(t, schema["properties"], "expand")
+	assert.NotContains(t, schema["properties"], "body")
+
+	tool = operationTool("orders", doc.Operations[2])
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.True(t, tool.Annotations.IdempotentHint)
+
+	assert.Equal(t, "my_app_list_orders", toolName("my.app", "list orders"))
+	assert.Len(t, toolName(strings.Repeat("a", 50), strings.Repeat("b", 50)), maxToolNameLength)
+}
+
+func TestCatalogToolNameConflicts(t *testing.T) {
+	document := func(operationIDs ...string) string {
+		paths := map[string]any{}
+		for _, id := range operationIDs {
+			paths["/"+id] = map[string]any{"get": map[string]any{"operationId": id}}
+		}
+		data, err := json.Marshal(map[string]any{"openapi": "3.0.0", "paths": paths})
+		require.NoError(t, err)
+		return string(data)
+	}
+	client := &fakeInvokeClient{documents: map[string]string{
+		"get/openapi.json":        document("state", "orders"),
+		"get_orders/openapi.json": document("list"),
+		"get.orders/openapi.json": document("list", "count"),
+	}}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	c := New(server, client, Config{
+		Apps:          []App{{AppID: "get", Path: "openapi.json"}, {AppID: "get_orders", Path: "openapi.json"}, {AppID: "get.orders", Path: "openapi.json"}},
+		GenerateTools: true,
+		ReservedTools: []string{"get_state"},
+	})
+	RegisterResources(server, resources.NewRouter(), c)
+	session := connect(t, server)
+
+	c.Refresh(context.Background())
+
+	// Reserved names and names generated for an earlier app are not replaced.
+	assert.ElementsMatch(t, []string{"get_orders", "get_orders_list", "get_orders_count"}, toolNames(t, session))
+	assert.Equal(t, []any{"state"}, readCatalog(t, session, "get")["skipped_operations"])
+	assert.NotContains(t, readCatalog(t, session, "get_orders"), "skipped_operations")
+	assert.Equal(t, []any{"list"}, readCatalog(t, session, "get.orders")["skipped_operations"])
+}
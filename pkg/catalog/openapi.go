@@ -0,0 +1,258 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxRefDepth bounds $ref expansion so recursive schemas stay finite.
+const maxRefDepth = 8
+
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+var nonIdentifierChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// Parameter is a path, query or header parameter of an operation.
+type Parameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Required    bool           `json:"required,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Schema      map[string]any `json:"schema,omitempty"`
+}
+
+// Operation is a single HTTP operation described by an app's OpenAPI document.
+type Operation struct {
+	OperationID        string         `json:"operation_id"`
+	Method             string         `json:"method"`
+	Path               string         `json:"path"`
+	Summary            string         `json:"summary,omitempty"`
+	Description        string         `json:"description,omitempty"`
+	Deprecated         bool           `json:"deprecated,omitempty"`
+	Parameters         []Parameter    `json:"parameters,omitempty"`
+	RequestContentType string         `json:"request_content_type,omitempty"`
+	RequestBody        map[string]any `json:"request_body,omitempty"`
+	RequestRequired    bool           `json:"request_body_required,omitempty"`
+}
+
+// Document is the part of an OpenAPI document the catalog keeps.
+type Document struct {
+	Title      string      `json:"title,omitempty"`
+	Version    string      `json:"version,omitempty"`
+	Operations []Operation `json:"operations"`
+}
+
+// ParseDocument parses an OpenAPI 3 or Swagger 2 document in JSON or YAML,
+// expanding local $ref schemas.
+func ParseDocument(data []byte) (*Document, error) {
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	root, ok := normalize(raw).(map[string]any)
+	if !ok {
+		return nil, errors.New("invalid OpenAPI document: not an object")
+	}
+	_, isOpenAPI := root["openapi"]
+	_, isSwagger := root["swagger"]
+	if !isOpenAPI && !isSwagger {
+		return nil, errors.New("invalid OpenAPI document: missing 'openapi' or 'swagger' version")
+	}
+
+	r := &resolver{root: root}
+	doc := &Document{Operations: []Operation{}}
+	if info, ok := root["info"].(map[string]any); ok {
+		doc.Title, _ = info["title"].(string)
+		if version, ok := info["version"]; ok && version != nil {
+			doc.Version = fmt.Sprint(version)
+		}
+	}
+
+	paths, _ := root["paths"].(map[string]any)
+	pathNames := make([]string, 0, len(paths))
+	for p := range paths {
+		pathNames = append(pathNames, p)
+	}
+	sort.Strings(pathNames)
+
+	for _, p := range pathNames {
+		item, ok := r.expand(paths[p], 0).(map[string]any)
+		if !ok {
+			continue
+		}
+		shared := r.parameters(item["parameters"])
+		for _, method := range httpMethods {
+			op, ok := item[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			doc.Operations = append(doc.Operations, r.operation(strings.ToUpper(method), p, op, shared))
+		}
+	}
+	return doc, nil
+}
+
+func (r *resolver) operation(method, path string, op map[string]any, shared []Parameter) Operation {
+	operation := Operation{Method: method, Path: path}
+	operation.OperationID, _ = op["operationId"].(string)
+	if operation.OperationID == "" {
+		operation.OperationID = strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(method)+" "+path, "_"), "_")
+	}
+	operation.Summary, _ = op["summary"].(string)
+	operation.Description, _ = op["description"].(string)
+	operation.Deprecated, _ = op["deprecated"].(bool)
+
+	// Operation parameters override path item parameters with the same name and location.
+	params := r.parameters(op["parameters"])
+	seen := make(map[string]bool, len(params))
+	for _, param := range params {
+		seen[param.In+"/"+param.Name] = true
+	}
+	for _, param := range shared {
+		if !seen[param.In+"/"+param.Name] {
+			params = append(params, param)
+		}
+	}
+	for _, param := range params {
+		switch param.In {
+		case "path", "query", "header":
+			operation.Parameters = append(operation.Parameters, param)
+		case "body":
+			// Swagger 2 describes the request body as a parameter.
+			operation.RequestContentType = "application/json"
+			operation.RequestBody = param.Schema
+			operation.RequestRequired = param.Required
+		}
+	}
+
+	if body, ok := r.expand(op["requestBody"], 0).(map[string]any); ok {
+		operation.RequestRequired, _ = body["required"].(bool)
+		content, _ := body["content"].(map[string]any)
+		contentTypes := make([]string, 0, len(content))
+		for ct := range content {
+			contentTypes = append(contentTypes, ct)
+		}
+		sort.Slice(contentTypes, func(i, j int) bool {
+			// Prefer JSON bodies, which the tools can build.
+			return strings.Contains(contentTypes[i], "json") && !strings.Contains(contentTypes[j], "json")
+		})
+		if len(contentTypes) > 0 {
+			operation.RequestContentType = contentTypes[0]
+			if media, ok := content[contentTypes[0]].(map[string]any); ok {
+				operation.RequestBody, _ = media["schema"].(map[string]any)
+			}
+		}
+	}
+	return operation
+}
+
+func (r *resolver) parameters(v any) []Parameter {
+	list, _ := r.expand(v, 0).([]any)
+	params := make([]Parameter, 0, len(list))
+	for _, item := range list {
+		p, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		param := Parameter{}
+		param.Name, _ = p["name"].(string)
+		param.In, _ = p["in"].(string)
+		param.Required, _ = p["required"].(bool)
+		param.Description, _ = p["description"].(string)
+		param.Schema, _ = p["schema"].(map[string]any)
+		if param.Schema == nil {
+			// Swagger 2 puts the type of non-body parameters on the parameter itself.
+			if typ, ok := p["type"].(string); ok {
+				param.Schema = map[string]any{"type": typ}
+			}
+		}
+		if param.Name != "" {
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// normalize converts YAML mappings with non-string keys, such as unquoted
+// response codes, into maps that encode as JSON objects.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = normalize(item)
+		}
+		return v
+	case map[any]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[fmt.Sprint(k)] = normalize(item)
+		}
+		return out
+	case []any:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	}
+	return v
+}
+
+// resolver expands local $ref pointers within a document.
+type resolver struct {
+	root map[string]any
+}
+
+// expand returns v with every local $ref replaced by a copy of its target.
+func (r *resolver) expand(v any, depth int) any {
+	switch v := v.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			if depth >= maxRefDepth {
+				return map[string]any{"description": fmt.Sprintf("Recursive reference to %s", ref)}
+			}
+			target, err := r.lookup(ref)
+			if err != nil {
+				return map[string]any{"description": err.Error()}
+			}
+			return r.expand(target, depth+1)
+		}
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = r.expand(item, depth)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = r.expand(item, depth)
+		}
+		return out
+	}
+	return v
+}
+
+// lookup resolves a local JSON pointer such as '#/components/schemas/Order'.
+func (r *resolver) lookup(ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported external reference %s", ref)
+	}
+	var node any = r.root
+	for _, token := range strings.Split(ref[2:], "/") {
+		token, _ = url.PathUnescape(token)
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolved reference %s", ref)
+		}
+		if node, ok = m[token]; !ok {
+			return nil, fmt.Errorf("unresolved reference %s", ref)
+		}
+	}
+	return node, nil
+}
@@ -0,0 +1,126 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ordersDocument = `{
+  "openapi": "3.0.3",
+  "info": {"title": "Orders", "version": "1.2.0"},
+  "paths": {
+    "/orders/{orderId}": {
+      "parameters": [
+        {"name": "orderId", "in": "path", "required": true, "schema": {"type": "string"}}
+      ],
+      "get": {
+        "operationId": "getOrder",
+        "summary": "Get an order",
+        "parameters": [
+          {"name": "expand", "in": "query", "schema": {"type": "array", "items": {"type": "string"}}},
+          {"name": "X-Tenant", "in": "header", "required": true, "schema": {"type": "string"}}
+        ]
+      },
+      "delete": {"operationId": "deleteOrder"}
+    },
+    "/orders": {
+      "post": {
+        "operationId": "createOrder",
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/xml": {"schema": {"type": "string"}},
+            "application/json": {"schema": {"$ref": "#/components/schemas/Order"}}
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Order": {
+        "type": "object",
+        "required": ["item"],
+        "properties": {
+          "item": {"type": "string"},
+          "quantity": {"type": "integer"},
+          "parent": {"$ref": "#/components/schemas/Order"}
+        }
+      }
+    }
+  }
+}`
+
+func TestParseDocument(t *testing.T) {
+	doc, err := ParseDocument([]byte(ordersDocument))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Orders", doc.Title)
+	assert.Equal(t, "1.2.0", doc.Version)
+	require.Len(t, doc.Operations, 3)
+
+	create := doc.Operations[0]
+	assert.Equal(t, "createOrder", create.OperationID)
+	assert.Equal(t, "POST", create.Method)
+	assert.Equal(t, "application/json", create.RequestContentType)
+	assert.True(t, create.RequestRequired)
+	assert.Equal(t, "object", create.RequestBody["type"])
+	properties := create.RequestBody["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "integer"}, properties["quantity"])
+	assert.Equal(t, "object", properties["parent"].(map[string]any)["type"], "recursive references are expanded up to a depth")
+
+	get := doc.Operations[1]
+	assert.Equal(t, "getOrder", get.OperationID)
+	assert.Equal(t, "GET", get.Method)
+	assert.Equal(t, "/orders/{orderId}", get.Path)
+	require.Len(t, get.Parameters, 3)
+	assert.Equal(t, Parameter{Name: "expand", In: "query", Schema: map[string]any{"type": "array", "items": map[string]any{"type": "string"}}}, get.Parameters[0])
+	assert.Equal(t, "orderId", get.Parameters[2].Name, "path item parameters are inherited")
+
+	assert.Equal(t, "deleteOrder", doc.Operations[2].OperationID)
+}
+
+func TestParseDocumentYAMLSwagger(t *testing.T) {
+	doc, err := ParseDocument([]byte(`
+swagger: "2.0"
+info:
+  title: Inventory
+  version: 3
+paths:
+  /items/{id}:
+    put:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          type: integer
+        - name: item
+          in: body
+          schema:
+            $ref: '#/definitions/Item'
+      responses:
+        200:
+          description: OK
+definitions:
+  Item:
+    type: object
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "3", doc.Version)
+	require.Len(t, doc.Operations, 1)
+	op := doc.Operations[0]
+	assert.Equal(t, "put_items_id", op.OperationID, "missing operation IDs are derived from the method and path")
+	assert.Equal(t, []Parameter{{Name: "id", In: "path", Required: true, Schema: map[string]any{"type": "integer"}}}, op.Parameters)
+	assert.Equal(t, "application/json", op.RequestContentType)
+	assert.Equal(t, map[string]any{"type": "object"}, op.RequestBody)
+}
+
+func TestParseDocumentErrors(t *testing.T) {
+	for _, data := range []string{"", "{not json", `{"info": {"title": "x"}}`, `["openapi"]`} {
+		_, err := ParseDocument([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
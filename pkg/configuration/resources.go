@@ -0,0 +1,281 @@
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/dapr/dapr-mcp-server/pkg/resources"
+)
+
+const (
+	// ItemURIPrefix prefixes the URIs of configuration item resources.
+	ItemURIPrefix = "dapr://configuration/"
+	// ItemURITemplate is the URI template of configuration item resources.
+	ItemURITemplate = ItemURIPrefix + "{store}/{key}"
+)
+
+// ItemURI returns the resource URI of a configuration item.
+func ItemURI(storeName, key string) string {
+	return ItemURIPrefix + url.PathEscape(storeName) + "/" + url.PathEscape(key)
+}
+
+// parseItemURI splits a configuration item resource URI into its store name and key.
+func parseItemURI(uri string) (string, string, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "dapr" || u.Host != "configuration" {
+		return "", "", fmt.Errorf("'%s' is not a configuration item resource", uri)
+	}
+	storeName, key, ok := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	if !ok || storeName == "" || key == "" {
+		return "", "", fmt.Errorf("'%s' is not a configuration item resource", uri)
+	}
+	return storeName, key, nil
+}
+
+const (
+	// DefaultRefreshInterval is how often the keys of configuration stores are
+	// listed again.
+	DefaultRefreshInterval = 5 * time.Minute
+)
+
+type itemFeed struct {
+	storeName string
+	key       string
+	refs      int
+	starting  bool
+	id        string
+}
+
+// Watcher exposes configuration items as MCP resources. While at least one
+// session is subscribed to an item resource, the watcher keeps a configuration
+// subscription open for the key and sends a resources/updated notification
+// every time the store reports a change.
+type Watcher struct {
+	server *mcp.Server
+	client ConfigurationClient
+
+	mu     sync.Mutex
+	feeds  map[string]*itemFeed
+	listed map[string]map[string]bool
+}
+
+// NewWatcher creates a Watcher reading items through client.
+func NewWatcher(server *mcp.Server, client ConfigurationClient) *Watcher {
+	return &Watcher{
+		server: server,
+		client: client,
+		feeds:  make(map[string]*itemFeed),
+		listed: make(map[string]map[string]bool),
+	}
+}
+
+// Run lists the keys of the stores every interval until ctx is done.
+func (w *Watcher) Run(ctx context.Context, storeNames []string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, storeName := range storeNames {
+				if err := w.ListItems(ctx, storeName); err != nil {
+					log.Printf("%v", err)
+				}
+			}
+		}
+	}
+}
+
+// ListItems lists every key of the store as a concrete resource, and removes
+// the resources of keys that no longer exist. Stores that cannot return all of
+// their keys are only reachable through the template.
+func (w *Watcher) ListItems(ctx context.Context, storeName string) error {
+	items, err := w.client.GetConfigurationItems(ctx, storeName, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list the keys of configuration store '%s': %w", storeName, err)
+	}
+
+	current := make(map[string]bool, len(items))
+	w.mu.Lock()
+	previous := w.listed[storeName]
+	w.listed[storeName] = current
+	w.mu.Unlock()
+
+	for _, item := range toItems(items) {
+		uri := ItemURI(storeName, item.Key)
+		current[uri] = true
+		if previous[uri] {
+			continue
+		}
+		w.server.AddResource(&mcp.Resource{
+			URI:         uri,
+			Name:        storeName + "/" + item.Key,
+			Title:       fmt.Sprintf("Configuration item '%s' in store '%s'", item.Key, storeName),
+			Description: "The current value, version and metadata of this configuration item.",
+			MIMEType:    "application/json",
+		}, w.readResource)
+	}
+	var removed []string
+	for uri := range previous {
+		if !current[uri] {
+			removed = append(removed, uri)
+		}
+	}
+	if len(removed) > 0 {
+		w.server.RemoveResources(removed...)
+	}
+	return nil
+}
+
+// Subscribe opens a configuration subscription for the item's key unless one
+// is already running. The resources.Router calls it once per subscribed
+// session, so refs counts sessions.
+func (w *Watcher) Subscribe(ctx context.Context, uri string) error {
+	storeName, key, err := parseItemURI(uri)
+	if err != nil {
+		return err
+	}
+	uri = ItemURI(storeName, key)
+
+	w.mu.Lock()
+	feed, ok := w.feeds[uri]
+	if !ok {
+		feed = &itemFeed{storeName: storeName, key: key, starting: true}
+		w.feeds[uri] = feed
+	}
+	feed.refs++
+	w.mu.Unlock()
+	if ok {
+		return nil
+	}
+
+	// The subscription is opened without holding the lock, so a slow store
+	// does not block other keys.
+	id, err := w.client.SubscribeConfigurationItems(context.Background(), storeName, []string{key}, func(_ string, _ map[string]*dapr.ConfigurationItem) {
+		w.notify(uri, feed)
+	})
+
+	w.mu.Lock()
+	feed.starting = false
+	if err != nil {
+		if w.feeds[uri] == feed {
+			delete(w.feeds, uri)
+		}
+		w.mu.Unlock()
+		return fmt.Errorf("failed to subscribe to key '%s' in configuration store '%s': %w", key, storeName, err)
+	}
+	feed.id = id
+	closed := w.feeds[uri] != feed
+	w.mu.Unlock()
+
+	if closed {
+		// Every subscriber left while the subscription was being opened.
+		w.closeSubscription(feed)
+		return nil
+	}
+	log.Printf("Configuration subscription '%s' opened for key '%s' in store '%s'", id, key, storeName)
+	return nil
+}
+
+// Unsubscribe closes the item's configuration subscription once no session is
+// subscribed.
+func (w *Watcher) Unsubscribe(ctx context.Context, uri string) error {
+	storeName, key, err := parseItemURI(uri)
+	if err != nil {
+		return err
+	}
+	uri = ItemURI(storeName, key)
+
+	w.mu.Lock()
+	feed, ok := w.feeds[uri]
+	if !ok {
+		w.mu.Unlock()
+		return nil
+	}
+	feed.refs--
+	if feed.refs > 0 {
+		w.mu.Unlock()
+		return nil
+	}
+	delete(w.feeds, uri)
+	starting := feed.starting
+	w.mu.Unlock()
+
+	// A subscription still being opened is closed by Subscribe once it is.
+	if !starting {
+		w.closeSubscription(feed)
+	}
+	return nil
+}
+
+func (w *Watcher) closeSubscription(feed *itemFeed) {
+	if err := w.client.UnsubscribeConfigurationItems(context.Background(), feed.storeName, feed.id); err != nil {
+		log.Printf("Closing configuration subscription '%s' failed: %v", feed.id, err)
+	}
+	log.Printf("Configuration subscription '%s' closed for key '%s' in store '%s'", feed.id, feed.key, feed.storeName)
+}
+
+// notify tells subscribed clients that the item changed, ignoring updates from
+// subscriptions that were already closed.
+func (w *Watcher) notify(uri string, feed *itemFeed) {
+	w.mu.Lock()
+	active := w.feeds[uri] == feed
+	w.mu.Unlock()
+	if !active {
+		return
+	}
+	if err := w.server.ResourceUpdated(context.Background(), &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+		log.Printf("Failed to notify subscribers of '%s': %v", uri, err)
+	}
+}
+
+func (w *Watcher) readResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	storeName, key, err := parseItemURI(req.Params.URI)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(req.Params.URI)
+	}
+
+	items, err := w.client.GetConfigurationItems(ctx, storeName, []string{key})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key '%s' from configuration store '%s': %w", key, storeName, err)
+	}
+
+	w.mu.Lock()
+	_, subscribed := w.feeds[ItemURI(storeName, key)]
+	w.mu.Unlock()
+
+	body := map[string]interface{}{
+		"store_name": storeName,
+		"key":        key,
+		"found":      false,
+		"subscribed": subscribed,
+	}
+	if item := items[key]; item != nil {
+		body["found"] = true
+		body["value"] = item.Value
+		body["version"] = item.Version
+		body["metadata"] = item.Metadata
+	}
+	return resources.JSONResult(req.Params.URI, body)
+}
+
+// RegisterResources exposes configuration items as MCP resources served by watcher.
+func RegisterResources(server *mcp.Server, router *resources.Router, watcher *Watcher) {
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: ItemURITemplate,
+		Name:        "configuration-item",
+		Title:       "Configuration Item",
+		Description: "A key in a Dapr configuration store, such as a feature flag. Read it to get the current value, version and metadata; subscribe to it to receive resources/updated notifications whenever the value changes.",
+		MIMEType:    "application/json",
+	}, watcher.readResource)
+	router.Handle(ItemURIPrefix, watcher)
+}
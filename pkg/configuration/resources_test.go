@@ -0,0 +1,225 @@
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr-mcp-server/pkg/resources"
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+)
+
+func readItem(t *testing.T, watcher *Watcher, uri string) map[string]interface{} {
+	t.Helper()
+	result, err := watcher.readResource(context.Background(), &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: uri}})
+	require.NoError(t, err)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(result.Contents[0].Text), &body))
+	return body
+}
+
+func TestParseItemURI(t *testing.T) {
+	storeName, key, err := parseItemURI(ItemURI("configstore", "features/checkout v2"))
+	require.NoError(t, err)
+	assert.Equal(t, "configstore", storeName)
+	assert.Equal(t, "features/checkout v2", key)
+
+	for _, uri := range []string{"dapr://configuration/configstore", "dapr://pubsub/pubsub/orders", "https://configuration/a/b"} {
+		_, _, err := parseItemURI(uri)
+		assert.Error(t, err, uri)
+	}
+}
+
+func TestWatcherReadResource(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	mockClient.On("GetConfigurationItems", mock.Anything, "configstore", []string{"flag"}, mock.Anything).
+		Return(map[string]*dapr.ConfigurationItem{"flag": {Value: "on", Version: "7"}}, nil).Once()
+	mockClient.On("GetConfigurationItems", mock.Anything, "configstore", []string{"flag"}, mock.Anything).
+		Return(map[string]*dapr.ConfigurationItem{}, nil).Once()
+	watcher := NewWatcher(mcp.NewServer(&mcp.Implementation{Name: "test"}, nil), mockClient)
+
+	body := readItem(t, watcher, ItemURI("configstore", "flag"))
+	assert.Equal(t, true, body["found"])
+	assert.Equal(t, "on", body["value"])
+	assert.Equal(t, "7", body["version"])
+	assert.Equal(t, false, body["subscribed"])
+
+	body = readItem(t, watcher, ItemURI("configstore", "flag"))
+	assert.Equal(t, false, body["found"])
+	assert.NotContains(t, body, "value")
+}
+
+func TestWatcherSubscription(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	var handler dapr.ConfigurationHandleFunction
+	mockClient.On("SubscribeConfigurationItems", mock.Anything, "configstore", []string{"flag"}, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { handler = args.Get(3).(dapr.ConfigurationHandleFunction) }).
+		Return("sub-1", nil).Once()
+	mockClient.On("UnsubscribeConfigurationItems", mock.Anything, "configstore", "sub-1", mock.Anything).Return(nil).Once()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	watcher := NewWatcher(server, mockClient)
+	uri := ItemURI("configstore", "flag")
+	ctx := context.Background()
+
+	require.NoError(t, watcher.Subscribe(ctx, uri))
+	require.NoError(t, watcher.Subscribe(ctx, uri))
+	mockClient.AssertNumberOfCalls(t, "SubscribeConfigurationItems", 1)
+	require.NotNil(t, handler)
+
+	require.NoError(t, watcher.Unsubscribe(ctx, uri))
+	mockClient.AssertNotCalled(t, "UnsubscribeConfigurationItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	require.NoError(t, watcher.Unsubscribe(ctx, uri))
+	mockClient.AssertExpectations(t)
+
+	// Updates arriving after the subscription was closed are ignored.
+	assert.NotPanics(t, func() { handler("sub-1", map[string]*dapr.ConfigurationItem{"flag": {Value: "off"}}) })
+	require.NoError(t, watcher.Unsubscribe(ctx, uri))
+}
+
+func TestWatcherUnsubscribeWhileOpening(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	release := make(chan struct{})
+	mockClient.On("SubscribeConfigurationItems", mock.Anything, "configstore", []string{"flag"}, mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { <-release }).
+		Return("sub-1", nil).Once()
+	mockClient.On("UnsubscribeConfigurationItems", mock.Anything, "configstore", "sub-1", mock.Anything).Return(nil).Once()
+	watcher := NewWatcher(mcp.NewServer(&mcp.Implementation{Name: "test"}, nil), mockClient)
+	uri := ItemURI("configstore", "flag")
+	ctx := context.Background()
+
+	done := make(chan error, 1)
+	go func() { done <- watcher.Subscribe(ctx, uri) }()
+	require.Eventually(t, func() bool {
+		watcher.mu.Lock()
+		defer watcher.mu.Unlock()
+		return watcher.feeds[uri] != nil
+	}, 5*time.Second, time.Millisecond)
+
+	// The lock is not held while the subscription is opened.
+	require.NoError(t, watcher.Unsubscribe(ctx, uri))
+	close(release)
+	require.NoError(t, <-done)
+
+	mockClient.AssertExpectations(t)
+	assert.Empty(t, watcher.feeds)
+}
+
+func TestWatcherListItems(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	mockClient.On("GetConfigurationItems", mock.Anything, "configstore", []string(nil), mock.Anything).
+		Return(map[string]*dapr.ConfigurationItem{"a": {Value: "1"}, "b": {Value: "2"}}, nil).Once()
+	mockClient.On("GetConfigurationItems", mock.Anything, "configstore", []string(nil), mock.Anything).
+		Return(map[string]*dapr.ConfigurationItem{"b": {Value: "2"}, "c": {Value: "3"}}, nil).Once()
+	mockClient.On("GetConfigurationItems", mock.Anything, "configstore", []string(nil), mock.Anything).
+		Return(nil, errors.New("not supported")).Once()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	watcher := NewWatcher(server, mockClient)
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	defer serverSession.Close()
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+	listed := func() []string {
+		res, err := session.ListResources(ctx, nil)
+		require.NoError(t, err)
+		var uris []string
+		for _, r := range res.Resources {
+			uris = append(uris, r.URI)
+		}
+		return uris
+	}
+
+	require.NoError(t, watcher.ListItems(ctx, "configstore"))
+	assert.ElementsMatch(t, []string{ItemURI("configstore", "a"), ItemURI("configstore", "b")}, listed())
+
+	// A refresh adds new keys and removes deleted ones.
+	require.NoError(t, watcher.ListItems(ctx, "configstore"))
+	assert.ElementsMatch(t, []string{ItemURI("configstore", "b"), ItemURI("configstore", "c")}, listed())
+
+	assert.ErrorContains(t, watcher.ListItems(ctx, "configstore"), "failed to list the keys of configuration store 'configstore'")
+}
+
+func TestWatcherSubscribeError(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	mockClient.On("SubscribeConfigurationItems", mock.Anything, "configstore", []string{"flag"}, mock.Anything, mock.Anything).
+		Return("", errors.New("store does not support subscriptions"))
+	watcher := NewWatcher(mcp.NewServer(&mcp.Implementation{Name: "test"}, nil), mockClient)
+
+	err := watcher.Subscribe(context.Background(), ItemURI("configstore", "flag"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to subscribe to key 'flag' in configuration store 'configstore'")
+	assert.Empty(t, watcher.feeds)
+}
+
+func TestWatcherNotifiesSubscribedClients(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	var handler dapr.ConfigurationHandleFunction
+	mockClient.On("SubscribeConfigurationItems", mock.Anything, "configstore", []string{"flag"}, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { handler = args.Get(3).(dapr.ConfigurationHandleFunction) }).
+		Return("sub-1", nil)
+	var unsubscribed atomic.Int32
+	mockClient.On("UnsubscribeConfigurationItems", mock.Anything, "configstore", "sub-1", mock.Anything).
+		Run(func(mock.Arguments) { unsubscribed.Add(1) }).
+		Return(nil)
+	mockClient.On("GetConfigurationItems", mock.Anything, "configstore", []string(nil), mock.Anything).
+		Return(map[string]*dapr.ConfigurationItem{"flag": {Value: "on"}}, nil)
+
+	router := resources.NewRouter()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, &mcp.ServerOptions{
+		SubscribeHandler:   router.Subscribe,
+		UnsubscribeHandler: router.Unsubscribe,
+	})
+	watcher := NewWatcher(server, mockClient)
+	RegisterResources(server, router, watcher)
+	require.NoError(t, watcher.ListItems(context.Background(), "configstore"))
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	defer serverSession.Close()
+	updated := make(chan string, 1)
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+	}).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+
+	listed, err := session.ListResources(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, listed.Resources, 1)
+	uri := listed.Resources[0].URI
+	assert.Equal(t, "dapr://configuration/configstore/flag", uri)
+
+	require.NoError(t, session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}))
+	handler("sub-1", map[string]*dapr.ConfigurationItem{"flag": {Value: "off", Version: "2"}})
+	select {
+	case got := <-updated:
+		assert.Equal(t, uri, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no resources/updated notification received")
+	}
+
+	require.NoError(t, session.Unsubscribe(ctx, &mcp.UnsubscribeParams{URI: uri}))
+	assert.Equal(t, int32(1), unsubscribed.Load())
+
+	// A session that disconnects without unsubscribing releases its subscription.
+	require.NoError(t, session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}))
+	require.NoError(t, session.Close())
+	assert.Eventually(t, func() bool { return unsubscribed.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,144 @@
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+// ConfigurationClient defines the interface for Configuration API operations.
+type ConfigurationClient interface {
+	GetConfigurationItems(ctx context.Context, storeName string, keys []string, opts ...dapr.ConfigurationOpt) (map[string]*dapr.ConfigurationItem, error)
+	SubscribeConfigurationItems(ctx context.Context, storeName string, keys []string, handler dapr.ConfigurationHandleFunction, opts ...dapr.ConfigurationOpt) (string, error)
+	UnsubscribeConfigurationItems(ctx context.Context, storeName string, id string, opts ...dapr.ConfigurationOpt) error
+}
+
+type GetConfigurationArgs struct {
+	StoreName string            `json:"storeName" jsonschema:"The name of the Dapr configuration store component (e.g., 'configstore')."`
+	Keys      []string          `json:"keys,omitempty" jsonschema:"The keys to retrieve. Leave empty to retrieve every key, if the store supports it."`
+	Metadata  map[string]string `json:"metadata,omitempty" jsonschema:"Optional store-specific metadata passed with the request."`
+}
+
+// Item is a configuration item as returned by tools and resources.
+type Item struct {
+	Key      string            `json:"key"`
+	Value    string            `json:"value"`
+	Version  string            `json:"version,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+var configurationClient ConfigurationClient
+
+func configurationError(operation string, err error) (*mcp.CallToolResult, any, error) {
+	log.Printf("Dapr %s failed: %v", operation, err)
+	toolErrorMessage := fmt.Errorf("dapr %s failed: %w", operation, err).Error()
+	return toolcall.WithStatus(&mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
+		IsError: true,
+	}, err), nil, nil
+}
+
+func invalidArgumentResult(err error) (*mcp.CallToolResult, any, error) {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}},
+		IsError: true,
+	}, nil, nil
+}
+
+// metadataOpts turns request metadata into configuration options.
+func metadataOpts(metadata map[string]string) []dapr.ConfigurationOpt {
+	opts := make([]dapr.ConfigurationOpt, 0, len(metadata))
+	for k, v := range metadata {
+		opts = append(opts, dapr.WithConfigurationMetadata(k, v))
+	}
+	return opts
+}
+
+// toItems converts the items returned by the sidecar, sorted by key.
+func toItems(items map[string]*dapr.ConfigurationItem) []Item {
+	result := make([]Item, 0, len(items))
+	for key, item := range items {
+		if item == nil {
+			continue
+		}
+		result = append(result, Item{Key: key, Value: item.Value, Version: item.Version, Metadata: item.Metadata})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+func getConfigurationTool(ctx context.Context, req *mcp.CallToolRequest, args GetConfigurationArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "get_configuration")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "get_configuration"),
+		attribute.String("dapr.store", args.StoreName),
+		attribute.Int("dapr.keys_count", len(args.Keys)),
+	)
+
+	if args.StoreName == "" {
+		return invalidArgumentResult(errors.New("storeName is required"))
+	}
+
+	items, err := configurationClient.GetConfigurationItems(ctx, args.StoreName, args.Keys, metadataOpts(args.Metadata)...)
+	if err != nil {
+		return configurationError("GetConfiguration", err)
+	}
+
+	found := toItems(items)
+	missing := []string{}
+	for _, key := range args.Keys {
+		if items[key] == nil {
+			missing = append(missing, key)
+		}
+	}
+
+	message := fmt.Sprintf("Successfully retrieved %d configuration item(s) from store '%s'.", len(found), args.StoreName)
+	if len(missing) > 0 {
+		message += fmt.Sprintf(" %d key(s) were not found.", len(missing))
+	}
+	log.Println(message)
+	structuredResult := map[string]interface{}{
+		"store_name": args.StoreName,
+		"items":      found,
+		"missing":    missing,
+	}
+	data, _ := json.MarshalIndent(found, "", "  ")
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message + "\n\nItems:\n" + string(data)}},
+	}, structuredResult, nil
+}
+
+// RegisterTools registers get_configuration.
+func RegisterTools(server *mcp.Server, client ConfigurationClient) {
+	configurationClient = client
+
+	isOpenWorld := true
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "get_configuration",
+		Title: "Get Configuration Items",
+		Description: "Retrieves items from a Dapr configuration store, such as feature flags or application settings, with each item's value, version and metadata.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Use `get_components` to find configuration stores (type `configuration.*`).\n" +
+			"2. To follow changes to a key, subscribe to its resource `dapr://configuration/{storeName}/{key}` instead of polling this tool.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `storeName`.\n" +
+			"2. **KEYS**: Provide `keys` to retrieve specific items. An empty list retrieves every item for stores that support it.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+			OpenWorldHint:  &isOpenWorld,
+		},
+	}, getConfigurationTool)
+}
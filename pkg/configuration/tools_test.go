@@ -0,0 +1,87 @@
+package configuration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+	"github.com/dapr/dapr-mcp-server/test/testutil"
+)
+
+func TestGetConfigurationTool(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	configurationClient = mockClient
+
+	var opts []dapr.ConfigurationOpt
+	mockClient.On("GetConfigurationItems", mock.Anything, "configstore", []string{"checkout.v2", "missing"}, mock.Anything).
+		Run(func(args mock.Arguments) { opts = args.Get(3).([]dapr.ConfigurationOpt) }).
+		Return(map[string]*dapr.ConfigurationItem{
+			"checkout.v2": {Value: "true", Version: "3", Metadata: map[string]string{"owner": "payments"}},
+		}, nil)
+
+	result, structured, err := getConfigurationTool(context.Background(), &mcp.CallToolRequest{}, GetConfigurationArgs{
+		StoreName: "configstore",
+		Keys:      []string{"checkout.v2", "missing"},
+		Metadata:  map[string]string{"partitionKey": "eu"},
+	})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, testutil.ResultText(t, result), "Successfully retrieved 1 configuration item(s) from store 'configstore'. 1 key(s) were not found.")
+
+	body := structured.(map[string]interface{})
+	assert.Equal(t, []Item{{Key: "checkout.v2", Value: "true", Version: "3", Metadata: map[string]string{"owner": "payments"}}}, body["items"])
+	assert.Equal(t, []string{"missing"}, body["missing"])
+
+	metadata := map[string]string{}
+	for _, opt := range opts {
+		opt(metadata)
+	}
+	assert.Equal(t, map[string]string{"partitionKey": "eu"}, metadata)
+}
+
+func TestGetConfigurationToolAllKeys(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	configurationClient = mockClient
+	mockClient.On("GetConfigurationItems", mock.Anything, "configstore", []string(nil), mock.Anything).
+		Return(map[string]*dapr.ConfigurationItem{"b": {Value: "2"}, "a": {Value: "1"}}, nil)
+
+	_, structured, err := getConfigurationTool(context.Background(), &mcp.CallToolRequest{}, GetConfigurationArgs{StoreName: "configstore"})
+	require.NoError(t, err)
+	items := structured.(map[string]interface{})["items"].([]Item)
+	require.Len(t, items, 2)
+	assert.Equal(t, "a", items[0].Key)
+	assert.Equal(t, "b", items[1].Key)
+}
+
+func TestGetConfigurationToolError(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	configurationClient = mockClient
+	mockClient.On("GetConfigurationItems", mock.Anything, "configstore", mock.Anything, mock.Anything).
+		Return(nil, errors.New("configuration store configstore not found"))
+
+	result, _, err := getConfigurationTool(context.Background(), &mcp.CallToolRequest{}, GetConfigurationArgs{StoreName: "configstore", Keys: []string{"a"}})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, testutil.ResultText(t, result), "dapr GetConfiguration failed: configuration store configstore not found")
+
+	result, _, err = getConfigurationTool(context.Background(), &mcp.CallToolRequest{}, GetConfigurationArgs{})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, "storeName is required", testutil.ResultText(t, result))
+}
+
+func TestRegisterTools(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil)
+
+	RegisterTools(server, mockClient)
+
+	assert.Equal(t, mockClient, configurationClient)
+}
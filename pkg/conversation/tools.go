@@ -12,6 +12,8 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.opentelemetry.io/otel"
 	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
 )
 
 // ConversationClient defines the interface for conversation operations.
@@ -102,10 +104,10 @@ func converseTool(ctx context.Context, req *mcp.CallToolRequest, args ConverseAr
 	if err != nil {
 		log.Printf("Dapr Converse failed: %v", err)
 		toolErrorMessage := fmt.Errorf("dapr API error while conversing with LLM '%s': %w", args.Name, err).Error()
-		return &mcp.CallToolResult{
+		return toolcall.WithStatus(&mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
 			IsError: true,
-		}, nil, nil
+		}, err), nil, nil
 	}
 
 	if len(resp.Outputs) == 0 {
@@ -10,6 +10,8 @@ import (
 	dapr "github.com/dapr/go-sdk/client"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.opentelemetry.io/otel"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
 )
 
 // CryptoClient defines the interface for cryptography operations.
@@ -46,10 +48,10 @@ func encryptTool(ctx context.Context, req *mcp.CallToolRequest, args EncryptArgs
 	if err != nil {
 		log.Printf("Dapr Encrypt failed: %v", err)
 		toolErrorMessage := fmt.Errorf("dapr Encrypt failed: %w", err).Error()
-		return &mcp.CallToolResult{
+		return toolcall.WithStatus(&mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
 			IsError: true,
-		}, nil, nil
+		}, err), nil, nil
 	}
 
 	cipherBuf, err := io.ReadAll(cipherStream)
@@ -92,10 +94,10 @@ func decryptTool(ctx context.Context, req *mcp.CallToolRequest, args DecryptArgs
 	if err != nil {
 		log.Printf("Dapr Decrypt failed: %v", err)
 		toolErrorMessage := fmt.Errorf("dapr Decrypt failed: %v", err).Error()
-		return &mcp.CallToolResult{
+		return toolcall.WithStatus(&mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
 			IsError: true,
-		}, nil, nil
+		}, err), nil, nil
 	}
 
 	plainBuf, err := io.ReadAll(plainStream)
@@ -0,0 +1,178 @@
+// Package dryrun lets mutating tools describe the request they would send to
+// the Dapr sidecar instead of sending it. Dry runs are enforced by receiving
+// middleware, so in dry-run mode no tool that is not annotated read-only is
+// executed, including tools generated at runtime.
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+var serverWide atomic.Bool
+
+var (
+	mu       sync.RWMutex
+	planners = make(map[string]bool)
+)
+
+// Plans records tools that plan their own calls: they accept a dryRun argument
+// and validate the request they would send when dry-run mode is on. Every
+// other tool is described from its arguments by Middleware.
+func Plans(tools ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, tool := range tools {
+		planners[tool] = true
+	}
+}
+
+func plans(tool string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return planners[tool]
+}
+
+type planningKey struct{}
+
+// Planning reports whether ctx belongs to a call that is being planned rather
+// than executed, so that other middleware can skip it.
+func Planning(ctx context.Context) bool {
+	planning, _ := ctx.Value(planningKey{}).(bool)
+	return planning
+}
+
+// Middleware keeps dry runs from reaching the sidecar. Calls of tools that plan
+// themselves are passed on, marked as planning when they are dry runs. In
+// dry-run mode, calls of every other tool not annotated read-only are not
+// executed; their plan shows the arguments they were called with.
+func Middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	annotations := toolcall.NewAnnotations()
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		call, ok := toolcall.Call(method, req)
+		if !ok {
+			return next(ctx, method, req)
+		}
+		tool := call.Params.Name
+		if plans(tool) {
+			if Enabled(requested(call.Params.Arguments)) {
+				ctx = context.WithValue(ctx, planningKey{}, true)
+			}
+			return next(ctx, method, req)
+		}
+		if !serverWide.Load() {
+			return next(ctx, method, req)
+		}
+		toolAnnotations, known := annotations.Lookup(ctx, next, call)
+		if !known || (toolAnnotations != nil && toolAnnotations.ReadOnlyHint) {
+			return next(ctx, method, req)
+		}
+
+		result, structured := render(Plan{
+			Tool:      tool,
+			Component: toolcall.Component(call.Params.Arguments),
+			Request:   call.Params.Arguments,
+			Warnings:  []string{fmt.Sprintf("'%s' cannot plan its calls, so its arguments were not validated", tool)},
+		})
+		result.StructuredContent = structured
+		return result, nil
+	}
+}
+
+// requested reports whether a call's arguments ask for a dry run.
+func requested(arguments json.RawMessage) bool {
+	var args struct {
+		DryRun bool `json:"dryRun"`
+	}
+	return json.Unmarshal(arguments, &args) == nil && args.DryRun
+}
+
+// SetEnabled turns dry-run mode on or off for every tool that supports it.
+func SetEnabled(enabled bool) {
+	serverWide.Store(enabled)
+}
+
+// Enabled reports whether a call should be planned rather than executed, either
+// because dry-run mode is on server-wide or because the call requested it.
+func Enabled(requested bool) bool {
+	return requested || serverWide.Load()
+}
+
+// Plan describes a sidecar call that was validated but not executed.
+type Plan struct {
+	// Tool is the MCP tool that produced the plan.
+	Tool string `json:"tool"`
+	// Operation is the Dapr API that would be called (e.g., DeleteState). It
+	// is empty for tools that cannot plan their calls.
+	Operation string `json:"operation,omitempty"`
+	// Component is the resolved Dapr component, if the operation targets one.
+	Component string `json:"component,omitempty"`
+	// ComponentType is the resolved component type (e.g., state.redis).
+	ComponentType string `json:"component_type,omitempty"`
+	// Request is the payload that would be sent.
+	Request any `json:"request"`
+	// Warnings are conditions that may make the real call fail.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Result builds the tool result returned in place of executing the call.
+func Result(plan Plan) (*mcp.CallToolResult, any, error) {
+	result, structured := render(plan)
+	return result, structured, nil
+}
+
+func render(plan Plan) (*mcp.CallToolResult, map[string]interface{}) {
+	request, err := json.MarshalIndent(plan.Request, "", "  ")
+	if err != nil {
+		request = []byte(fmt.Sprintf("%v", plan.Request))
+	}
+
+	var text strings.Builder
+	if plan.Operation != "" {
+		fmt.Fprintf(&text, "DRY RUN: nothing was sent to the Dapr sidecar. '%s' would call %s", plan.Tool, plan.Operation)
+	} else {
+		fmt.Fprintf(&text, "DRY RUN: nothing was sent to the Dapr sidecar. '%s' would be called", plan.Tool)
+	}
+	switch {
+	case plan.Component != "" && plan.ComponentType != "":
+		fmt.Fprintf(&text, " on component '%s' (%s)", plan.Component, plan.ComponentType)
+	case plan.Component != "":
+		fmt.Fprintf(&text, " on '%s'", plan.Component)
+	}
+	fmt.Fprintf(&text, " with:\n%s", request)
+	for _, warning := range plan.Warnings {
+		fmt.Fprintf(&text, "\nWARNING: %s", warning)
+	}
+	log.Printf("Dry run for '%s': %s", plan.Tool, plan.Operation)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: text.String()}},
+	}, map[string]interface{}{"dry_run": true, "plan": plan}
+}
+
+// Invalid builds the tool error returned when a dry run fails validation.
+func Invalid(tool string, err error) (*mcp.CallToolResult, any, error) {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("DRY RUN: '%s' would be rejected: %v", tool, err)}},
+		IsError: true,
+	}, map[string]interface{}{"dry_run": true}, nil
+}
+
+// Payload renders bytes for a plan: as a string when they are valid UTF-8, and
+// as a base64 JSON value otherwise.
+func Payload(data []byte) any {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	return data
+}
@@ -0,0 +1,113 @@
+package dryrun
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnabled(t *testing.T) {
+	t.Cleanup(func() { SetEnabled(false) })
+
+	assert.False(t, Enabled(false))
+	assert.True(t, Enabled(true))
+
+	SetEnabled(true)
+	assert.True(t, Enabled(false))
+}
+
+func TestResult(t *testing.T) {
+	result, structured, err := Result(Plan{
+		Tool:          "delete_state",
+		Operation:     "DeleteState",
+		Component:     "statestore",
+		ComponentType: "state.redis",
+		Request:       map[string]string{"key": "order-1"},
+		Warnings:      []string{"something may fail"},
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsError)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "DRY RUN: nothing was sent to the Dapr sidecar. 'delete_state' would call DeleteState on component 'statestore' (state.redis)")
+	assert.Contains(t, text, `"key": "order-1"`)
+	assert.Contains(t, text, "WARNING: something may fail")
+	resultMap := structured.(map[string]interface{})
+	assert.Equal(t, true, resultMap["dry_run"])
+	assert.Equal(t, "DeleteState", resultMap["plan"].(Plan).Operation)
+}
+
+func TestInvalid(t *testing.T) {
+	result, _, err := Invalid("release_lock", errors.New("storeName is required"))
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, "DRY RUN: 'release_lock' would be rejected: storeName is required", result.Content[0].(*mcp.TextContent).Text)
+}
+
+func TestPayload(t *testing.T) {
+	assert.Equal(t, "hello", Payload([]byte("hello")))
+	assert.Equal(t, []byte{0xff, 0x00}, Payload([]byte{0xff, 0x00}))
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Cleanup(func() { SetEnabled(false) })
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	isDestructive := true
+	var executed []string
+	planning := map[string]bool{}
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+		executed = append(executed, req.Params.Name)
+		planning[req.Params.Name] = Planning(ctx)
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "done"}}}, nil, nil
+	}
+	mcp.AddTool(server, &mcp.Tool{Name: "release_lock", Annotations: &mcp.ToolAnnotations{DestructiveHint: &isDestructive}}, handler)
+	mcp.AddTool(server, &mcp.Tool{Name: "orders_createOrder"}, handler)
+	mcp.AddTool(server, &mcp.Tool{Name: "get_state", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}, handler)
+	Plans("release_lock")
+	server.AddReceivingMiddleware(Middleware)
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	call := func(name string, args map[string]any) *mcp.CallToolResult {
+		res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: args})
+		require.NoError(t, err)
+		return res
+	}
+
+	// Tools that plan themselves are marked when the call asks for a dry run.
+	call("release_lock", map[string]any{"dryRun": true})
+	assert.True(t, planning["release_lock"])
+	call("release_lock", map[string]any{})
+	assert.False(t, planning["release_lock"])
+	call("orders_createOrder", map[string]any{"appID": "orders"})
+	assert.Equal(t, []string{"release_lock", "release_lock", "orders_createOrder"}, executed)
+
+	SetEnabled(true)
+	executed = nil
+	call("release_lock", map[string]any{})
+	assert.True(t, planning["release_lock"])
+	call("get_state", map[string]any{})
+
+	// Other tools not annotated read-only are described, not executed.
+	res := call("orders_createOrder", map[string]any{"appID": "orders", "body": "{}"})
+	assert.False(t, res.IsError)
+	text := res.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "DRY RUN: nothing was sent to the Dapr sidecar. 'orders_createOrder' would be called on 'orders' with:")
+	assert.Contains(t, text, "WARNING: 'orders_createOrder' cannot plan its calls")
+	structured := res.StructuredContent.(map[string]any)
+	assert.Equal(t, true, structured["dry_run"])
+	assert.Equal(t, "orders", structured["plan"].(map[string]any)["component"])
+	assert.Equal(t, []string{"release_lock", "get_state"}, executed)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -36,6 +37,9 @@ type HealthResponse struct {
 	Version string        `json:"version,omitempty"`
 }
 
+// CheckFunc reports the health of a dependency for the readiness probe.
+type CheckFunc func(ctx context.Context) []CheckResult
+
 // Handler provides health check HTTP handlers.
 type Handler struct {
 	daprClient  dapr.Client
@@ -43,6 +47,9 @@ type Handler struct {
 	version     string
 	ready       atomic.Bool
 	startupDone atomic.Bool
+
+	checksMu sync.RWMutex
+	checks   []CheckFunc
 }
 
 // NewHandler creates a new health handler.
@@ -77,6 +84,14 @@ func (h *Handler) SetStartupDone(done bool) {
 	h.startupDone.Store(done)
 }
 
+// AddCheck adds a check to the readiness probe. Results that are not
+// healthy mark the server as degraded without failing the probe.
+func (h *Handler) AddCheck(check CheckFunc) {
+	h.checksMu.Lock()
+	defer h.checksMu.Unlock()
+	h.checks = append(h.checks, check)
+}
+
 // LivenessHandler handles /livez requests.
 // Liveness probes should be simple - just check if the server is running.
 func (h *Handler) LivenessHandler(w http.ResponseWriter, r *http.Request) {
@@ -119,6 +134,18 @@ func (h *Handler) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	h.checksMu.RLock()
+	extraChecks := h.checks
+	h.checksMu.RUnlock()
+	for _, check := range extraChecks {
+		for _, result := range check(r.Context()) {
+			checks = append(checks, result)
+			if result.Status != StatusHealthy && overallStatus == StatusHealthy {
+				overallStatus = StatusDegraded
+			}
+		}
+	}
+
 	resp := HealthResponse{
 		Status:  overallStatus,
 		Checks:  checks,
@@ -360,3 +360,23 @@ func TestReadinessHandlerChecksContent(t *testing.T) {
 	assert.Equal(t, StatusHealthy, resp.Checks[0].Status)
 	assert.NotEmpty(t, resp.Checks[0].Latency)
 }
+
+func TestReadinessHandlerAddCheck(t *testing.T) {
+	handler := NewHandler(nil, "v1.0.0")
+	handler.AddCheck(func(ctx context.Context) []CheckResult {
+		return []CheckResult{{Status: StatusDegraded, Component: "circuit-breaker:statestore", Message: "circuit breaker open"}}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ReadinessHandler(rec, req)
+
+	var resp HealthResponse
+	err := json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, StatusDegraded, resp.Status)
+	assert.Len(t, resp.Checks, 1)
+	assert.Equal(t, "circuit-breaker:statestore", resp.Checks[0].Component)
+}
@@ -0,0 +1,408 @@
+package invoke
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+const (
+	// appIDMetadataKey routes a gRPC call through the sidecar to the target app.
+	appIDMetadataKey = "dapr-app-id"
+	// reflectionTTL is how long descriptors fetched through reflection are
+	// used before they are fetched again.
+	reflectionTTL = 10 * time.Minute
+)
+
+// reservedMetadataPrefixes are the gRPC metadata keys callers cannot set: the
+// sidecar routes calls on dapr- keys, and the others belong to the transport.
+var reservedMetadataPrefixes = []string{"dapr-", "grpc-", ":"}
+
+type InvokeGRPCServiceArgs struct {
+	AppID    string            `json:"appID" jsonschema:"The Dapr application ID of the gRPC service to call (e.g., 'order-processor')."`
+	Method   string            `json:"method" jsonschema:"The fully qualified gRPC method (e.g., 'orders.v1.OrderService/GetOrder')."`
+	Request  string            `json:"request,omitempty" jsonschema:"The request message as a JSON string in protobuf JSON form (e.g., '{\"orderId\": \"123\"}'). Default is an empty message."`
+	Metadata map[string]string `json:"metadata,omitempty" jsonschema:"Optional key-value pairs to send as gRPC metadata."`
+}
+
+// LoadDescriptorSet reads a serialized FileDescriptorSet, as written by
+// 'protoc --descriptor_set_out --include_imports'.
+func LoadDescriptorSet(path string) (*protoregistry.Files, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor set: %w", err)
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse descriptor set '%s': %w", path, err)
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor set '%s': %w", path, err)
+	}
+	return files, nil
+}
+
+// splitGRPCMethod splits 'pkg.Service/Method', '/pkg.Service/Method' or
+// 'pkg.Service.Method' into the service and method names.
+func splitGRPCMethod(fullMethod string) (protoreflect.FullName, protoreflect.Name, error) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	service, method, ok := strings.Cut(name, "/")
+	if !ok {
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			return "", "", fmt.Errorf("method '%s' must be fully qualified (e.g., 'pkg.Service/Method')", fullMethod)
+		}
+		service, method = name[:i], name[i+1:]
+	}
+	if !protoreflect.FullName(service).IsValid() || !protoreflect.Name(method).IsValid() {
+		return "", "", fmt.Errorf("method '%s' must be fully qualified (e.g., 'pkg.Service/Method')", fullMethod)
+	}
+	return protoreflect.FullName(service), protoreflect.Name(method), nil
+}
+
+func findMethod(files *protoregistry.Files, service protoreflect.FullName, method protoreflect.Name) (protoreflect.MethodDescriptor, bool) {
+	if files == nil {
+		return nil, false
+	}
+	d, err := files.FindDescriptorByName(service)
+	if err != nil {
+		return nil, false
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, false
+	}
+	md := sd.Methods().ByName(method)
+	return md, md != nil
+}
+
+// reflectedFiles are the file descriptors an app returned through reflection.
+// They are never modified once cached.
+type reflectedFiles struct {
+	files   map[string]*descriptorpb.FileDescriptorProto
+	fetched time.Time
+}
+
+// descriptorResolver finds method descriptors in the configured descriptor set
+// and, failing that, through gRPC reflection on the target app.
+type descriptorResolver struct {
+	conn  grpc.ClientConnInterface
+	files *protoregistry.Files
+
+	mu        sync.Mutex
+	reflected map[string]*reflectedFiles
+}
+
+func newDescriptorResolver(conn grpc.ClientConnInterface, files *protoregistry.Files) *descriptorResolver {
+	return &descriptorResolver{
+		conn:      conn,
+		files:     files,
+		reflected: make(map[string]*reflectedFiles),
+	}
+}
+
+func (r *descriptorResolver) resolve(ctx context.Context, appID string, service protoreflect.FullName, method protoreflect.Name) (protoreflect.MethodDescriptor, error) {
+	if md, ok := findMethod(r.files, service, method); ok {
+		return md, nil
+	}
+
+	r.mu.Lock()
+	cached := r.reflected[appID]
+	r.mu.Unlock()
+
+	// Descriptors that have not expired are reused, and kept when the app is
+	// asked for another service.
+	known := make(map[string]*descriptorpb.FileDescriptorProto)
+	fetched := time.Now()
+	if cached != nil && time.Since(cached.fetched) < reflectionTTL {
+		files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: fileList(cached.files)})
+		if err == nil {
+			if md, ok := findMethod(files, service, method); ok {
+				return md, nil
+			}
+		}
+		for name, fd := range cached.files {
+			known[name] = fd
+		}
+		fetched = cached.fetched
+	}
+
+	// The lock is not held during reflection, so a slow app does not block
+	// calls to other apps.
+	if err := r.reflect(ctx, appID, service, known); err != nil {
+		return nil, fmt.Errorf("no descriptor for service '%s' in the descriptor set, and reflection on app '%s' failed: %w", service, appID, err)
+	}
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: fileList(known)})
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptors from app '%s': %w", appID, err)
+	}
+	r.mu.Lock()
+	r.reflected[appID] = &reflectedFiles{files: known, fetched: fetched}
+	r.mu.Unlock()
+
+	md, ok := findMethod(files, service, method)
+	if !ok {
+		return nil, fmt.Errorf("app '%s' has no method '%s' on service '%s'", appID, method, service)
+	}
+	return md, nil
+}
+
+// forget drops the descriptors reflected from an app, so that the next call
+// fetches them again.
+func (r *descriptorResolver) forget(appID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.reflected, appID)
+}
+
+// reflect fetches the file defining service, and every file it depends on,
+// from the app's reflection service into known.
+func (r *descriptorResolver) reflect(ctx context.Context, appID string, service protoreflect.FullName, known map[string]*descriptorpb.FileDescriptorProto) error {
+	ctx, cancel := context.WithCancel(metadata.AppendToOutgoingContext(ctx, appIDMetadataKey, appID))
+	defer cancel()
+	stream, err := rpb.NewServerReflectionClient(r.conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.CloseSend() }()
+	// Placeholders mark dependencies that were requested but not returned.
+	defer func() {
+		for name, fd := range known {
+			if fd == nil {
+				delete(known, name)
+			}
+		}
+	}()
+
+	pending := []*rpb.ServerReflectionRequest{{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: string(service)},
+	}}
+	for len(pending) > 0 {
+		if err := stream.Send(pending[0]); err != nil {
+			return err
+		}
+		pending = pending[1:]
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return fmt.Errorf("%s", errResp.GetErrorMessage())
+		}
+		for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			var fd descriptorpb.FileDescriptorProto
+			if err := proto.Unmarshal(raw, &fd); err != nil {
+				return fmt.Errorf("invalid file descriptor: %w", err)
+			}
+			known[fd.GetName()] = &fd
+		}
+		for _, fd := range known {
+			for _, dep := range fd.GetDependency() {
+				if _, ok := known[dep]; ok {
+					continue
+				}
+				// Well-known types are linked into this binary.
+				if global, err := protoregistry.GlobalFiles.FindFileByPath(dep); err == nil {
+					known[dep] = protodesc.ToFileDescriptorProto(global)
+					continue
+				}
+				known[dep] = nil
+				pending = append(pending, &rpb.ServerReflectionRequest{
+					MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+				})
+			}
+		}
+	}
+	for name, fd := range known {
+		if fd == nil {
+			return fmt.Errorf("app did not return dependency '%s'", name)
+		}
+	}
+	return nil
+}
+
+func fileList(files map[string]*descriptorpb.FileDescriptorProto) []*descriptorpb.FileDescriptorProto {
+	list := make([]*descriptorpb.FileDescriptorProto, 0, len(files))
+	for _, fd := range files {
+		list = append(list, fd)
+	}
+	return list
+}
+
+var (
+	grpcConn           grpc.ClientConnInterface
+	grpcResolver       *descriptorResolver
+	errStreamingMethod = errors.New("streaming methods are not supported")
+)
+
+func invokeGRPCServiceTool(ctx context.Context, req *mcp.CallToolRequest, args InvokeGRPCServiceArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "invoke_grpc_service")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "invoke_grpc_service"),
+		attribute.String("dapr.app_id", args.AppID),
+		attribute.String("rpc.method", args.Method),
+	)
+
+	invalid := func(err error) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("invalid argument: %v", err)}},
+			IsError: true,
+		}, nil, nil
+	}
+	if args.AppID == "" {
+		return invalid(errors.New("appID is required"))
+	}
+	service, method, err := splitGRPCMethod(args.Method)
+	if err != nil {
+		return invalid(err)
+	}
+	for k := range args.Metadata {
+		key := strings.ToLower(k)
+		for _, prefix := range reservedMetadataPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				return invalid(fmt.Errorf("metadata key '%s' is reserved", k))
+			}
+		}
+	}
+
+	md, err := grpcResolver.resolve(ctx, args.AppID, service, method)
+	if err != nil {
+		log.Printf("Resolving gRPC method %s on app %s failed: %v", args.Method, args.AppID, err)
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to resolve gRPC method '%s': %v", args.Method, err)}},
+			IsError: true,
+		}, nil, nil
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return invalid(fmt.Errorf("'%s': %w", args.Method, errStreamingMethod))
+	}
+
+	in := dynamicpb.NewMessage(md.Input())
+	if strings.TrimSpace(args.Request) != "" {
+		if err := protojson.Unmarshal([]byte(args.Request), in); err != nil {
+			return invalid(fmt.Errorf("request does not match '%s': %w", md.Input().FullName(), err))
+		}
+	}
+
+	pairs := []string{appIDMetadataKey, args.AppID}
+	for k, v := range args.Metadata {
+		pairs = append(pairs, strings.ToLower(k), v)
+	}
+	callCtx := metadata.AppendToOutgoingContext(ctx, pairs...)
+	out := dynamicpb.NewMessage(md.Output())
+	fullMethod := fmt.Sprintf("/%s/%s", service, method)
+	var header metadata.MD
+	if err := grpcConn.Invoke(callCtx, fullMethod, in, out, grpc.Header(&header)); err != nil {
+		st := status.Convert(err)
+		span.SetAttributes(attribute.String("rpc.grpc.status_code", st.Code().String()))
+		if st.Code() == codes.Unimplemented {
+			// The app may have changed since its descriptors were reflected.
+			grpcResolver.forget(args.AppID)
+		}
+		log.Printf("gRPC invocation of %s on app %s failed: %v", fullMethod, args.AppID, err)
+		return toolcall.WithStatus(&mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("gRPC method '%s' on app '%s' failed with %s: %s", fullMethod, args.AppID, st.Code(), st.Message())}},
+			IsError: true,
+		}, err), map[string]interface{}{
+			"app_id":  args.AppID,
+			"method":  fullMethod,
+			"code":    st.Code().String(),
+			"message": st.Message(),
+		}, nil
+	}
+	span.SetAttributes(attribute.String("rpc.grpc.status_code", "OK"))
+
+	body, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(out)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to convert the response of '%s' to JSON: %v", fullMethod, err)}},
+			IsError: true,
+		}, nil, nil
+	}
+	// protojson output is deliberately unstable, so it is re-indented.
+	var response any
+	_ = json.Unmarshal(body, &response)
+	var resultData bytes.Buffer
+	if json.Indent(&resultData, body, "", "  ") != nil {
+		resultData.Write(body)
+	}
+
+	headers := make(map[string]string, len(header))
+	for k, v := range header {
+		headers[k] = strings.Join(v, ", ")
+	}
+
+	successMessage := fmt.Sprintf("Successfully invoked gRPC method '%s' on app '%s'.", fullMethod, args.AppID)
+	log.Println(successMessage)
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: successMessage + "\n\nResponse:\n" + resultData.String()}},
+	}, map[string]interface{}{
+		"app_id":        args.AppID,
+		"method":        fullMethod,
+		"code":          "OK",
+		"response_type": string(md.Output().FullName()),
+		"response":      response,
+		"headers":       headers,
+	}, nil
+}
+
+// RegisterGRPCTools registers invoke_grpc_service, which calls gRPC apps
+// through the sidecar connection conn. Message descriptors come from files,
+// when set, or from gRPC reflection on the target app.
+func RegisterGRPCTools(server *mcp.Server, conn grpc.ClientConnInterface, files *protoregistry.Files) {
+	grpcConn = conn
+	grpcResolver = newDescriptorResolver(conn, files)
+
+	isDestructive := true
+	notIdempotent := false
+	isOpenWorld := true
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "invoke_grpc_service",
+		Title: "Execute gRPC Inter-Service Request",
+		Description: "Calls a unary gRPC method on another Dapr-enabled service that only speaks gRPC. **This is a SIDE-EFFECT action that can be DESTRUCTIVE and is NOT IDEMPOTENT.** The JSON request is converted to protobuf using the app's descriptors, and the response is returned as JSON.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Use `get_components` to find the `AppID` of the target service.\n" +
+			"2. Use `invoke_service` instead for HTTP apps.\n" +
+			"3. Descriptors come from the server's configured descriptor set, or from gRPC reflection on the target app. If neither knows the service, ask the user for the descriptor set.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `AppID` and `Method`.\n" +
+			"2. **METHOD FORMAT**: `Method` MUST be fully qualified, including the protobuf package (e.g., 'orders.v1.OrderService/GetOrder').\n" +
+			"3. **REQUEST FORMAT**: `Request` MUST use protobuf JSON field names (lowerCamelCase or the original field names); unknown fields are rejected.\n" +
+			"4. **METADATA**: `Metadata` keys starting with 'dapr-', 'grpc-' or ':' are reserved and rejected.\n" +
+			"5. **NEVER INVENT**: You must NOT invent `AppID` or `Method` names.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: &isDestructive,
+			ReadOnlyHint:    false,
+			IdempotentHint:  notIdempotent,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, invokeGRPCServiceTool)
+}
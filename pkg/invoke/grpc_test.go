@@ -0,0 +1,236 @@
+package invoke
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// startGRPCApp serves the gRPC health service, optionally with reflection, and
+// records the app ID each call was routed to.
+func startGRPCApp(t *testing.T, withReflection bool) (*grpc.ClientConn, *[]string) {
+	t.Helper()
+	var appIDs []string
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			appIDs = append(appIDs, md.Get(appIDMetadataKey)...)
+			return handler(ctx, req)
+		}),
+	)
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("orders", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	if withReflection {
+		reflection.Register(server)
+	}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, &appIDs
+}
+
+func healthDescriptorSet(t *testing.T) string {
+	t.Helper()
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(healthpb.File_grpc_health_v1_health_proto),
+	}}
+	data, err := proto.Marshal(set)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "health.pb")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestInvokeGRPCServiceToolWithReflection(t *testing.T) {
+	conn, appIDs := startGRPCApp(t, true)
+	RegisterGRPCTools(mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil), conn, nil)
+
+	result, structured, err := invokeGRPCServiceTool(context.Background(), &mcp.CallToolRequest{}, InvokeGRPCServiceArgs{
+		AppID:   "order-service",
+		Method:  "grpc.health.v1.Health/Check",
+		Request: `{"service": "orders"}`,
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content[0].(*mcp.TextContent).Text)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, `"status": "NOT_SERVING"`)
+	structuredMap := structured.(map[string]interface{})
+	assert.Equal(t, "/grpc.health.v1.Health/Check", structuredMap["method"])
+	assert.Equal(t, "grpc.health.v1.HealthCheckResponse", structuredMap["response_type"])
+	assert.Equal(t, map[string]interface{}{"status": "NOT_SERVING"}, structuredMap["response"])
+	assert.Equal(t, []string{"order-service"}, *appIDs)
+}
+
+func TestInvokeGRPCServiceToolWithDescriptorSet(t *testing.T) {
+	conn, _ := startGRPCApp(t, false)
+	files, err := LoadDescriptorSet(healthDescriptorSet(t))
+	require.NoError(t, err)
+	RegisterGRPCTools(mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil), conn, files)
+
+	result, structured, err := invokeGRPCServiceTool(context.Background(), &mcp.CallToolRequest{}, InvokeGRPCServiceArgs{
+		AppID:  "order-service",
+		Method: "grpc.health.v1.Health.Check",
+	})
+
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content[0].(*mcp.TextContent).Text)
+	assert.Equal(t, map[string]interface{}{"status": "SERVING"}, structured.(map[string]interface{})["response"])
+}
+
+func TestInvokeGRPCServiceToolErrors(t *testing.T) {
+	conn, _ := startGRPCApp(t, true)
+	RegisterGRPCTools(mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil), conn, nil)
+
+	tests := []struct {
+		name        string
+		args        InvokeGRPCServiceArgs
+		wantContent string
+	}{
+		{
+			name:        "unqualified method",
+			args:        InvokeGRPCServiceArgs{AppID: "order-service", Method: "Check"},
+			wantContent: "must be fully qualified",
+		},
+		{
+			name:        "unknown service",
+			args:        InvokeGRPCServiceArgs{AppID: "order-service", Method: "orders.v1.OrderService/GetOrder"},
+			wantContent: "failed to resolve gRPC method",
+		},
+		{
+			name:        "request does not match the input type",
+			args:        InvokeGRPCServiceArgs{AppID: "order-service", Method: "grpc.health.v1.Health/Check", Request: `{"orderId": "1"}`},
+			wantContent: "request does not match 'grpc.health.v1.HealthCheckRequest'",
+		},
+		{
+			name:        "streaming method",
+			args:        InvokeGRPCServiceArgs{AppID: "order-service", Method: "grpc.health.v1.Health/Watch"},
+			wantContent: "streaming methods are not supported",
+		},
+		{
+			name:        "reserved metadata",
+			args:        InvokeGRPCServiceArgs{AppID: "order-service", Method: "grpc.health.v1.Health/Check", Metadata: map[string]string{"Dapr-App-Id": "payments"}},
+			wantContent: "metadata key 'Dapr-App-Id' is reserved",
+		},
+		{
+			name:        "status from the app",
+			args:        InvokeGRPCServiceArgs{AppID: "order-service", Method: "grpc.health.v1.Health/Check", Request: `{"service": "payments"}`},
+			wantContent: "failed with NotFound",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _, err := invokeGRPCServiceTool(context.Background(), &mcp.CallToolRequest{}, tt.args)
+
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, tt.wantContent)
+		})
+	}
+}
+
+// countingConn counts the streams opened for reflection.
+type countingConn struct {
+	grpc.ClientConnInterface
+	streams int
+}
+
+func (c *countingConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	c.streams++
+	return c.ClientConnInterface.NewStream(ctx, desc, method, opts...)
+}
+
+func TestDescriptorResolverCache(t *testing.T) {
+	conn, _ := startGRPCApp(t, true)
+	counting := &countingConn{ClientConnInterface: conn}
+	r := newDescriptorResolver(counting, nil)
+	ctx := context.Background()
+
+	_, err := r.resolve(ctx, "order-service", "grpc.health.v1.Health", "Check")
+	require.NoError(t, err)
+	_, err = r.resolve(ctx, "order-service", "grpc.health.v1.Health", "Watch")
+	require.NoError(t, err)
+	assert.Equal(t, 1, counting.streams)
+
+	// Expired descriptors are fetched again.
+	r.reflected["order-service"].fetched = time.Now().Add(-reflectionTTL)
+	_, err = r.resolve(ctx, "order-service", "grpc.health.v1.Health", "Check")
+	require.NoError(t, err)
+	assert.Equal(t, 2, counting.streams)
+
+	r.forget("order-service")
+	_, err = r.resolve(ctx, "order-service", "grpc.health.v1.Health", "Check")
+	require.NoError(t, err)
+	assert.Equal(t, 3, counting.streams)
+}
+
+func TestInvokeGRPCServiceToolForgetsStaleDescriptors(t *testing.T) {
+	conn, _ := startGRPCApp(t, true)
+	RegisterGRPCTools(mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil), conn, nil)
+
+	// Descriptors for a service the app no longer serves.
+	stale := &descriptorpb.FileDescriptorProto{
+		Name:        proto.String("orders.proto"),
+		Package:     proto.String("orders.v1"),
+		Syntax:      proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Empty")}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("OrderService"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("GetOrder"),
+				InputType:  proto.String(".orders.v1.Empty"),
+				OutputType: proto.String(".orders.v1.Empty"),
+			}},
+		}},
+	}
+	grpcResolver.reflected["order-service"] = &reflectedFiles{
+		files:   map[string]*descriptorpb.FileDescriptorProto{"orders.proto": stale},
+		fetched: time.Now(),
+	}
+
+	result, _, err := invokeGRPCServiceTool(context.Background(), &mcp.CallToolRequest{}, InvokeGRPCServiceArgs{
+		AppID:  "order-service",
+		Method: "orders.v1.OrderService/GetOrder",
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "failed with Unimplemented")
+	assert.NotContains(t, grpcResolver.reflected, "order-service")
+}
+
+func TestSplitGRPCMethod(t *testing.T) {
+	for _, method := range []string{"orders.v1.OrderService/GetOrder", "/orders.v1.OrderService/GetOrder", "orders.v1.OrderService.GetOrder"} {
+		service, name, err := splitGRPCMethod(method)
+		require.NoError(t, err, method)
+		assert.Equal(t, "orders.v1.OrderService", string(service))
+		assert.Equal(t, "GetOrder", string(name))
+	}
+
+	_, _, err := splitGRPCMethod("orders/v1/GetOrder")
+	assert.Error(t, err)
+}
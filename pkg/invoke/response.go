@@ -0,0 +1,356 @@
+package invoke
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// ResponseURIPrefix prefixes the URIs of retained service responses.
+	ResponseURIPrefix = "dapr://invoke/responses/"
+	// ResponseURITemplate is the URI template of a page of a retained response.
+	ResponseURITemplate = ResponseURIPrefix + "{id}/pages/{page}"
+
+	// DefaultMaxInlineBytes is the largest text body returned in the tool result.
+	DefaultMaxInlineBytes = 32 * 1024
+	// DefaultPageSize is the size of each page of a retained response.
+	DefaultPageSize = 64 * 1024
+	// DefaultRetainedResponses is the number of responses kept for paging.
+	DefaultRetainedResponses = 16
+	// DefaultRetainedBytes is the total size of the responses kept for paging.
+	DefaultRetainedBytes = 16 * 1024 * 1024
+	// DefaultResponseTTL is how long a response is kept for paging.
+	DefaultResponseTTL = 15 * time.Minute
+
+	// maxPageLinks bounds the resource links added to a single tool result.
+	maxPageLinks = 10
+	// maxSummaryKeys bounds the JSON object keys listed in a summary.
+	maxSummaryKeys = 10
+)
+
+// ResponseLimits bounds how much of a service response is returned inline.
+type ResponseLimits struct {
+	// MaxInlineBytes is the largest text body returned in the tool result.
+	// Larger bodies are truncated and retained for paging.
+	MaxInlineBytes int
+	// PageSize is the size of each page of a retained response.
+	PageSize int
+	// RetainedResponses is the number of responses kept for paging; the
+	// oldest is evicted first.
+	RetainedResponses int
+	// RetainedBytes bounds the total size of the responses kept for paging;
+	// the oldest are evicted first. A larger response is not retained.
+	RetainedBytes int
+	// TTL is how long a response is kept for paging.
+	TTL time.Duration
+}
+
+// DefaultResponseLimits returns limits from environment variables.
+func DefaultResponseLimits() ResponseLimits {
+	limit := func(name string, fallback int) int {
+		if v, err := strconv.Atoi(os.Getenv(name)); err == nil && v > 0 {
+			return v
+		}
+		return fallback
+	}
+	ttl := DefaultResponseTTL
+	if d, err := time.ParseDuration(os.Getenv("DAPR_MCP_SERVER_INVOKE_RESPONSE_TTL")); err == nil && d > 0 {
+		ttl = d
+	}
+	return ResponseLimits{
+		MaxInlineBytes:    limit("DAPR_MCP_SERVER_INVOKE_MAX_INLINE_BYTES", DefaultMaxInlineBytes),
+		PageSize:          limit("DAPR_MCP_SERVER_INVOKE_PAGE_SIZE", DefaultPageSize),
+		RetainedResponses: limit("DAPR_MCP_SERVER_INVOKE_RETAINED_RESPONSES", DefaultRetainedResponses),
+		RetainedBytes:     limit("DAPR_MCP_SERVER_INVOKE_RETAINED_BYTES", DefaultRetainedBytes),
+		TTL:               ttl,
+	}
+}
+
+// storedResponse is a service response retained for paging. Only the session
+// that invoked the service can read it.
+type storedResponse struct {
+	session     *mcp.ServerSession
+	appID       string
+	method      string
+	contentType string
+	body        []byte
+	expires     time.Time
+}
+
+// responseStore keeps the most recent oversized or binary responses.
+type responseStore struct {
+	mu     sync.Mutex
+	limits ResponseLimits
+	order  []string
+	items  map[string]*storedResponse
+	size   int
+}
+
+var responses = &responseStore{
+	limits: ResponseLimits{
+		MaxInlineBytes:    DefaultMaxInlineBytes,
+		PageSize:          DefaultPageSize,
+		RetainedResponses: DefaultRetainedResponses,
+		RetainedBytes:     DefaultRetainedBytes,
+		TTL:               DefaultResponseTTL,
+	},
+	items: make(map[string]*storedResponse),
+}
+
+// SetResponseLimits replaces the response limits. Unset limits keep their defaults.
+func SetResponseLimits(limits ResponseLimits) {
+	if limits.MaxInlineBytes <= 0 {
+		limits.MaxInlineBytes = DefaultMaxInlineBytes
+	}
+	if limits.PageSize <= 0 {
+		limits.PageSize = DefaultPageSize
+	}
+	if limits.RetainedResponses <= 0 {
+		limits.RetainedResponses = DefaultRetainedResponses
+	}
+	if limits.RetainedBytes <= 0 {
+		limits.RetainedBytes = DefaultRetainedBytes
+	}
+	if limits.TTL <= 0 {
+		limits.TTL = DefaultResponseTTL
+	}
+	responses.mu.Lock()
+	defer responses.mu.Unlock()
+	responses.limits = limits
+}
+
+func (s *responseStore) currentLimits() ResponseLimits {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.limits
+}
+
+// put retains a response and returns its ID, evicting expired responses and
+// then the oldest ones beyond the configured count and size. It returns false
+// when the response alone is larger than the size budget.
+func (s *responseStore) put(r *storedResponse) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(r.body) > s.limits.RetainedBytes {
+		return "", false
+	}
+	id := uuid.NewString()
+	r.expires = time.Now().Add(s.limits.TTL)
+	s.items[id] = r
+	s.order = append(s.order, id)
+	s.size += len(r.body)
+	now := time.Now()
+	for len(s.order) > 0 {
+		oldest := s.items[s.order[0]]
+		if len(s.order) <= s.limits.RetainedResponses && s.size <= s.limits.RetainedBytes && now.Before(oldest.expires) {
+			break
+		}
+		s.evictOldest()
+	}
+	return id, true
+}
+
+func (s *responseStore) evictOldest() {
+	s.size -= len(s.items[s.order[0]].body)
+	delete(s.items, s.order[0])
+	s.order = s.order[1:]
+}
+
+// get returns a response retained for session that has not expired, and the
+// page size.
+func (s *responseStore) get(session *mcp.ServerSession, id string) (*storedResponse, int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.items[id]
+	if !ok || r.session != session || !time.Now().Before(r.expires) {
+		return nil, s.limits.PageSize, false
+	}
+	return r, s.limits.PageSize, true
+}
+
+// responsePageURI returns the resource URI of a page of a retained response.
+func responsePageURI(id string, page int) string {
+	return fmt.Sprintf("%s%s/pages/%d", ResponseURIPrefix, id, page)
+}
+
+// parseResponsePageURI splits a page URI into the response ID and page number.
+func parseResponsePageURI(uri string) (string, int, error) {
+	id, pageStr, ok := strings.Cut(strings.TrimPrefix(uri, ResponseURIPrefix), "/pages/")
+	if !ok || id == "" || !strings.HasPrefix(uri, ResponseURIPrefix) {
+		return "", 0, fmt.Errorf("'%s' is not a service response page", uri)
+	}
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 1 {
+		return "", 0, fmt.Errorf("'%s' is not a service response page", uri)
+	}
+	return id, page, nil
+}
+
+func pageCount(size, pageSize int) int {
+	if size == 0 {
+		return 1
+	}
+	return (size + pageSize - 1) / pageSize
+}
+
+// pageBounds returns the byte range of a page. Boundaries of text bodies are
+// moved back to the start of a UTF-8 sequence so no page splits a character.
+func pageBounds(body []byte, page, pageSize int, binary bool) (int, int) {
+	align := func(pos int) int {
+		if pos >= len(body) {
+			return len(body)
+		}
+		for i := 0; !binary && i < utf8.UTFMax-1 && pos > 0 && !utf8.RuneStart(body[pos]); i++ {
+			pos--
+		}
+		return pos
+	}
+	return align((page - 1) * pageSize), align(page * pageSize)
+}
+
+// sniffContentType detects the content type of a response body the service
+// did not label.
+func sniffContentType(body []byte) string {
+	if json.Valid(body) {
+		return "application/json"
+	}
+	return http.DetectContentType(body)
+}
+
+// isBinaryContentType reports whether a body of this content type should be
+// returned as bytes rather than text.
+func isBinaryContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return true
+	}
+	if strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml") {
+		return false
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/javascript", "application/x-www-form-urlencoded", "application/yaml", "application/x-yaml":
+		return false
+	}
+	return true
+}
+
+// summarizeBody describes the shape of a JSON body that was truncated.
+func summarizeBody(body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return ""
+	}
+	switch v := v.(type) {
+	case []any:
+		return fmt.Sprintf("JSON array with %d items", len(v))
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		summary := fmt.Sprintf("JSON object with %d keys", len(keys))
+		if len(keys) > maxSummaryKeys {
+			return summary + ": " + strings.Join(keys[:maxSummaryKeys], ", ") + ", ..."
+		}
+		return summary + ": " + strings.Join(keys, ", ")
+	}
+	return ""
+}
+
+// retainedResponseResult returns a binary or oversized body as resources the
+// client can read page by page, with a truncated preview of text bodies. The
+// paging details are added to structured.
+func retainedResponseResult(session *mcp.ServerSession, message string, structured map[string]interface{}, args InvokeServiceArgs, body []byte, contentType string) (*mcp.CallToolResult, any, error) {
+	limits := responses.currentLimits()
+	binary := isBinaryContentType(contentType) || !utf8.Valid(body)
+	pages := pageCount(len(body), limits.PageSize)
+	size := int64(len(body))
+
+	structured["size_bytes"] = len(body)
+	structured["binary"] = binary
+	structured["truncated"] = len(body) > limits.MaxInlineBytes
+
+	id, retained := responses.put(&storedResponse{session: session, appID: args.AppID, method: args.Method, contentType: contentType, body: body})
+	structured["retained"] = retained
+	firstPage := responsePageURI(id, 1)
+	if retained {
+		structured["resource_uri"] = firstPage
+		structured["pages"] = pages
+		structured["page_size"] = limits.PageSize
+	}
+
+	// A binary body that fits inline is embedded whole.
+	if binary && len(body) <= limits.MaxInlineBytes {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("%s\n\nThe response is %d bytes of binary content (%s), embedded as a resource.", message, len(body), contentType)},
+				&mcp.EmbeddedResource{Resource: &mcp.ResourceContents{URI: firstPage, MIMEType: contentType, Blob: body}},
+			},
+		}, structured, nil
+	}
+
+	var text strings.Builder
+	text.WriteString(message)
+	if binary {
+		fmt.Fprintf(&text, "\n\nThe response is %d bytes of binary content (%s) and was not included.", len(body), contentType)
+	} else {
+		_, end := pageBounds(body, 1, limits.MaxInlineBytes, false)
+		fmt.Fprintf(&text, "\n\nResponse (truncated):\n%s\n[... truncated: showing %d of %d bytes ...]", body[:end], end, len(body))
+		if summary := summarizeBody(body); summary != "" {
+			structured["summary"] = summary
+			fmt.Fprintf(&text, "\n[summary: %s]", summary)
+		}
+	}
+	if !retained {
+		fmt.Fprintf(&text, "\n\nThe full response is larger than the %d bytes kept for paging and was not retained.", limits.RetainedBytes)
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: text.String()}}}, structured, nil
+	}
+	fmt.Fprintf(&text, "\n\nThe full response is available for %s in %d page(s) of up to %d bytes. Read %s, incrementing the page number, to fetch it.", limits.TTL, pages, limits.PageSize, firstPage)
+
+	content := []mcp.Content{&mcp.TextContent{Text: text.String()}}
+	for page := 1; page <= pages && page <= maxPageLinks; page++ {
+		content = append(content, &mcp.ResourceLink{
+			URI:      responsePageURI(id, page),
+			Name:     fmt.Sprintf("%s-%s-page-%d", args.AppID, args.Method, page),
+			Title:    fmt.Sprintf("Response of '%s' method '%s', page %d of %d", args.AppID, args.Method, page, pages),
+			MIMEType: contentType,
+			Size:     &size,
+		})
+	}
+	return &mcp.CallToolResult{Content: content}, structured, nil
+}
+
+func readResponsePage(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	id, page, err := parseResponsePageURI(req.Params.URI)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(req.Params.URI)
+	}
+	r, pageSize, ok := responses.get(req.Session, id)
+	if !ok || page > pageCount(len(r.body), pageSize) {
+		return nil, mcp.ResourceNotFoundError(req.Params.URI)
+	}
+
+	binary := isBinaryContentType(r.contentType) || !utf8.Valid(r.body)
+	start, end := pageBounds(r.body, page, pageSize, binary)
+	contents := &mcp.ResourceContents{URI: req.Params.URI, MIMEType: r.contentType}
+	if binary {
+		contents.Blob = r.body[start:end]
+	} else {
+		contents.Text = string(r.body[start:end])
+	}
+	return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{contents}}, nil
+}
@@ -0,0 +1,216 @@
+package invoke
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+)
+
+func withResponseLimits(t *testing.T, limits ResponseLimits) {
+	t.Helper()
+	SetResponseLimits(limits)
+	t.Cleanup(func() {
+		SetResponseLimits(ResponseLimits{})
+		responses.mu.Lock()
+		defer responses.mu.Unlock()
+		responses.order, responses.items, responses.size = nil, make(map[string]*storedResponse), 0
+	})
+}
+
+func readPage(t *testing.T, uri string) *mcp.ResourceContents {
+	t.Helper()
+	result, err := readResponsePage(context.Background(), &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: uri}})
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	return result.Contents[0]
+}
+
+func TestInvokeServiceToolTruncatesLargeResponses(t *testing.T) {
+	withResponseLimits(t, ResponseLimits{MaxInlineBytes: 16, PageSize: 20})
+	body := `[{"id":1},{"id":2},{"id":3},{"id":4}]`
+
+	mockClient := new(mocks.MockInvokeClient)
+	mockClient.On("InvokeService", mock.Anything, call("order-service", "orders", "GET")).
+		Return(respond("application/json", body), nil)
+	invokeClient = mockClient
+
+	result, structured, err := invokeServiceTool(context.Background(), &mcp.CallToolRequest{}, InvokeServiceArgs{
+		AppID:    "order-service",
+		Method:   "orders",
+		HTTPVerb: "GET",
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "Response (truncated):\n[{\"id\":1},{\"id\":\n")
+	assert.Contains(t, text, "[... truncated: showing 16 of 37 bytes ...]")
+	assert.Contains(t, text, "[summary: JSON array with 4 items]")
+
+	structuredMap := structured.(map[string]interface{})
+	assert.Equal(t, true, structuredMap["truncated"])
+	assert.Equal(t, false, structuredMap["binary"])
+	assert.Equal(t, "application/json", structuredMap["content_type"])
+	assert.Equal(t, 2, structuredMap["pages"])
+
+	require.Len(t, result.Content, 3)
+	link := result.Content[1].(*mcp.ResourceLink)
+	assert.Equal(t, structuredMap["resource_uri"], link.URI)
+
+	var full strings.Builder
+	for _, content := range result.Content[1:] {
+		full.WriteString(readPage(t, content.(*mcp.ResourceLink).URI).Text)
+	}
+	assert.Equal(t, body, full.String())
+}
+
+func TestInvokeServiceToolBinaryResponse(t *testing.T) {
+	withResponseLimits(t, ResponseLimits{})
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	// The service does not label the body, so its content type is sniffed.
+	mockClient := new(mocks.MockInvokeClient)
+	mockClient.On("InvokeService", mock.Anything, call("image-service", "thumbnail", "GET")).
+		Return(respond("", string(png)), nil)
+	invokeClient = mockClient
+
+	result, structured, err := invokeServiceTool(context.Background(), &mcp.CallToolRequest{}, InvokeServiceArgs{
+		AppID:    "image-service",
+		Method:   "thumbnail",
+		HTTPVerb: "GET",
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "binary content (image/png)")
+	embedded := result.Content[1].(*mcp.EmbeddedResource)
+	assert.Equal(t, "image/png", embedded.Resource.MIMEType)
+	assert.Equal(t, png, embedded.Resource.Blob)
+	assert.Equal(t, true, structured.(map[string]interface{})["binary"])
+
+	page := readPage(t, embedded.Resource.URI)
+	assert.Equal(t, png, page.Blob)
+}
+
+func TestReadResponsePageNotFound(t *testing.T) {
+	for _, uri := range []string{
+		ResponseURIPrefix + "missing/pages/1",
+		ResponseURIPrefix + "missing",
+		ResponseURIPrefix + "missing/pages/0",
+	} {
+		_, err := readResponsePage(context.Background(), &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: uri}})
+		assert.Error(t, err, uri)
+	}
+}
+
+func TestResponseStoreEviction(t *testing.T) {
+	tests := []struct {
+		name     string
+		limits   ResponseLimits
+		wait     time.Duration
+		bodies   []string
+		retained []bool
+		readable []bool
+	}{
+		{
+			name:     "by count",
+			limits:   ResponseLimits{RetainedResponses: 2},
+			bodies:   []string{"1", "2", "3"},
+			retained: []bool{true, true, true},
+			readable: []bool{false, true, true},
+		},
+		{
+			name:     "by size",
+			limits:   ResponseLimits{RetainedBytes: 5},
+			bodies:   []string{"aa", "bb", "cc"},
+			retained: []bool{true, true, true},
+			readable: []bool{false, true, true},
+		},
+		{
+			name:     "larger than the size budget",
+			limits:   ResponseLimits{RetainedBytes: 2},
+			bodies:   []string{"aa", "bbb"},
+			retained: []bool{true, false},
+			readable: []bool{true, false},
+		},
+		{
+			name:     "expired",
+			limits:   ResponseLimits{TTL: time.Millisecond},
+			wait:     5 * time.Millisecond,
+			bodies:   []string{"1"},
+			retained: []bool{true},
+			readable: []bool{false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withResponseLimits(t, tt.limits)
+			var ids []string
+			for i, body := range tt.bodies {
+				id, ok := responses.put(&storedResponse{body: []byte(body)})
+				assert.Equal(t, tt.retained[i], ok, body)
+				ids = append(ids, id)
+			}
+			time.Sleep(tt.wait)
+			for i, id := range ids {
+				_, _, ok := responses.get(nil, id)
+				assert.Equal(t, tt.readable[i], ok, tt.bodies[i])
+			}
+		})
+	}
+}
+
+func TestResponseStoreIsScopedToSession(t *testing.T) {
+	withResponseLimits(t, ResponseLimits{})
+	owner, other := &mcp.ServerSession{}, &mcp.ServerSession{}
+	id, ok := responses.put(&storedResponse{session: owner, body: []byte("secret")})
+	require.True(t, ok)
+
+	_, _, ok = responses.get(owner, id)
+	assert.True(t, ok)
+	_, _, ok = responses.get(other, id)
+	assert.False(t, ok)
+	_, err := readResponsePage(context.Background(), &mcp.ReadResourceRequest{Session: other, Params: &mcp.ReadResourceParams{URI: responsePageURI(id, 1)}})
+	assert.Error(t, err)
+}
+
+func TestPageBoundsKeepsCharactersWhole(t *testing.T) {
+	body := []byte("aé€b")
+	start, end := pageBounds(body, 1, 2, false)
+	assert.Equal(t, "a", string(body[start:end]))
+	start, end = pageBounds(body, 2, 2, false)
+	assert.Equal(t, "é", string(body[start:end]))
+
+	start, end = pageBounds(body, 1, 2, true)
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 2, end)
+}
+
+func TestIsBinaryContentType(t *testing.T) {
+	for contentType, binary := range map[string]bool{
+		"application/json":               false,
+		"application/problem+json":       false,
+		"text/plain; charset=utf-8":      false,
+		"application/xml":                false,
+		"application/octet-stream":       true,
+		"image/png":                      true,
+		"application/pdf":                true,
+		"not a content type; \x00broken": true,
+	} {
+		assert.Equal(t, binary, isBinaryContentType(contentType), contentType)
+	}
+}
+
+func TestSummarizeBody(t *testing.T) {
+	assert.Equal(t, "JSON object with 2 keys: a, b", summarizeBody([]byte(`{"b":1,"a":2}`)))
+	assert.Equal(t, "JSON array with 0 items", summarizeBody([]byte(`[]`)))
+	assert.Empty(t, summarizeBody([]byte("plain text")))
+}
@@ -6,28 +6,45 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
+	"strings"
 
-	dapr "github.com/dapr/go-sdk/client"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/dapr/dapr-mcp-server/pkg/sidecar"
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
 )
 
 // InvokeClient defines the interface for service invocation operations.
 type InvokeClient interface {
-	InvokeMethodWithContent(ctx context.Context, appID, methodName, verb string, content *dapr.DataContent) ([]byte, error)
+	InvokeService(ctx context.Context, req *sidecar.InvokeRequest) (*sidecar.InvokeResponse, error)
 }
 
 type InvokeServiceArgs struct {
-	AppID    string            `json:"appID" jsonschema:"The Dapr application ID of the service to call (e.g., 'order-processor')."`
-	Method   string            `json:"method" jsonschema:"The method/endpoint on the target service to call (e.g., 'status')."`
-	Data     string            `json:"data" jsonschema:"The body payload for the request, typically a JSON string."`
-	HTTPVerb string            `json:"httpVerb" jsonschema:"The HTTP verb to use (e.g., 'GET', 'POST', 'PUT'). Default is 'POST'."`
-	Metadata map[string]string `json:"metadata,omitempty" jsonschema:"Optional key-value pairs to send as HTTP headers."`
+	AppID       string            `json:"appID" jsonschema:"The Dapr application ID of the service to call (e.g., 'order-processor')."`
+	Method      string            `json:"method" jsonschema:"The method/endpoint on the target service to call (e.g., 'orders/123')."`
+	Data        string            `json:"data" jsonschema:"The body payload for the request, typically a JSON string."`
+	HTTPVerb    string            `json:"httpVerb" jsonschema:"The HTTP verb to use (e.g., 'GET', 'POST', 'PUT'). Default is 'POST'."`
+	QueryParams map[string]string `json:"queryParams,omitempty" jsonschema:"Optional query string parameters (e.g., {'status': 'open'})."`
+	Metadata    map[string]string `json:"metadata,omitempty" jsonschema:"Optional key-value pairs to send as HTTP headers."`
+	ContentType string            `json:"contentType,omitempty" jsonschema:"Optional content type of the request body. Default is 'application/json'."`
 }
 
 var invokeClient InvokeClient
 
+// flattenHeaders joins repeated header values so headers serialize as a flat map.
+func flattenHeaders(headers http.Header) map[string]string {
+	flat := make(map[string]string, len(headers))
+	for k, v := range headers {
+		flat[k] = strings.Join(v, ", ")
+	}
+	return flat
+}
+
 func invokeServiceTool(ctx context.Context, req *mcp.CallToolRequest, args InvokeServiceArgs) (*mcp.CallToolResult, any, error) {
 	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "invoke_service")
 	defer span.End()
@@ -35,65 +52,130 @@ func invokeServiceTool(ctx context.Context, req *mcp.CallToolRequest, args Invok
 	if args.HTTPVerb == "" {
 		args.HTTPVerb = "POST"
 	}
+	contentType := args.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	span.SetAttributes(
+		attribute.String("dapr.operation", "invoke_service"),
+		attribute.String("dapr.app_id", args.AppID),
+		attribute.String("http.request.method", args.HTTPVerb),
+	)
 
-	content := &dapr.DataContent{
-		ContentType: "application/json",
-		Data:        []byte(args.Data),
+	// Query parameters may be given in the method, in QueryParams, or both
+	method, rawQuery, _ := strings.Cut(args.Method, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("invalid query string in method '%s': %v", args.Method, err)}},
+			IsError: true,
+		}, nil, nil
+	}
+	for k, v := range args.QueryParams {
+		query.Add(k, v)
 	}
 
-	// Merge user metadata with baggage
-	metadata := make(map[string]string)
+	// Merge user headers with baggage
+	headers := make(map[string]string)
 	for k, v := range args.Metadata {
-		metadata[k] = v
+		headers[k] = v
 	}
 	propagator := otel.GetTextMapPropagator()
-	propagator.Inject(ctx, propagation.MapCarrier(metadata))
-
-	resp, err := invokeClient.InvokeMethodWithContent(ctx, args.AppID, args.Method, args.HTTPVerb, content)
+	propagator.Inject(ctx, propagation.MapCarrier(headers))
+
+	resp, err := invokeClient.InvokeService(ctx, &sidecar.InvokeRequest{
+		AppID:       args.AppID,
+		Method:      method,
+		Verb:        args.HTTPVerb,
+		Query:       query,
+		Headers:     headers,
+		ContentType: contentType,
+		Body:        []byte(args.Data),
+	})
 	if err != nil {
 		log.Printf("Dapr InvokeMethod failed for app %s/%s: %v", args.AppID, args.Method, err)
 		toolErrorMessage := fmt.Errorf("failed to invoke service method: %w", err).Error()
-		return &mcp.CallToolResult{
+		return toolcall.WithStatus(&mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
 			IsError: true,
-		}, nil, nil
+		}, err), nil, nil
 	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
 
-	var resultData bytes.Buffer
-	if json.Indent(&resultData, resp, "", "  ") != nil {
-		resultData.Write(resp)
+	status := fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	failed := resp.StatusCode >= http.StatusBadRequest
+	message := fmt.Sprintf(
+		"Successfully invoked service '%s' method '%s' (%s). The service responded with status %s.",
+		args.AppID, args.Method, args.HTTPVerb, status,
+	)
+	if failed {
+		message = fmt.Sprintf("Service '%s' method '%s' (%s) responded with status %s.", args.AppID, args.Method, args.HTTPVerb, status)
 	}
+	log.Println(message)
 
-	successMessage := fmt.Sprintf(
-		"Successfully invoked service '%s' method '%s' (%s). The service responded with data/status.",
-		args.AppID, args.Method, args.HTTPVerb,
-	)
-	log.Println(successMessage)
-	var structuredResult map[string]interface{}
-	if len(resp) > 0 {
-		if err := json.Unmarshal(resp, &structuredResult); err != nil {
-			structuredResult = map[string]interface{}{
-				"raw_response": string(resp),
-				"app_id":       args.AppID,
-				"method":       args.Method,
-			}
+	body := resp.Body
+	responseContentType := resp.ContentType
+	if responseContentType == "" && len(body) > 0 {
+		responseContentType = sniffContentType(body)
+	}
+	structuredResult := map[string]interface{}{
+		"app_id":       args.AppID,
+		"method":       args.Method,
+		"http_verb":    args.HTTPVerb,
+		"status_code":  resp.StatusCode,
+		"headers":      flattenHeaders(resp.Headers),
+		"content_type": responseContentType,
+	}
+
+	// Binary and oversized bodies are retained and returned as resources
+	if len(body) > 0 && (isBinaryContentType(responseContentType) || len(body) > responses.currentLimits().MaxInlineBytes) {
+		result, structured, err := retainedResponseResult(req.Session, message, structuredResult, args, body, responseContentType)
+		if result != nil {
+			result.IsError = failed
 		}
+		return result, structured, err
+	}
+
+	if len(body) == 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: message + " The response has no body."}},
+			IsError: failed,
+		}, structuredResult, nil
+	}
+
+	var resultData bytes.Buffer
+	var parsed any
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		structuredResult["body"] = parsed
+		_ = json.Indent(&resultData, body, "", "  ")
 	} else {
-		structuredResult = map[string]interface{}{
-			"status": "success_no_content",
-			"app_id": args.AppID,
-			"method": args.Method,
-		}
+		structuredResult["body"] = string(body)
+		resultData.Write(body)
 	}
 
 	return &mcp.CallToolResult{
-		Content: []mcp.Content{&mcp.TextContent{Text: successMessage + "\n\nResponse:\n" + resultData.String()}},
+		Content: []mcp.Content{&mcp.TextContent{Text: message + "\n\nResponse:\n" + resultData.String()}},
+		IsError: failed,
 	}, structuredResult, nil
 }
 
+// CallService invokes a service method the way invoke_service does. Tools
+// generated from a service's OpenAPI document call it after mapping their
+// arguments onto the request.
+func CallService(ctx context.Context, req *mcp.CallToolRequest, args InvokeServiceArgs) (*mcp.CallToolResult, any, error) {
+	return invokeServiceTool(ctx, req, args)
+}
+
 func RegisterTools(server *mcp.Server, client InvokeClient) {
 	invokeClient = client
 
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: ResponseURITemplate,
+		Name:        "invoke-response-page",
+		Title:       "Service Response Page",
+		Description: "A page of a binary or oversized response returned by invoke_service. Pages are numbered from 1; the tool result gives the page count.",
+	}, readResponsePage)
+
 	isDestructive := true
 	notReadOnly := false
 	notIdempotent := false
@@ -105,11 +187,14 @@ func RegisterTools(server *mcp.Server, client InvokeClient) {
 		Description: "Calls a method (endpoint) on another Dapr-enabled service. **This is a SIDE-EFFECT action that can be DESTRUCTIVE and is NOT IDEMPOTENT for POST/PUT calls.** Use this tool to perform transactional business logic (e.g., updating data, creating resources, triggering workflows).\n\n" +
 			"**GUIDANCE:**\n" +
 			"1. Use `get_components` to find the `AppID` of the target service.\n" +
-			"2. For `HTTPVerb`, use 'GET' for read-only status checks, 'POST' for creation, and 'DELETE' for removal. Default is 'POST'.\n\n" +
+			"2. For `HTTPVerb`, use 'GET' for read-only status checks, 'POST' for creation, and 'DELETE' for removal. Default is 'POST'.\n" +
+			"3. The result reports the service's `status_code` and response `headers`. A status of 400 or above is returned as an error.\n\n" +
 			"**ARGUMENT RULES:**\n" +
 			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `AppID`, `Method`, and `HTTPVerb`.\n" +
 			"2. **NEVER INVENT**: You must NOT invent `AppID` or `Method` names; they must be provided by the user or discovered.\n" +
-			"3. **CLARIFICATION**: If any required input is missing, you MUST ask the user for clarification.\n\n" +
+			"3. **CLARIFICATION**: If any required input is missing, you MUST ask the user for clarification.\n" +
+			"4. **HTTP DETAILS**: Put query string parameters in `QueryParams`, request headers in `Metadata`, and set `ContentType` when the body is not JSON.\n" +
+			"5. **LARGE RESPONSES**: Responses larger than the inline limit are truncated and binary responses are not shown as text. Both are returned as resources: read the linked pages only if the user needs the rest of the body.\n\n" +
 			"**SECURITY WARNING**: This tool bypasses the standard Resource/Tool abstraction and directly executes service logic. Ensure user intent is clear and the operation is authorized.",
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: &isDestructive,
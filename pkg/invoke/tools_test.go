@@ -3,21 +3,40 @@ package invoke
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/url"
 	"testing"
 
-	"github.com/dapr/go-sdk/client"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/dapr/dapr-mcp-server/pkg/sidecar"
 	"github.com/dapr/dapr-mcp-server/test/mocks"
 )
 
+// call matches an invocation of appID's method with verb.
+func call(appID, method, verb string) interface{} {
+	return mock.MatchedBy(func(req *sidecar.InvokeRequest) bool {
+		return req.AppID == appID && req.Method == method && req.Verb == verb
+	})
+}
+
+// respond builds a response with status 200 and the given body.
+func respond(contentType, body string) *sidecar.InvokeResponse {
+	headers := http.Header{}
+	if contentType != "" {
+		headers.Set("Content-Type", contentType)
+	}
+	return &sidecar.InvokeResponse{StatusCode: http.StatusOK, Headers: headers, ContentType: contentType, Body: []byte(body)}
+}
+
 func TestInvokeServiceTool(t *testing.T) {
 	tests := []struct {
 		name        string
 		args        InvokeServiceArgs
-		setupMock   func(*mocks.MockDaprClient)
+		setupMock   func(*mocks.MockInvokeClient)
 		wantErr     bool
 		wantContent string
 	}{
@@ -29,9 +48,9 @@ func TestInvokeServiceTool(t *testing.T) {
 				Data:     `{"orderId": "123"}`,
 				HTTPVerb: "POST",
 			},
-			setupMock: func(m *mocks.MockDaprClient) {
-				m.On("InvokeMethodWithContent", mock.Anything, "order-service", "getOrder", "POST", mock.AnythingOfType("*client.DataContent")).
-					Return([]byte(`{"status": "completed"}`), nil)
+			setupMock: func(m *mocks.MockInvokeClient) {
+				m.On("InvokeService", mock.Anything, call("order-service", "getOrder", "POST")).
+					Return(respond("application/json", `{"status": "completed"}`), nil)
 			},
 			wantErr:     false,
 			wantContent: "Successfully invoked service 'order-service' method 'getOrder'",
@@ -44,9 +63,9 @@ func TestInvokeServiceTool(t *testing.T) {
 				Data:     `{"message": "hello"}`,
 				HTTPVerb: "POST",
 			},
-			setupMock: func(m *mocks.MockDaprClient) {
-				m.On("InvokeMethodWithContent", mock.Anything, "notification-service", "notify", "POST", mock.AnythingOfType("*client.DataContent")).
-					Return([]byte{}, nil)
+			setupMock: func(m *mocks.MockInvokeClient) {
+				m.On("InvokeService", mock.Anything, call("notification-service", "notify", "POST")).
+					Return(&sidecar.InvokeResponse{StatusCode: http.StatusNoContent}, nil)
 			},
 			wantErr:     false,
 			wantContent: "Successfully invoked service 'notification-service' method 'notify'",
@@ -59,9 +78,9 @@ func TestInvokeServiceTool(t *testing.T) {
 				Data:     `{}`,
 				HTTPVerb: "", // Should default to POST
 			},
-			setupMock: func(m *mocks.MockDaprClient) {
-				m.On("InvokeMethodWithContent", mock.Anything, "test-service", "test", "POST", mock.AnythingOfType("*client.DataContent")).
-					Return([]byte(`{}`), nil)
+			setupMock: func(m *mocks.MockInvokeClient) {
+				m.On("InvokeService", mock.Anything, call("test-service", "test", "POST")).
+					Return(respond("application/json", `{}`), nil)
 			},
 			wantErr:     false,
 			wantContent: "Successfully invoked service",
@@ -74,9 +93,9 @@ func TestInvokeServiceTool(t *testing.T) {
 				Data:     "",
 				HTTPVerb: "GET",
 			},
-			setupMock: func(m *mocks.MockDaprClient) {
-				m.On("InvokeMethodWithContent", mock.Anything, "status-service", "health", "GET", mock.AnythingOfType("*client.DataContent")).
-					Return([]byte(`{"healthy": true}`), nil)
+			setupMock: func(m *mocks.MockInvokeClient) {
+				m.On("InvokeService", mock.Anything, call("status-service", "health", "GET")).
+					Return(respond("application/json", `{"healthy": true}`), nil)
 			},
 			wantErr:     false,
 			wantContent: "Successfully invoked service 'status-service' method 'health' (GET)",
@@ -90,9 +109,10 @@ func TestInvokeServiceTool(t *testing.T) {
 				HTTPVerb: "POST",
 				Metadata: map[string]string{"X-Custom-Header": "value"},
 			},
-			setupMock: func(m *mocks.MockDaprClient) {
-				m.On("InvokeMethodWithContent", mock.Anything, "secure-service", "protected", "POST", mock.AnythingOfType("*client.DataContent")).
-					Return([]byte(`{"success": true}`), nil)
+			setupMock: func(m *mocks.MockInvokeClient) {
+				m.On("InvokeService", mock.Anything, mock.MatchedBy(func(req *sidecar.InvokeRequest) bool {
+					return req.AppID == "secure-service" && req.Headers["X-Custom-Header"] == "value"
+				})).Return(respond("application/json", `{"success": true}`), nil)
 			},
 			wantErr:     false,
 			wantContent: "Successfully invoked service",
@@ -105,8 +125,8 @@ func TestInvokeServiceTool(t *testing.T) {
 				Data:     `{}`,
 				HTTPVerb: "POST",
 			},
-			setupMock: func(m *mocks.MockDaprClient) {
-				m.On("InvokeMethodWithContent", mock.Anything, "offline-service", "action", "POST", mock.AnythingOfType("*client.DataContent")).
+			setupMock: func(m *mocks.MockInvokeClient) {
+				m.On("InvokeService", mock.Anything, call("offline-service", "action", "POST")).
 					Return(nil, errors.New("connection refused"))
 			},
 			wantErr:     true,
@@ -120,8 +140,8 @@ func TestInvokeServiceTool(t *testing.T) {
 				Data:     `{}`,
 				HTTPVerb: "POST",
 			},
-			setupMock: func(m *mocks.MockDaprClient) {
-				m.On("InvokeMethodWithContent", mock.Anything, "nonexistent-service", "method", "POST", mock.AnythingOfType("*client.DataContent")).
+			setupMock: func(m *mocks.MockInvokeClient) {
+				m.On("InvokeService", mock.Anything, call("nonexistent-service", "method", "POST")).
 					Return(nil, errors.New("service not found"))
 			},
 			wantErr:     true,
@@ -135,18 +155,33 @@ func TestInvokeServiceTool(t *testing.T) {
 				Data:     `{}`,
 				HTTPVerb: "POST",
 			},
-			setupMock: func(m *mocks.MockDaprClient) {
-				m.On("InvokeMethodWithContent", mock.Anything, "text-service", "text", "POST", mock.AnythingOfType("*client.DataContent")).
-					Return([]byte("plain text response"), nil)
+			setupMock: func(m *mocks.MockInvokeClient) {
+				m.On("InvokeService", mock.Anything, call("text-service", "text", "POST")).
+					Return(respond("", "plain text response"), nil)
 			},
 			wantErr:     false,
 			wantContent: "Successfully invoked service",
 		},
+		{
+			name: "error status from the service",
+			args: InvokeServiceArgs{
+				AppID:    "order-service",
+				Method:   "orders/404",
+				HTTPVerb: "GET",
+			},
+			setupMock: func(m *mocks.MockInvokeClient) {
+				resp := respond("application/json", `{"error": "not found"}`)
+				resp.StatusCode = http.StatusNotFound
+				m.On("InvokeService", mock.Anything, call("order-service", "orders/404", "GET")).Return(resp, nil)
+			},
+			wantErr:     true,
+			wantContent: "responded with status 404 Not Found",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockClient := new(mocks.MockDaprClient)
+			mockClient := new(mocks.MockInvokeClient)
 			tt.setupMock(mockClient)
 
 			// Replace the package-level client
@@ -167,48 +202,61 @@ func TestInvokeServiceTool(t *testing.T) {
 	}
 }
 
-func TestRegisterTools(t *testing.T) {
-	mockClient := new(mocks.MockDaprClient)
-	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil)
-
-	// Should not panic
-	RegisterTools(server, mockClient)
+func TestInvokeServiceToolHTTPSemantics(t *testing.T) {
+	mockInvoke := new(mocks.MockInvokeClient)
+	var sent *sidecar.InvokeRequest
+	resp := respond("application/json", `{"result": "ok"}`)
+	resp.StatusCode = http.StatusCreated
+	resp.Headers.Set("Location", "/orders/123")
+	mockInvoke.On("InvokeService", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(*sidecar.InvokeRequest) }).
+		Return(resp, nil)
+	invokeClient = mockInvoke
 
-	assert.Equal(t, mockClient, invokeClient)
-}
+	result, structured, err := invokeServiceTool(context.Background(), &mcp.CallToolRequest{}, InvokeServiceArgs{
+		AppID:       "app",
+		Method:      "orders?dryRun=true",
+		Data:        "id=123",
+		HTTPVerb:    "POST",
+		QueryParams: map[string]string{"tenant": "acme"},
+		Metadata:    map[string]string{"Authorization": "Bearer token"},
+		ContentType: "application/x-www-form-urlencoded",
+	})
 
-// mockInvokeClient implements InvokeClient for testing
-type mockInvokeClient struct {
-	mock.Mock
-}
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, "orders", sent.Method)
+	assert.Equal(t, url.Values{"dryRun": {"true"}, "tenant": {"acme"}}, sent.Query)
+	assert.Equal(t, "Bearer token", sent.Headers["Authorization"])
+	assert.Equal(t, "application/x-www-form-urlencoded", sent.ContentType)
+	assert.Equal(t, []byte("id=123"), sent.Body)
 
-func (m *mockInvokeClient) InvokeMethodWithContent(ctx context.Context, appID, methodName, verb string, content *client.DataContent) ([]byte, error) {
-	args := m.Called(ctx, appID, methodName, verb, content)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).([]byte), args.Error(1)
+	structuredMap := structured.(map[string]interface{})
+	assert.Equal(t, http.StatusCreated, structuredMap["status_code"])
+	assert.Equal(t, "/orders/123", structuredMap["headers"].(map[string]string)["Location"])
+	assert.Equal(t, "application/json", structuredMap["content_type"])
+	assert.Equal(t, map[string]interface{}{"result": "ok"}, structuredMap["body"])
 }
 
-func TestInvokeServiceToolWithInterfaceMock(t *testing.T) {
-	mockInvoke := new(mockInvokeClient)
-	mockInvoke.On("InvokeMethodWithContent", mock.Anything, "app", "method", "POST", mock.Anything).
-		Return([]byte(`{"result": "ok"}`), nil)
+func TestInvokeServiceToolInvalidQuery(t *testing.T) {
+	invokeClient = new(mocks.MockInvokeClient)
 
-	invokeClient = mockInvoke
+	result, _, err := invokeServiceTool(context.Background(), &mcp.CallToolRequest{}, InvokeServiceArgs{
+		AppID:  "app",
+		Method: "orders?bad=%zz",
+	})
 
-	args := InvokeServiceArgs{
-		AppID:    "app",
-		Method:   "method",
-		Data:     "{}",
-		HTTPVerb: "POST",
-	}
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "invalid query string")
+}
 
-	result, structured, err := invokeServiceTool(context.Background(), &mcp.CallToolRequest{}, args)
+func TestRegisterTools(t *testing.T) {
+	mockClient := new(mocks.MockInvokeClient)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil)
 
-	assert.NoError(t, err)
-	assert.False(t, result.IsError)
-	assert.NotNil(t, structured)
+	// Should not panic
+	RegisterTools(server, mockClient)
 
-	mockInvoke.AssertExpectations(t)
+	assert.Equal(t, mockClient, invokeClient)
 }
@@ -0,0 +1,288 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+// JobsClient defines the interface for Jobs API operations.
+type JobsClient interface {
+	ScheduleJobAlpha1(ctx context.Context, req *dapr.Job) error
+	GetJobAlpha1(ctx context.Context, name string) (*dapr.Job, error)
+	DeleteJobAlpha1(ctx context.Context, name string) error
+}
+
+type ScheduleJobArgs struct {
+	Name      string `json:"name" jsonschema:"The unique job name (e.g., 'nightly-report'). The app behind the connected sidecar receives the job at /job/{name}."`
+	Schedule  string `json:"schedule,omitempty" jsonschema:"Optional cron expression with seconds first ('0 30 9 * * MON-FRI'), a shorthand ('@daily') or an interval ('@every 10m')."`
+	DueTime   string `json:"dueTime,omitempty" jsonschema:"Optional time of the first run, as an RFC 3339 time, a Go duration ('10m') or an ISO 8601 duration ('PT10M')."`
+	Repeats   uint32 `json:"repeats,omitempty" jsonschema:"Optional number of times the job runs before it is deleted."`
+	TTL       string `json:"ttl,omitempty" jsonschema:"Optional time after which the job expires, in the same formats as dueTime."`
+	Data      any    `json:"data,omitempty" jsonschema:"Optional JSON payload delivered to the app on every run."`
+	Overwrite bool   `json:"overwrite,omitempty" jsonschema:"If true, replace an existing job with the same name instead of failing."`
+}
+
+type JobNameArgs struct {
+	Name string `json:"name" jsonschema:"The name of the scheduled job."`
+}
+
+var jobsClient JobsClient
+
+func jobError(operation string, err error) (*mcp.CallToolResult, any, error) {
+	log.Printf("Dapr %s failed: %v", operation, err)
+	toolErrorMessage := fmt.Errorf("dapr %s failed: %w", operation, err).Error()
+	return toolcall.WithStatus(&mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
+		IsError: true,
+	}, err), nil, nil
+}
+
+func invalidArgumentResult(err error) (*mcp.CallToolResult, any, error) {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}},
+		IsError: true,
+	}, nil, nil
+}
+
+// validateJob checks a job before it is sent to the sidecar, so that typos in
+// schedules and durations come back with an explanation.
+func validateJob(args ScheduleJobArgs) error {
+	if args.Name == "" {
+		return errors.New("name is required")
+	}
+	if args.Schedule == "" && args.DueTime == "" {
+		return errors.New("at least one of schedule and dueTime is required")
+	}
+	if args.Schedule != "" {
+		if err := ValidateSchedule(args.Schedule); err != nil {
+			return fmt.Errorf("invalid schedule: %w", err)
+		}
+	}
+	if args.DueTime != "" {
+		if err := ValidateTime(args.DueTime); err != nil {
+			return fmt.Errorf("invalid dueTime: %w", err)
+		}
+	}
+	if args.TTL != "" {
+		if err := ValidateTime(args.TTL); err != nil {
+			return fmt.Errorf("invalid ttl: %w", err)
+		}
+	}
+	return nil
+}
+
+// encodeData wraps the payload in a google.protobuf.Value, which the sidecar
+// delivers to HTTP apps as JSON.
+func encodeData(data any) (*anypb.Any, error) {
+	value, err := structpb.NewValue(data)
+	if err != nil {
+		return nil, fmt.Errorf("data must be a JSON value: %w", err)
+	}
+	return anypb.New(value)
+}
+
+// decodeData returns the job payload as a JSON value when possible.
+func decodeData(data *anypb.Any) any {
+	if data == nil {
+		return nil
+	}
+	var value structpb.Value
+	if data.MessageIs(&value) && data.UnmarshalTo(&value) == nil {
+		return value.AsInterface()
+	}
+	var decoded any
+	if err := json.Unmarshal(data.GetValue(), &decoded); err == nil {
+		return decoded
+	}
+	return string(data.GetValue())
+}
+
+func scheduleJobTool(ctx context.Context, req *mcp.CallToolRequest, args ScheduleJobArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "schedule_job")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "schedule_job"),
+		attribute.String("dapr.job_name", args.Name),
+	)
+
+	if err := validateJob(args); err != nil {
+		return invalidArgumentResult(err)
+	}
+	data, err := encodeData(args.Data)
+	if err != nil {
+		return invalidArgumentResult(err)
+	}
+
+	opts := []dapr.JobOption{dapr.WithJobData(data)}
+	if args.Schedule != "" {
+		opts = append(opts, dapr.WithJobSchedule(args.Schedule))
+	}
+	if args.DueTime != "" {
+		opts = append(opts, dapr.WithJobDueTime(args.DueTime))
+	}
+	if args.Repeats > 0 {
+		opts = append(opts, dapr.WithJobRepeats(args.Repeats))
+	}
+	if args.TTL != "" {
+		opts = append(opts, dapr.WithJobTTL(args.TTL))
+	}
+	job := dapr.NewJob(args.Name, opts...)
+	job.Overwrite = args.Overwrite
+
+	if err := jobsClient.ScheduleJobAlpha1(ctx, job); err != nil {
+		return jobError("ScheduleJobAlpha1", err)
+	}
+
+	message := fmt.Sprintf("Successfully scheduled job '%s'.", args.Name)
+	log.Println(message)
+	structuredResult := map[string]interface{}{
+		"name": args.Name,
+	}
+	for k, v := range map[string]string{"schedule": args.Schedule, "due_time": args.DueTime, "ttl": args.TTL} {
+		if v != "" {
+			structuredResult[k] = v
+		}
+	}
+	if args.Repeats > 0 {
+		structuredResult["repeats"] = args.Repeats
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message}},
+	}, structuredResult, nil
+}
+
+func getJobTool(ctx context.Context, req *mcp.CallToolRequest, args JobNameArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "get_job")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "get_job"),
+		attribute.String("dapr.job_name", args.Name),
+	)
+
+	job, err := jobsClient.GetJobAlpha1(ctx, args.Name)
+	if err != nil {
+		return jobError("GetJobAlpha1", err)
+	}
+
+	structuredResult := map[string]interface{}{
+		"name": job.Name,
+		"data": decodeData(job.Data),
+	}
+	for k, v := range map[string]*string{"schedule": job.Schedule, "due_time": job.DueTime, "ttl": job.TTL} {
+		if v != nil && *v != "" {
+			structuredResult[k] = *v
+		}
+	}
+	if job.Repeats != nil && *job.Repeats > 0 {
+		structuredResult["repeats"] = *job.Repeats
+	}
+	switch policy := job.FailurePolicy.(type) {
+	case *dapr.JobFailurePolicyDrop:
+		structuredResult["failure_policy"] = map[string]interface{}{"type": "drop"}
+	case *dapr.JobFailurePolicyConstant:
+		constant := map[string]interface{}{"type": "constant"}
+		if policy.MaxRetries != nil {
+			constant["max_retries"] = *policy.MaxRetries
+		}
+		if policy.Interval != nil {
+			constant["interval"] = policy.Interval.String()
+		}
+		structuredResult["failure_policy"] = constant
+	}
+
+	message := fmt.Sprintf("Successfully retrieved job '%s'.", args.Name)
+	log.Println(message)
+	data, _ := json.MarshalIndent(structuredResult, "", "  ")
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message + "\n\nJob:\n" + string(data)}},
+	}, structuredResult, nil
+}
+
+func deleteJobTool(ctx context.Context, req *mcp.CallToolRequest, args JobNameArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "delete_job")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "delete_job"),
+		attribute.String("dapr.job_name", args.Name),
+	)
+
+	if err := jobsClient.DeleteJobAlpha1(ctx, args.Name); err != nil {
+		return jobError("DeleteJobAlpha1", err)
+	}
+
+	message := fmt.Sprintf("Successfully deleted job '%s'.", args.Name)
+	log.Println(message)
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message}},
+	}, map[string]interface{}{"name": args.Name, "deleted": true}, nil
+}
+
+// RegisterTools registers schedule_job, get_job and delete_job. The runtime
+// API has no way to list jobs yet, so there is no list tool. Jobs belong to
+// the app ID of the connected sidecar and cannot target another app.
+func RegisterTools(server *mcp.Server, client JobsClient) {
+	jobsClient = client
+
+	isDestructive := true
+	notDestructive := false
+	isOpenWorld := true
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "schedule_job",
+		Title: "Schedule Job",
+		Description: "Schedules a job with the Dapr Scheduler for the app ID of the sidecar this server is connected to. When the job is due, the sidecar calls THAT app's `/job/{name}` endpoint with `data`. **This is a SIDE-EFFECT action.**\n\n" +
+			"**SCOPE**: Jobs cannot target another app. When the sidecar belongs to the MCP server itself, nothing handles `/job/{name}` and every run fails; only use this tool when the user confirms the connected sidecar's app handles the job.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. For recurring work use `schedule`; for a one-off run use `dueTime` alone.\n" +
+			"2. `schedule` is a cron expression with SIX fields, seconds first: '0 0 9 * * *' runs daily at 09:00:00. Shorthands ('@hourly', '@daily') and intervals ('@every 15m') are accepted.\n" +
+			"3. `repeats` limits the number of runs and `ttl` sets when the job expires.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `name` and at least one of `schedule` or `dueTime`.\n" +
+			"2. **FORMATS**: `dueTime` and `ttl` are RFC 3339 times, Go durations ('90s') or ISO 8601 durations ('PT90S').\n" +
+			"3. **OVERWRITE**: Scheduling an existing name fails unless `overwrite` is true; confirm with the user before overwriting.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: &notDestructive,
+			IdempotentHint:  false,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, scheduleJobTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "get_job",
+		Title: "Get Scheduled Job",
+		Description: "Retrieves a job scheduled with the Dapr Scheduler: its schedule, due time, repeats, TTL, failure policy and data. Only jobs of the connected sidecar's app ID are visible.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `name`. Jobs cannot be listed, so ask the user for the name if unsure.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+			OpenWorldHint:  &isOpenWorld,
+		},
+	}, getJobTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "delete_job",
+		Title: "Delete Scheduled Job",
+		Description: "Deletes a job of the connected sidecar's app ID from the Dapr Scheduler so it no longer runs. **This is a DESTRUCTIVE action.**\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `name`.\n" +
+			"2. **CONFIRMATION**: Confirm the job name with the user before deleting.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: &isDestructive,
+			IdempotentHint:  true,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, deleteJobTool)
+}
@@ -0,0 +1,149 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+	"github.com/dapr/dapr-mcp-server/test/testutil"
+)
+
+func TestScheduleJobTool(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	jobsClient = mockClient
+
+	var job *dapr.Job
+	mockClient.On("ScheduleJobAlpha1", mock.Anything, mock.AnythingOfType("*client.Job")).
+		Run(func(args mock.Arguments) { job = args.Get(1).(*dapr.Job) }).
+		Return(nil)
+
+	result, structured, err := scheduleJobTool(context.Background(), &mcp.CallToolRequest{}, ScheduleJobArgs{
+		Name:      "nightly-report",
+		Schedule:  "0 0 2 * * *",
+		Repeats:   7,
+		TTL:       "P7D",
+		Data:      map[string]any{"report": "sales"},
+		Overwrite: true,
+	})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, testutil.ResultText(t, result), "Successfully scheduled job 'nightly-report'.")
+	assert.Equal(t, uint32(7), structured.(map[string]interface{})["repeats"])
+
+	require.NotNil(t, job)
+	assert.Equal(t, "0 0 2 * * *", *job.Schedule)
+	assert.Equal(t, uint32(7), *job.Repeats)
+	assert.Equal(t, "P7D", *job.TTL)
+	assert.Nil(t, job.DueTime)
+	assert.True(t, job.Overwrite)
+	assert.Equal(t, "type.googleapis.com/google.protobuf.Value", job.Data.GetTypeUrl())
+	assert.Equal(t, map[string]any{"report": "sales"}, decodeData(job.Data))
+	mockClient.AssertExpectations(t)
+}
+
+func TestScheduleJobToolValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        ScheduleJobArgs
+		wantContent string
+	}{
+		{"missing name", ScheduleJobArgs{Schedule: "@daily"}, "name is required"},
+		{"missing schedule and due time", ScheduleJobArgs{Name: "job"}, "at least one of schedule and dueTime is required"},
+		{"five-field cron", ScheduleJobArgs{Name: "job", Schedule: "0 2 * * *"}, "invalid schedule: cron expression '0 2 * * *' has 5 fields"},
+		{"bad due time", ScheduleJobArgs{Name: "job", DueTime: "soon"}, "invalid dueTime"},
+		{"bad ttl", ScheduleJobArgs{Name: "job", DueTime: "10m", TTL: "PT"}, "invalid ttl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(mocks.MockDaprClient)
+			jobsClient = mockClient
+
+			result, _, err := scheduleJobTool(context.Background(), &mcp.CallToolRequest{}, tt.args)
+			require.NoError(t, err)
+			assert.True(t, result.IsError)
+			assert.Contains(t, testutil.ResultText(t, result), tt.wantContent)
+			assert.NotContains(t, testutil.ResultText(t, result), "ScheduleJobAlpha1")
+			mockClient.AssertNotCalled(t, "ScheduleJobAlpha1", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestScheduleJobToolError(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	jobsClient = mockClient
+	mockClient.On("ScheduleJobAlpha1", mock.Anything, mock.Anything).Return(errors.New("job already exists"))
+
+	result, _, err := scheduleJobTool(context.Background(), &mcp.CallToolRequest{}, ScheduleJobArgs{Name: "job", DueTime: "2025-01-01T09:00:00Z"})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, testutil.ResultText(t, result), "dapr ScheduleJobAlpha1 failed: job already exists")
+}
+
+func TestGetJobTool(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	jobsClient = mockClient
+
+	data, err := encodeData([]any{"a", "b"})
+	require.NoError(t, err)
+	schedule, empty, repeats, retries, interval := "@every 1h", "", uint32(3), uint32(2), 5*time.Second
+	mockClient.On("GetJobAlpha1", mock.Anything, "cleanup").Return(&dapr.Job{
+		Name:          "cleanup",
+		Schedule:      &schedule,
+		DueTime:       &empty,
+		Repeats:       &repeats,
+		Data:          data,
+		FailurePolicy: &dapr.JobFailurePolicyConstant{MaxRetries: &retries, Interval: &interval},
+	}, nil)
+	mockClient.On("GetJobAlpha1", mock.Anything, "raw").Return(&dapr.Job{Name: "raw", Data: &anypb.Any{Value: []byte("plain text")}}, nil)
+	mockClient.On("GetJobAlpha1", mock.Anything, "missing").Return(nil, errors.New("job not found"))
+
+	result, structured, err := getJobTool(context.Background(), &mcp.CallToolRequest{}, JobNameArgs{Name: "cleanup"})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	job := structured.(map[string]interface{})
+	assert.Equal(t, "@every 1h", job["schedule"])
+	assert.NotContains(t, job, "due_time")
+	assert.Equal(t, uint32(3), job["repeats"])
+	assert.Equal(t, []any{"a", "b"}, job["data"])
+	assert.Equal(t, map[string]interface{}{"type": "constant", "max_retries": uint32(2), "interval": "5s"}, job["failure_policy"])
+
+	_, structured, err = getJobTool(context.Background(), &mcp.CallToolRequest{}, JobNameArgs{Name: "raw"})
+	require.NoError(t, err)
+	assert.Equal(t, "plain text", structured.(map[string]interface{})["data"])
+
+	result, _, err = getJobTool(context.Background(), &mcp.CallToolRequest{}, JobNameArgs{Name: "missing"})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, testutil.ResultText(t, result), "dapr GetJobAlpha1 failed: job not found")
+}
+
+func TestDeleteJobTool(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	jobsClient = mockClient
+	mockClient.On("DeleteJobAlpha1", mock.Anything, "cleanup").Return(nil)
+
+	result, structured, err := deleteJobTool(context.Background(), &mcp.CallToolRequest{}, JobNameArgs{Name: "cleanup"})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, true, structured.(map[string]interface{})["deleted"])
+	mockClient.AssertExpectations(t)
+}
+
+func TestRegisterTools(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil)
+
+	RegisterTools(server, mockClient)
+
+	assert.Equal(t, mockClient, jobsClient)
+}
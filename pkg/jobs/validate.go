@@ -0,0 +1,157 @@
+package jobs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField describes the values accepted by one field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+	question bool
+}
+
+var (
+	months = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	weekdays = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+
+	// cronFields are the six fields of a Scheduler cron expression, which
+	// starts with seconds.
+	cronFields = []cronField{
+		{name: "second", min: 0, max: 59},
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31, question: true},
+		{name: "month", min: 1, max: 12, names: months},
+		{name: "day of week", min: 0, max: 6, names: weekdays, question: true},
+	}
+
+	cronShorthands = map[string]bool{
+		"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+		"@daily": true, "@midnight": true, "@hourly": true,
+	}
+
+	isoDuration = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+)
+
+// ValidateSchedule checks a job schedule: a six-field cron expression with
+// seconds first (e.g., "0 30 * * * *"), a shorthand such as "@daily", or
+// "@every" followed by a Go duration.
+func ValidateSchedule(schedule string) error {
+	schedule = strings.TrimSpace(schedule)
+	if strings.HasPrefix(schedule, "@") {
+		if every, ok := strings.CutPrefix(schedule, "@every "); ok {
+			d, err := time.ParseDuration(strings.TrimSpace(every))
+			if err != nil {
+				return fmt.Errorf("invalid @every interval '%s': %w", every, err)
+			}
+			if d <= 0 {
+				return fmt.Errorf("@every interval must be positive, got '%s'", every)
+			}
+			return nil
+		}
+		if !cronShorthands[schedule] {
+			return fmt.Errorf("unknown schedule shorthand '%s'", schedule)
+		}
+		return nil
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("cron expression '%s' has %d fields, expected 6 (second minute hour day-of-month month day-of-week)", schedule, len(fields))
+	}
+	for i, field := range fields {
+		if err := cronFields[i].validate(field); err != nil {
+			return fmt.Errorf("invalid %s field '%s': %w", cronFields[i].name, field, err)
+		}
+	}
+	return nil
+}
+
+func (f cronField) validate(field string) error {
+	if field == "?" {
+		if !f.question {
+			return fmt.Errorf("'?' is only allowed in the day fields")
+		}
+		return nil
+	}
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("step '%s' must be a positive number", step)
+			}
+		}
+		if rangePart == "*" {
+			continue
+		}
+		low, high, isRange := strings.Cut(rangePart, "-")
+		lo, err := f.value(low)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			continue
+		}
+		hi, err := f.value(high)
+		if err != nil {
+			return err
+		}
+		if lo > hi {
+			return fmt.Errorf("range '%s' starts after it ends", rangePart)
+		}
+	}
+	return nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if n, ok := f.names[strings.ToUpper(s)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a number", s)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%d is outside %d-%d", n, f.min, f.max)
+	}
+	return n, nil
+}
+
+// ValidateISODuration checks an ISO 8601 duration such as "PT30S" or
+// "P1DT12H".
+func ValidateISODuration(s string) error {
+	m := isoDuration.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return fmt.Errorf("'%s' is not an ISO 8601 duration (e.g., 'PT30S', 'P1DT12H')", s)
+	}
+	return nil
+}
+
+// ValidateTime checks a due time or TTL, which the Scheduler accepts as an
+// RFC 3339 time, a Go duration ("1h30m") or an ISO 8601 duration ("PT1H30M").
+func ValidateTime(s string) error {
+	if _, err := time.Parse(time.RFC3339, s); err == nil {
+		return nil
+	}
+	if strings.HasPrefix(s, "P") {
+		return ValidateISODuration(s)
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("'%s' is not an RFC 3339 time, a Go duration or an ISO 8601 duration", s)
+	}
+	if d < 0 {
+		return fmt.Errorf("'%s' must not be negative", s)
+	}
+	return nil
+}
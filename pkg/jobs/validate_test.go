@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	for _, schedule := range []string{
+		"0 30 9 * * *",
+		"*/15 * * * * *",
+		"0 0 9 ? * MON-FRI",
+		"0 0 0 1,15 jan-jun *",
+		"0 0 12 * * 0",
+		"@daily",
+		"@every 1h30m",
+	} {
+		assert.NoError(t, ValidateSchedule(schedule), schedule)
+	}
+
+	for schedule, want := range map[string]string{
+		"30 9 * * *":      "has 5 fields, expected 6",
+		"60 * * * * *":    "invalid second field '60': 60 is outside 0-59",
+		"0 0 25 * * *":    "invalid hour field",
+		"0 0 0 0 * *":     "invalid day of month field",
+		"0 0 0 * FOO *":   "'FOO' is not a number",
+		"0 0 0 * * 5-1":   "starts after it ends",
+		"*/0 * * * * *":   "step '0' must be a positive number",
+		"0 ? * * * *":     "'?' is only allowed in the day fields",
+		"@fortnightly":    "unknown schedule shorthand",
+		"@every tomorrow": "invalid @every interval",
+		"@every -1m":      "must be positive",
+	} {
+		err := ValidateSchedule(schedule)
+		if assert.Error(t, err, schedule) {
+			assert.Contains(t, err.Error(), want, schedule)
+		}
+	}
+}
+
+func TestValidateTime(t *testing.T) {
+	for _, value := range []string{"2025-01-01T09:00:00Z", "90s", "1h30m", "PT90S", "P1DT12H", "P2W", "PT0.5S"} {
+		assert.NoError(t, ValidateTime(value), value)
+	}
+	for _, value := range []string{"", "tomorrow", "-5m", "P", "PT", "P1H", "PT1D", "P1DT"} {
+		assert.Error(t, ValidateTime(value), value)
+	}
+}
@@ -2,6 +2,7 @@ package lock
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -9,10 +10,15 @@ import (
 	dapr "github.com/dapr/go-sdk/client"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.opentelemetry.io/otel"
+
+	"github.com/dapr/dapr-mcp-server/pkg/dryrun"
+	"github.com/dapr/dapr-mcp-server/pkg/metadata"
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
 )
 
 // LockClient defines the interface for lock operations.
 type LockClient interface {
+	metadata.MetadataClient
 	TryLockAlpha1(ctx context.Context, storeName string, req *dapr.LockRequest) (*dapr.LockResponse, error)
 	UnlockAlpha1(ctx context.Context, storeName string, req *dapr.UnlockRequest) (*dapr.UnlockResponse, error)
 }
@@ -28,6 +34,7 @@ type ReleaseLockArgs struct {
 	StoreName  string `json:"storeName" jsonschema:"The name of the Dapr lock store component."`
 	ResourceID string `json:"resourceID" jsonschema:"The unique name of the resource whose lock should be released."`
 	LockOwner  string `json:"lockOwner" jsonschema:"The unique identifier of the entity that currently holds the lock."`
+	DryRun     bool   `json:"dryRun,omitempty" jsonschema:"If true, validate the request and describe what would be sent to the sidecar without releasing the lock."`
 }
 
 var lockClient LockClient
@@ -49,10 +56,10 @@ func acquireLockTool(ctx context.Context, req *mcp.CallToolRequest, args Acquire
 	if err != nil {
 		log.Printf("Dapr TryLockAlpha1 failed: %v", err)
 		toolErrorMessage := fmt.Errorf("dapr API error while trying to acquire lock: %w", err).Error()
-		return &mcp.CallToolResult{
+		return toolcall.WithStatus(&mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
 			IsError: true,
-		}, nil, nil
+		}, err), nil, nil
 	}
 
 	var successMessage string
@@ -81,6 +88,10 @@ func releaseLockTool(ctx context.Context, req *mcp.CallToolRequest, args Release
 	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "release_lock")
 	defer span.End()
 
+	if dryrun.Enabled(args.DryRun) {
+		return planReleaseLock(ctx, args)
+	}
+
 	unlockReq := &dapr.UnlockRequest{
 		LockOwner:  args.LockOwner,
 		ResourceID: args.ResourceID,
@@ -90,10 +101,10 @@ func releaseLockTool(ctx context.Context, req *mcp.CallToolRequest, args Release
 	if err != nil {
 		log.Printf("Dapr UnlockAlpha1 failed: %v", err)
 		toolErrorMessage := fmt.Errorf("dapr API error while trying to release lock: %w", err).Error()
-		return &mcp.CallToolResult{
+		return toolcall.WithStatus(&mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
 			IsError: true,
-		}, nil, nil
+		}, err), nil, nil
 	}
 
 	var statusMessage string
@@ -132,8 +143,31 @@ func releaseLockTool(ctx context.Context, req *mcp.CallToolRequest, args Release
 	}, structuredResult, nil
 }
 
+func planReleaseLock(ctx context.Context, args ReleaseLockArgs) (*mcp.CallToolResult, any, error) {
+	if args.StoreName == "" || args.ResourceID == "" || args.LockOwner == "" {
+		return dryrun.Invalid("release_lock", errors.New("storeName, resourceID and lockOwner are required"))
+	}
+	comp, err := metadata.FindComponent(ctx, lockClient, args.StoreName, "lock.")
+	if err != nil {
+		return dryrun.Invalid("release_lock", err)
+	}
+
+	return dryrun.Result(dryrun.Plan{
+		Tool:          "release_lock",
+		Operation:     "UnlockAlpha1",
+		Component:     comp.Name,
+		ComponentType: comp.Type,
+		Request: map[string]interface{}{
+			"storeName":  args.StoreName,
+			"resourceID": args.ResourceID,
+			"lockOwner":  args.LockOwner,
+		},
+	})
+}
+
 func RegisterTools(server *mcp.Server, client LockClient) {
 	lockClient = client
+	dryrun.Plans("release_lock")
 
 	notDestructive := false
 	acquireIsIdempotent := true
@@ -171,7 +205,8 @@ func RegisterTools(server *mcp.Server, client LockClient) {
 			"**ARGUMENT RULES:**\n" +
 			"1. **REQUIRED INPUTS**: You MUST provide `storeName`, `resourceID`, and the correct `lockOwner`.\n" +
 			"2. **OWNERSHIP**: Only the entity that acquired the lock can release it.\n" +
-			"3. **CLARIFICATION**: If any required input is missing, you MUST ask the user for clarification.\n\n" +
+			"3. **CLARIFICATION**: If any required input is missing, you MUST ask the user for clarification.\n" +
+			"4. **DRY RUN**: Set `DryRun` to preview the unlock request without releasing the lock.\n\n" +
 			"**WORKFLOW RULE**: This tool must be used as the final step in a critical concurrency workflow.",
 		Annotations: &mcp.ToolAnnotations{
 			ReadOnlyHint:    false,
@@ -267,6 +267,14 @@ func (m *mockLockClient) UnlockAlpha1(ctx context.Context, storeName string, req
 	return args.Get(0).(*dapr.UnlockResponse), args.Error(1)
 }
 
+func (m *mockLockClient) GetMetadata(ctx context.Context) (*dapr.GetMetadataResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dapr.GetMetadataResponse), args.Error(1)
+}
+
 func TestAcquireLockToolWithInterfaceMock(t *testing.T) {
 	mockLock := new(mockLockClient)
 	mockLock.On("TryLockAlpha1", mock.Anything, "test-store", mock.AnythingOfType("*client.LockRequest")).
@@ -293,3 +301,23 @@ func TestAcquireLockToolWithInterfaceMock(t *testing.T) {
 
 	mockLock.AssertExpectations(t)
 }
+
+func TestReleaseLockDryRun(t *testing.T) {
+	mockLock := new(mockLockClient)
+	mockLock.On("GetMetadata", mock.Anything).Return(&dapr.GetMetadataResponse{
+		RegisteredComponents: []*dapr.MetadataRegisteredComponents{{Name: "redis-lock", Type: "lock.redis"}},
+	}, nil)
+	lockClient = mockLock
+
+	result, _, err := releaseLockTool(context.Background(), &mcp.CallToolRequest{}, ReleaseLockArgs{
+		StoreName:  "redis-lock",
+		ResourceID: "inventory",
+		LockOwner:  "agent-1",
+		DryRun:     true,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "would call UnlockAlpha1 on component 'redis-lock' (lock.redis)")
+	mockLock.AssertNotCalled(t, "UnlockAlpha1")
+}
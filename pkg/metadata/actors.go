@@ -0,0 +1,126 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
+
+	"github.com/dapr/dapr-mcp-server/pkg/resources"
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+// ActorsURI is the URI of the actor runtime resource.
+const ActorsURI = "dapr://actors"
+
+// ActorRuntimeClient reads the sidecar metadata from the Dapr gRPC API. The
+// SDK's GetMetadata drops the actor runtime status, so the raw API is used.
+type ActorRuntimeClient interface {
+	GetMetadata(ctx context.Context, in *pb.GetMetadataRequest, opts ...grpc.CallOption) (*pb.GetMetadataResponse, error)
+}
+
+type ActorTypeInfo struct {
+	Type        string `json:"type" jsonschema:"The actor type registered by the app (e.g., 'cart')."`
+	ActiveCount int32  `json:"active_count" jsonschema:"The number of actors of this type currently active in this sidecar."`
+}
+
+type ActorRuntimeInfo struct {
+	AppID         string          `json:"app_id" jsonschema:"The app ID of this sidecar."`
+	RuntimeStatus string          `json:"runtime_status" jsonschema:"The actor runtime status: INITIALIZING, DISABLED or RUNNING."`
+	HostReady     bool            `json:"host_ready" jsonschema:"Whether the actor runtime is ready to host actors."`
+	Placement     string          `json:"placement,omitempty" jsonschema:"The status message of the placement service."`
+	ActorTypes    []ActorTypeInfo `json:"actor_types" jsonschema:"The actor types hosted by this sidecar's app."`
+}
+
+var actorRuntimeClient ActorRuntimeClient
+
+// GetActorRuntime reports the actor runtime status and the actor types hosted
+// by this sidecar's app, with their active actor counts.
+func GetActorRuntime(ctx context.Context, client ActorRuntimeClient) (ActorRuntimeInfo, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "get_actor_types")
+	defer span.End()
+	span.SetAttributes(attribute.String("dapr.operation", "get_actor_types"))
+
+	resp, err := client.GetMetadata(ctx, &pb.GetMetadataRequest{})
+	if err != nil {
+		return ActorRuntimeInfo{}, fmt.Errorf("failed to fetch Dapr metadata: %w", err)
+	}
+
+	runtime := resp.GetActorRuntime()
+	info := ActorRuntimeInfo{
+		AppID:         resp.GetId(),
+		RuntimeStatus: runtime.GetRuntimeStatus().String(),
+		HostReady:     runtime.GetHostReady(),
+		Placement:     runtime.GetPlacement(),
+		ActorTypes:    []ActorTypeInfo{},
+	}
+	for _, actor := range runtime.GetActiveActors() {
+		info.ActorTypes = append(info.ActorTypes, ActorTypeInfo{Type: actor.GetType(), ActiveCount: actor.GetCount()})
+	}
+	sort.Slice(info.ActorTypes, func(i, j int) bool { return info.ActorTypes[i].Type < info.ActorTypes[j].Type })
+	return info, nil
+}
+
+func getActorTypesTool(ctx context.Context, req *mcp.CallToolRequest, args any) (
+	*mcp.CallToolResult,
+	ActorRuntimeInfo,
+	error,
+) {
+	info, err := GetActorRuntime(ctx, actorRuntimeClient)
+	if err != nil {
+		log.Printf("Error calling getActorTypesTool: %v", err)
+		toolErrorMessage := fmt.Sprintf("Error fetching the actor runtime status: %v", err)
+		return toolcall.WithStatus(&mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
+			IsError: true,
+		}, err), ActorRuntimeInfo{}, nil
+	}
+
+	message := fmt.Sprintf("The actor runtime is %s and hosts %d actor type(s). The details are returned in the structured result.", info.RuntimeStatus, len(info.ActorTypes))
+	if !info.HostReady {
+		message += " The runtime is not ready to host actors yet."
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message}},
+	}, info, nil
+}
+
+func readActorsResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	info, err := GetActorRuntime(ctx, actorRuntimeClient)
+	if err != nil {
+		return nil, err
+	}
+	return resources.JSONResult(req.Params.URI, info)
+}
+
+// RegisterActorTools registers get_actor_types and the actor runtime resource.
+func RegisterActorTools(server *mcp.Server, client ActorRuntimeClient) {
+	actorRuntimeClient = client
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "get_actor_types",
+		Title: "Discover Actor Types",
+		Description: "Retrieves the status of the Dapr actor runtime and the actor types hosted by this sidecar's app, with the number of active actors of each type.\n\n" +
+			"**SCOPE**: This is NOT a cluster-wide list. Dapr cannot list the actor types of other apps, and this sidecar's app is usually the MCP server itself, which hosts no actors. An empty list does not mean the cluster has no actors.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Actor types hosted by other apps are not listed but can still be called; ask the user for those.\n" +
+			"2. A `runtime_status` other than RUNNING, or `host_ready: false`, means actor calls are likely to fail.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}, getActorTypesTool)
+
+	server.AddResource(&mcp.Resource{
+		URI:         ActorsURI,
+		Name:        "actors",
+		Title:       "Actor Runtime",
+		Description: "The actor runtime status, placement message and the actor types hosted by this sidecar's app with their active actor counts. Types hosted by other apps are not included.",
+		MIMEType:    "application/json",
+	}, readActorsResource)
+}
@@ -0,0 +1,101 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeRuntimeClient returns a fixed metadata response.
+type fakeRuntimeClient struct {
+	resp *pb.GetMetadataResponse
+	err  error
+}
+
+func (f *fakeRuntimeClient) GetMetadata(ctx context.Context, in *pb.GetMetadataRequest, opts ...grpc.CallOption) (*pb.GetMetadataResponse, error) {
+	return f.resp, f.err
+}
+
+var runningActors = &pb.GetMetadataResponse{
+	Id: "order-service",
+	ActorRuntime: &pb.ActorRuntime{
+		RuntimeStatus: pb.ActorRuntime_RUNNING,
+		HostReady:     true,
+		Placement:     "placement: connected",
+		ActiveActors: []*pb.ActiveActorsCount{
+			{Type: "shopping-cart", Count: 3},
+			{Type: "payment", Count: 0},
+		},
+	},
+}
+
+func TestGetActorTypesTool(t *testing.T) {
+	tests := []struct {
+		name        string
+		client      *fakeRuntimeClient
+		wantErr     bool
+		wantContent string
+		want        ActorRuntimeInfo
+	}{
+		{
+			name:        "running runtime",
+			client:      &fakeRuntimeClient{resp: runningActors},
+			wantContent: "The actor runtime is RUNNING and hosts 2 actor type(s).",
+			want: ActorRuntimeInfo{
+				AppID:         "order-service",
+				RuntimeStatus: "RUNNING",
+				HostReady:     true,
+				Placement:     "placement: connected",
+				ActorTypes:    []ActorTypeInfo{{Type: "payment", ActiveCount: 0}, {Type: "shopping-cart", ActiveCount: 3}},
+			},
+		},
+		{
+			name:        "no actor runtime",
+			client:      &fakeRuntimeClient{resp: &pb.GetMetadataResponse{Id: "web", ActorRuntime: &pb.ActorRuntime{RuntimeStatus: pb.ActorRuntime_DISABLED}}},
+			wantContent: "The actor runtime is DISABLED and hosts 0 actor type(s). The details are returned in the structured result. The runtime is not ready to host actors yet.",
+			want:        ActorRuntimeInfo{AppID: "web", RuntimeStatus: "DISABLED", ActorTypes: []ActorTypeInfo{}},
+		},
+		{
+			name:        "metadata error",
+			client:      &fakeRuntimeClient{err: errors.New("connection refused")},
+			wantErr:     true,
+			wantContent: "Error fetching the actor runtime status: failed to fetch Dapr metadata: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actorRuntimeClient = tt.client
+
+			result, info, err := getActorTypesTool(context.Background(), &mcp.CallToolRequest{}, nil)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantErr, result.IsError)
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, tt.wantContent)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, info)
+			}
+		})
+	}
+}
+
+func TestActorsResource(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil)
+	RegisterActorTools(server, &fakeRuntimeClient{resp: runningActors})
+
+	result, err := readActorsResource(context.Background(), &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: ActorsURI}})
+
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	var info ActorRuntimeInfo
+	require.NoError(t, json.Unmarshal([]byte(result.Contents[0].Text), &info))
+	assert.Equal(t, "RUNNING", info.RuntimeStatus)
+	assert.Len(t, info.ActorTypes, 2)
+}
@@ -9,6 +9,8 @@ import (
 	dapr "github.com/dapr/go-sdk/client"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.opentelemetry.io/otel"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
 )
 
 // MetadataClient defines the interface for metadata operations.
@@ -27,6 +29,27 @@ type ComponentInfo struct {
 	Capabilities []string `json:"capabilities" jsonschema:"The capabilities of the Component."`
 }
 
+const (
+	// CapabilityQueryAPI is the capability reported by state stores that support the Query API.
+	CapabilityQueryAPI = "QUERY_API"
+	// CapabilityTTL is the capability reported by state stores that support per-key expiry.
+	CapabilityTTL = "TTL"
+	// CapabilityTransactional is the capability reported by state stores that support transactions.
+	CapabilityTransactional = "TRANSACTIONAL"
+	// CapabilityActor is the capability reported by the state store configured for actors and workflows.
+	CapabilityActor = "ACTOR"
+)
+
+// HasCapability reports whether the component advertises the given capability.
+func (c ComponentInfo) HasCapability(capability string) bool {
+	for _, have := range c.Capabilities {
+		if strings.EqualFold(have, capability) {
+			return true
+		}
+	}
+	return false
+}
+
 var metadataClient MetadataClient
 
 func GetLiveComponentList(ctx context.Context, client MetadataClient) ([]ComponentInfo, error) {
@@ -43,6 +66,7 @@ func GetLiveComponentList(ctx context.Context, client MetadataClient) ([]Compone
 		if strings.Contains(component.Type, "pubsub") ||
 			strings.Contains(component.Type, "state") ||
 			strings.Contains(component.Type, "binding") ||
+			strings.Contains(component.Type, "configuration") ||
 			strings.Contains(component.Type, "conversation") ||
 			strings.Contains(component.Type, "secretstores") ||
 			strings.Contains(component.Type, "lock") ||
@@ -64,6 +88,21 @@ func GetLiveComponentList(ctx context.Context, client MetadataClient) ([]Compone
 	return components, nil
 }
 
+// FindComponent returns the registered component with the given name whose type
+// starts with typePrefix (e.g., "state.").
+func FindComponent(ctx context.Context, client MetadataClient, name, typePrefix string) (ComponentInfo, error) {
+	components, err := GetLiveComponentList(ctx, client)
+	if err != nil {
+		return ComponentInfo{}, err
+	}
+	for _, comp := range components {
+		if comp.Name == name && strings.HasPrefix(comp.Type, typePrefix) {
+			return comp, nil
+		}
+	}
+	return ComponentInfo{}, fmt.Errorf("no %s component named '%s' was found in the sidecar", strings.TrimSuffix(typePrefix, "."), name)
+}
+
 func getMetadataTool(ctx context.Context, req *mcp.CallToolRequest, args any) (
 	*mcp.CallToolResult,
 	ComponentListWrapper,
@@ -82,10 +121,10 @@ func getMetadataTool(ctx context.Context, req *mcp.CallToolRequest, args any) (
 	if err != nil {
 		log.Printf("Error calling getMetadataTool: %v", err)
 		toolErrorMessage := fmt.Sprintf("Error fetching live Dapr component list: %v", err)
-		return &mcp.CallToolResult{
+		return toolcall.WithStatus(&mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
 			IsError: true,
-		}, ComponentListWrapper{}, nil
+		}, err), ComponentListWrapper{}, nil
 	}
 	log.Printf("Components: %s", components)
 
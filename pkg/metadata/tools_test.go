@@ -62,12 +62,13 @@ func TestGetMetadataTool(t *testing.T) {
 						{Name: "secretstore", Type: "secretstores.vault", Version: "v1", Capabilities: []string{}},
 						{Name: "lockstore", Type: "lock.redis", Version: "v1", Capabilities: []string{}},
 						{Name: "cryptostore", Type: "crypto.azure", Version: "v1", Capabilities: []string{}},
+						{Name: "configstore", Type: "configuration.redis", Version: "v1", Capabilities: []string{}},
 					},
 				}, nil)
 			},
 			wantErr:       false,
-			wantContent:   "Successfully retrieved 7 Dapr component(s)",
-			expectedCount: 7,
+			wantContent:   "Successfully retrieved 8 Dapr component(s)",
+			expectedCount: 8,
 		},
 		{
 			name: "metadata retrieval failure",
@@ -251,6 +252,36 @@ func TestComponentInfoStruct(t *testing.T) {
 	assert.Len(t, component.Capabilities, 2)
 }
 
+func TestComponentInfoHasCapability(t *testing.T) {
+	component := ComponentInfo{
+		Name:         "statestore",
+		Type:         "state.mongodb",
+		Capabilities: []string{"ETAG", "TRANSACTIONAL", "QUERY_API"},
+	}
+
+	assert.True(t, component.HasCapability(CapabilityQueryAPI))
+	assert.True(t, component.HasCapability("query_api"))
+	assert.False(t, component.HasCapability("ACTOR"))
+	assert.False(t, ComponentInfo{}.HasCapability(CapabilityQueryAPI))
+}
+
+func TestFindComponent(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	mockClient.On("GetMetadata", mock.Anything).Return(&dapr.GetMetadataResponse{
+		RegisteredComponents: []*dapr.MetadataRegisteredComponents{
+			{Name: "shared", Type: "pubsub.redis", Version: "v1"},
+			{Name: "shared", Type: "state.redis", Version: "v1"},
+		},
+	}, nil)
+
+	comp, err := FindComponent(context.Background(), mockClient, "shared", "state.")
+	assert.NoError(t, err)
+	assert.Equal(t, "state.redis", comp.Type)
+
+	_, err = FindComponent(context.Background(), mockClient, "shared", "lock.")
+	assert.ErrorContains(t, err, "no lock component named 'shared'")
+}
+
 func TestComponentListWrapper(t *testing.T) {
 	wrapper := ComponentListWrapper{
 		Components: []ComponentInfo{
@@ -0,0 +1,144 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+// BulkPublishEntry is a single message in a publish_events_bulk call.
+type BulkPublishEntry struct {
+	EntryID     string            `json:"entryId,omitempty" jsonschema:"Optional unique ID for this entry, used to report failures. Generated when empty."`
+	Message     string            `json:"message" jsonschema:"The message payload to publish, typically a JSON string."`
+	ContentType string            `json:"contentType,omitempty" jsonschema:"Optional content type of the message (default 'application/json')."`
+	Metadata    map[string]string `json:"metadata,omitempty" jsonschema:"Optional per-entry metadata (e.g., 'ttlInSeconds')."`
+}
+
+type PublishBulkArgs struct {
+	PubsubName string             `json:"pubsubName" jsonschema:"The name of the Dapr pubsub component (e.g., 'pubsub')."`
+	Topic      string             `json:"topic" jsonschema:"The topic to publish the messages to (e.g., 'orders')."`
+	Entries    []BulkPublishEntry `json:"entries" jsonschema:"The messages to publish."`
+	Metadata   map[string]string  `json:"metadata,omitempty" jsonschema:"Optional metadata applied to the whole request."`
+	RawPayload bool               `json:"rawPayload,omitempty" jsonschema:"Optional. When true, messages are published as-is without CloudEvent envelopes."`
+}
+
+// bulkPublishEvents converts entries into SDK events, generating missing entry IDs.
+func bulkPublishEvents(entries []BulkPublishEntry) ([]interface{}, error) {
+	if len(entries) == 0 {
+		return nil, errors.New("at least one entry is required")
+	}
+	events := make([]interface{}, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for i, entry := range entries {
+		entryID := entry.EntryID
+		if entryID == "" {
+			entryID = uuid.NewString()
+		}
+		if seen[entryID] {
+			return nil, fmt.Errorf("entry %d: duplicate entryId '%s'", i, entryID)
+		}
+		seen[entryID] = true
+
+		contentType := entry.ContentType
+		if contentType == "" {
+			contentType = defaultContentType
+		}
+		events = append(events, dapr.PublishEventsEvent{
+			EntryID:     entryID,
+			Data:        []byte(entry.Message),
+			ContentType: contentType,
+			Metadata:    entry.Metadata,
+		})
+	}
+	return events, nil
+}
+
+// failedEntryIDs extracts the entry IDs from the SDK's failed events.
+func failedEntryIDs(failed []interface{}) []string {
+	ids := make([]string, 0, len(failed))
+	for _, event := range failed {
+		switch e := event.(type) {
+		case dapr.PublishEventsEvent:
+			ids = append(ids, e.EntryID)
+		case string:
+			ids = append(ids, e)
+		}
+	}
+	return ids
+}
+
+func publishEventsBulkTool(ctx context.Context, req *mcp.CallToolRequest, args PublishBulkArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "publish_events_bulk")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "publish_events_bulk"),
+		attribute.String("dapr.pubsub", args.PubsubName),
+		attribute.String("dapr.topic", args.Topic),
+		attribute.Int("dapr.entries_count", len(args.Entries)),
+	)
+
+	events, err := bulkPublishEvents(args.Entries)
+	if err != nil {
+		return invalidArgumentResult(err)
+	}
+
+	metadata := make(map[string]string, len(args.Metadata))
+	for k, v := range args.Metadata {
+		metadata[k] = v
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(metadata))
+
+	opts := []dapr.PublishEventsOption{dapr.PublishEventsWithMetadata(metadata)}
+	if args.RawPayload {
+		opts = append(opts, dapr.PublishEventsWithRawPayload())
+	}
+
+	res := pubsubClient.PublishEvents(ctx, args.PubsubName, args.Topic, events, opts...)
+	failed := failedEntryIDs(res.FailedEvents)
+	if res.Error == nil && len(failed) == 0 {
+		successMessage := fmt.Sprintf("Successfully published %d message(s) to topic '%s' on pubsub component '%s'.", len(events), args.Topic, args.PubsubName)
+		log.Println(successMessage)
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: successMessage}},
+		}, map[string]interface{}{
+			"status":      "published",
+			"pubsub_name": args.PubsubName,
+			"topic":       args.Topic,
+			"published":   len(events),
+			"failed":      0,
+		}, nil
+	}
+
+	log.Printf("Dapr PublishEvents failed for %d of %d entries: %v", len(failed), len(events), res.Error)
+	status := "partially_published"
+	if len(failed) == len(events) {
+		status = "failed"
+	}
+	toolErrorMessage := fmt.Sprintf("%d of %d message(s) failed to publish to topic '%s' on pubsub '%s'. Failed entry IDs: %s.",
+		len(failed), len(events), args.Topic, args.PubsubName, strings.Join(failed, ", "))
+	if res.Error != nil {
+		toolErrorMessage += fmt.Sprintf(" Dapr Error: %v", res.Error)
+	}
+	return toolcall.WithStatus(&mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
+		IsError: true,
+	}, res.Error), map[string]interface{}{
+		"status":           status,
+		"pubsub_name":      args.PubsubName,
+		"topic":            args.Topic,
+		"published":        len(events) - len(failed),
+		"failed":           len(failed),
+		"failed_entry_ids": failed,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+)
+
+func TestPublishEventsBulkTool(t *testing.T) {
+	entries := []BulkPublishEntry{
+		{EntryID: "order-1", Message: `{"orderId":"1"}`},
+		{EntryID: "order-2", Message: "order 2", ContentType: "text/plain", Metadata: map[string]string{"ttlInSeconds": "60"}},
+		{Message: `{"orderId":"3"}`},
+	}
+
+	t.Run("all entries published", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		var sent []interface{}
+		mockClient.On("PublishEvents", mock.Anything, "pubsub", "orders", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { sent = args.Get(3).([]interface{}) }).
+			Return(dapr.PublishEventsResponse{FailedEvents: []interface{}{}})
+		pubsubClient = mockClient
+
+		result, structured, err := publishEventsBulkTool(context.Background(), &mcp.CallToolRequest{}, PublishBulkArgs{
+			PubsubName: "pubsub",
+			Topic:      "orders",
+			Entries:    entries,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "Successfully published 3 message(s)")
+		assert.Equal(t, 3, structured.(map[string]interface{})["published"])
+
+		require.Len(t, sent, 3)
+		second := sent[1].(dapr.PublishEventsEvent)
+		assert.Equal(t, "order-2", second.EntryID)
+		assert.Equal(t, "text/plain", second.ContentType)
+		assert.Equal(t, "60", second.Metadata["ttlInSeconds"])
+		third := sent[2].(dapr.PublishEventsEvent)
+		assert.NotEmpty(t, third.EntryID)
+		assert.Equal(t, "application/json", third.ContentType)
+	})
+
+	t.Run("failed entries are reported", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		mockClient.On("PublishEvents", mock.Anything, "pubsub", "orders", mock.Anything, mock.Anything).
+			Return(dapr.PublishEventsResponse{
+				Error:        errors.New("broker unavailable"),
+				FailedEvents: []interface{}{dapr.PublishEventsEvent{EntryID: "order-2"}},
+			})
+		pubsubClient = mockClient
+
+		result, structured, err := publishEventsBulkTool(context.Background(), &mcp.CallToolRequest{}, PublishBulkArgs{
+			PubsubName: "pubsub",
+			Topic:      "orders",
+			Entries:    entries,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "1 of 3 message(s) failed")
+		structuredMap := structured.(map[string]interface{})
+		assert.Equal(t, "partially_published", structuredMap["status"])
+		assert.Equal(t, []string{"order-2"}, structuredMap["failed_entry_ids"])
+		assert.Equal(t, 2, structuredMap["published"])
+	})
+
+	t.Run("duplicate entry IDs are rejected", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		pubsubClient = mockClient
+
+		result, _, err := publishEventsBulkTool(context.Background(), &mcp.CallToolRequest{}, PublishBulkArgs{
+			PubsubName: "pubsub",
+			Topic:      "orders",
+			Entries:    []BulkPublishEntry{{EntryID: "a", Message: "{}"}, {EntryID: "a", Message: "{}"}},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "duplicate entryId 'a'")
+		mockClient.AssertNotCalled(t, "PublishEvents")
+	})
+
+	t.Run("empty batch is rejected", func(t *testing.T) {
+		pubsubClient = new(mocks.MockDaprClient)
+
+		result, _, err := publishEventsBulkTool(context.Background(), &mcp.CallToolRequest{}, PublishBulkArgs{PubsubName: "pubsub", Topic: "orders"})
+
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+}
@@ -0,0 +1,150 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	cloudEventContentType   = "application/cloudevents+json"
+	defaultContentType      = "application/json"
+	cloudEventSpecVersion   = "1.0"
+	defaultCloudEventSource = "dapr-mcp-server"
+	defaultCloudEventType   = "com.dapr.event.sent"
+)
+
+// CloudEventArgs sets CloudEvent envelope attributes on a published message.
+// Unset attributes fall back to Dapr's defaults.
+type CloudEventArgs struct {
+	ID         string         `json:"id,omitempty" jsonschema:"Optional event ID. A UUID is generated when empty."`
+	Type       string         `json:"type,omitempty" jsonschema:"Optional event type (e.g., 'com.example.order.created'). Defaults to 'com.dapr.event.sent'."`
+	Source     string         `json:"source,omitempty" jsonschema:"Optional event source URI reference (e.g., '/orders/service'). Defaults to 'dapr-mcp-server'."`
+	Subject    string         `json:"subject,omitempty" jsonschema:"Optional subject of the event within the source (e.g., 'order-123')."`
+	DataSchema string         `json:"dataschema,omitempty" jsonschema:"Optional absolute URI of the schema the data adheres to."`
+	Extensions map[string]any `json:"extensions,omitempty" jsonschema:"Optional CloudEvent extension attributes. Names must be lowercase letters and digits; values must be strings, numbers or booleans."`
+}
+
+// coreAttributes are the CloudEvent attributes that are not extensions.
+var coreAttributes = map[string]bool{
+	"specversion":     true,
+	"id":              true,
+	"source":          true,
+	"type":            true,
+	"subject":         true,
+	"dataschema":      true,
+	"datacontenttype": true,
+	"time":            true,
+	"data":            true,
+	"data_base64":     true,
+}
+
+var errEnvelopeConflict = errors.New("cloudEvent attributes cannot be combined with rawPayload or a pre-built CloudEvent")
+
+var extensionNamePattern = regexp.MustCompile(`^[a-z0-9]+$`)
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func isCloudEventContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == cloudEventContentType
+}
+
+// buildCloudEvent wraps data in a CloudEvent envelope using the attributes in args.
+func buildCloudEvent(args CloudEventArgs, data []byte, contentType string) (map[string]any, error) {
+	event := map[string]any{
+		"specversion":     cloudEventSpecVersion,
+		"id":              args.ID,
+		"source":          args.Source,
+		"type":            args.Type,
+		"datacontenttype": contentType,
+		"time":            time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	if args.ID == "" {
+		event["id"] = uuid.NewString()
+	}
+	if args.Source == "" {
+		event["source"] = defaultCloudEventSource
+	}
+	if args.Type == "" {
+		event["type"] = defaultCloudEventType
+	}
+	if args.Subject != "" {
+		event["subject"] = args.Subject
+	}
+	if args.DataSchema != "" {
+		u, err := url.Parse(args.DataSchema)
+		if err != nil || !u.IsAbs() {
+			return nil, fmt.Errorf("dataschema '%s' must be an absolute URI", args.DataSchema)
+		}
+		event["dataschema"] = args.DataSchema
+	}
+
+	for name, value := range args.Extensions {
+		if !extensionNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("extension name '%s' must contain only lowercase letters and digits", name)
+		}
+		if coreAttributes[name] {
+			return nil, fmt.Errorf("extension name '%s' is a reserved CloudEvent attribute", name)
+		}
+		switch value.(type) {
+		case string, float64, bool, int, int64:
+		default:
+			return nil, fmt.Errorf("extension '%s' must be a string, number or boolean", name)
+		}
+		event[name] = value
+	}
+
+	if isJSONContentType(contentType) {
+		if !json.Valid(data) {
+			return nil, fmt.Errorf("message is not valid JSON for content type '%s'", contentType)
+		}
+		event["data"] = json.RawMessage(data)
+	} else {
+		event["data"] = string(data)
+	}
+	return event, nil
+}
+
+// parseCloudEvent checks that data is a pre-built structured CloudEvent.
+func parseCloudEvent(data []byte) (map[string]any, error) {
+	var event map[string]any
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("message is not a JSON CloudEvent: %w", err)
+	}
+	var missing []string
+	for _, attr := range []string{"specversion", "id", "source", "type"} {
+		if s, ok := event[attr].(string); !ok || s == "" {
+			missing = append(missing, attr)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("CloudEvent is missing required attribute(s): %s", strings.Join(missing, ", "))
+	}
+	return event, nil
+}
+
+// envelopeAttributes returns the attributes of event without its data.
+func envelopeAttributes(event map[string]any) map[string]any {
+	attrs := make(map[string]any, len(event))
+	for name, value := range event {
+		if name == "data" || name == "data_base64" {
+			continue
+		}
+		attrs[name] = value
+	}
+	return attrs
+}
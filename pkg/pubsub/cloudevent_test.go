@@ -0,0 +1,176 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+)
+
+// capturePublish records the payload and the request built from the publish options.
+func capturePublish(m *mocks.MockDaprClient, data *[]byte, req *pb.PublishEventRequest) {
+	m.On("PublishEvent", mock.Anything, "pubsub", "orders", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*data = args.Get(3).([]byte)
+			for _, opt := range args.Get(4).([]dapr.PublishEventOption) {
+				opt(req)
+			}
+		}).
+		Return(nil)
+}
+
+func TestBuildCloudEvent(t *testing.T) {
+	t.Run("defaults and overrides", func(t *testing.T) {
+		event, err := buildCloudEvent(CloudEventArgs{
+			Type:       "com.example.order.created",
+			Subject:    "order-123",
+			DataSchema: "https://example.com/schemas/order.json",
+			Extensions: map[string]any{"tenant": "acme", "priority": float64(2)},
+		}, []byte(`{"orderId":"123"}`), "application/json")
+
+		require.NoError(t, err)
+		assert.Equal(t, "1.0", event["specversion"])
+		assert.NotEmpty(t, event["id"])
+		assert.Equal(t, defaultCloudEventSource, event["source"])
+		assert.Equal(t, "com.example.order.created", event["type"])
+		assert.Equal(t, "order-123", event["subject"])
+		assert.Equal(t, "acme", event["tenant"])
+		assert.Equal(t, json.RawMessage(`{"orderId":"123"}`), event["data"])
+	})
+
+	t.Run("text data", func(t *testing.T) {
+		event, err := buildCloudEvent(CloudEventArgs{ID: "1"}, []byte("hello"), "text/plain")
+
+		require.NoError(t, err)
+		assert.Equal(t, "hello", event["data"])
+	})
+
+	for name, args := range map[string]CloudEventArgs{
+		"relative dataschema":  {DataSchema: "schemas/order.json"},
+		"uppercase extension":  {Extensions: map[string]any{"Tenant": "acme"}},
+		"reserved extension":   {Extensions: map[string]any{"subject": "x"}},
+		"structured extension": {Extensions: map[string]any{"tenant": map[string]any{}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := buildCloudEvent(args, []byte(`{}`), "application/json")
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("invalid json data", func(t *testing.T) {
+		_, err := buildCloudEvent(CloudEventArgs{}, []byte("not json"), "application/json")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseCloudEvent(t *testing.T) {
+	event, err := parseCloudEvent([]byte(`{"specversion":"1.0","id":"evt-1","source":"/orders","type":"order.created","data":{}}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"specversion": "1.0", "id": "evt-1", "source": "/orders", "type": "order.created"}, envelopeAttributes(event))
+
+	_, err = parseCloudEvent([]byte(`{"specversion":"1.0","id":"evt-1"}`))
+	assert.ErrorContains(t, err, "source, type")
+}
+
+func TestPublishEventToolEnvelope(t *testing.T) {
+	t.Run("cloud event attributes", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		var data []byte
+		req := &pb.PublishEventRequest{}
+		capturePublish(mockClient, &data, req)
+		pubsubClient = mockClient
+
+		result, structured, err := publishEventTool(context.Background(), &mcp.CallToolRequest{}, PublishArgs{
+			PubsubName: "pubsub",
+			Topic:      "orders",
+			Message:    `{"orderId":"123"}`,
+			CloudEvent: &CloudEventArgs{ID: "evt-1", Type: "order.created", Source: "/orders", Extensions: map[string]any{"tenant": "acme"}},
+		})
+
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "CloudEvent 'evt-1' of type 'order.created'")
+		assert.Equal(t, cloudEventContentType, req.DataContentType)
+
+		var sent map[string]any
+		require.NoError(t, json.Unmarshal(data, &sent))
+		assert.Equal(t, "evt-1", sent["id"])
+		assert.Equal(t, "acme", sent["tenant"])
+		assert.Equal(t, map[string]any{"orderId": "123"}, sent["data"])
+
+		echoed := structured.(map[string]interface{})["cloud_event"].(map[string]any)
+		assert.Equal(t, "/orders", echoed["source"])
+		assert.Equal(t, "acme", echoed["tenant"])
+		assert.NotContains(t, echoed, "data")
+	})
+
+	t.Run("pre-built cloud event is sent as-is", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		var data []byte
+		req := &pb.PublishEventRequest{}
+		capturePublish(mockClient, &data, req)
+		pubsubClient = mockClient
+		message := `{"specversion":"1.0","id":"evt-2","source":"/orders","type":"order.created","data":{"orderId":"123"}}`
+
+		result, structured, err := publishEventTool(context.Background(), &mcp.CallToolRequest{}, PublishArgs{
+			PubsubName:  "pubsub",
+			Topic:       "orders",
+			Message:     message,
+			ContentType: cloudEventContentType,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Equal(t, message, string(data))
+		assert.Equal(t, cloudEventContentType, req.DataContentType)
+		assert.Equal(t, "evt-2", structured.(map[string]interface{})["cloud_event"].(map[string]any)["id"])
+	})
+
+	t.Run("raw payload", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		var data []byte
+		req := &pb.PublishEventRequest{}
+		capturePublish(mockClient, &data, req)
+		pubsubClient = mockClient
+
+		result, structured, err := publishEventTool(context.Background(), &mcp.CallToolRequest{}, PublishArgs{
+			PubsubName:  "pubsub",
+			Topic:       "orders",
+			Message:     "order,123",
+			ContentType: "text/csv",
+			RawPayload:  true,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Equal(t, "order,123", string(data))
+		assert.Equal(t, "text/csv", req.DataContentType)
+		assert.Equal(t, "true", req.Metadata["rawPayload"])
+		assert.Equal(t, true, structured.(map[string]interface{})["raw_payload"])
+	})
+
+	t.Run("raw payload with envelope attributes is rejected", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		pubsubClient = mockClient
+
+		result, _, err := publishEventTool(context.Background(), &mcp.CallToolRequest{}, PublishArgs{
+			PubsubName: "pubsub",
+			Topic:      "orders",
+			Message:    "{}",
+			RawPayload: true,
+			CloudEvent: &CloudEventArgs{Type: "order.created"},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		mockClient.AssertNotCalled(t, "PublishEvent")
+	})
+}
@@ -0,0 +1,467 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/dapr/go-sdk/service/common"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+// ReplayCountExtension is the CloudEvent extension counting how many times a
+// message was replayed from its dead-letter topic.
+const ReplayCountExtension = "replaycount"
+
+var (
+	deadLetterMu     sync.RWMutex
+	deadLetterTopics = map[string]string{}
+)
+
+func deadLetterKey(pubsubName, topic string) string {
+	return pubsubName + "/" + topic
+}
+
+// ParseDeadLetterTopics parses a comma-separated list of
+// 'pubsub/topic=deadLetterTopic' entries into a map keyed by 'pubsub/topic'.
+func ParseDeadLetterTopics(spec string) (map[string]string, error) {
+	topics := make(map[string]string)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		source, deadLetterTopic, ok := strings.Cut(entry, "=")
+		pubsubName, topic, hasTopic := strings.Cut(source, "/")
+		if !ok || !hasTopic || pubsubName == "" || topic == "" || deadLetterTopic == "" {
+			return nil, fmt.Errorf("invalid dead-letter topic entry '%s': expected 'pubsub/topic=deadLetterTopic'", entry)
+		}
+		topics[deadLetterKey(pubsubName, topic)] = deadLetterTopic
+	}
+	return topics, nil
+}
+
+// SetDeadLetterTopics configures the dead-letter topic of each 'pubsub/topic'.
+func SetDeadLetterTopics(topics map[string]string) {
+	deadLetterMu.Lock()
+	defer deadLetterMu.Unlock()
+	deadLetterTopics = make(map[string]string, len(topics))
+	for k, v := range topics {
+		deadLetterTopics[k] = v
+	}
+}
+
+// resolveDeadLetterTopic returns the explicit dead-letter topic, or the one
+// configured for the pubsub topic.
+func resolveDeadLetterTopic(pubsubName, topic, explicit string) (string, error) {
+	if explicit != "" {
+		return explicit, nil
+	}
+	if topic == "" {
+		return "", errors.New("topic or deadLetterTopic is required")
+	}
+	deadLetterMu.RLock()
+	defer deadLetterMu.RUnlock()
+	if deadLetterTopic, ok := deadLetterTopics[deadLetterKey(pubsubName, topic)]; ok {
+		return deadLetterTopic, nil
+	}
+	return "", fmt.Errorf("no dead-letter topic is configured for topic '%s' on pubsub '%s'; set deadLetterTopic", topic, pubsubName)
+}
+
+// maxReplayCounts bounds the number of messages whose replay count is kept.
+const maxReplayCounts = 10000
+
+// replayCounts remembers how often this server process replayed each message.
+// Streaming subscriptions do not surface CloudEvent extensions, so the count
+// carried by a message read back from the dead-letter topic is not visible.
+// Only the last maxReplayCounts messages are kept, in insertion order, and the
+// counts are lost when the server restarts.
+var replayCounts = struct {
+	sync.Mutex
+	counts map[string]int
+	order  []string
+}{counts: map[string]int{}}
+
+// nextReplayCount returns the replay count for the next replay of event to topic.
+func nextReplayCount(pubsubName, topic string, event ReceivedEvent) int {
+	previous := 0
+	switch v := event.Extensions[ReplayCountExtension].(type) {
+	case float64:
+		previous = int(v)
+	case int:
+		previous = v
+	case string:
+		previous, _ = strconv.Atoi(v)
+	}
+
+	key := deadLetterKey(pubsubName, topic) + "/" + event.ID
+	replayCounts.Lock()
+	defer replayCounts.Unlock()
+	count, ok := replayCounts.counts[key]
+	if !ok {
+		if len(replayCounts.order) >= maxReplayCounts {
+			delete(replayCounts.counts, replayCounts.order[0])
+			replayCounts.order = replayCounts.order[1:]
+		}
+		replayCounts.order = append(replayCounts.order, key)
+	}
+	if count > previous {
+		previous = count
+	}
+	replayCounts.counts[key] = previous + 1
+	return previous + 1
+}
+
+// replayEnvelope rebuilds the CloudEvent of a dead-lettered message, keeping its
+// original ID and setting the replay count extension.
+func replayEnvelope(event ReceivedEvent, replayCount int) ([]byte, error) {
+	envelope := map[string]any{
+		"specversion": event.SpecVersion,
+		"id":          event.ID,
+		"source":      event.Source,
+		"type":        event.Type,
+	}
+	if event.SpecVersion == "" {
+		envelope["specversion"] = cloudEventSpecVersion
+	}
+	if event.Source == "" {
+		envelope["source"] = defaultCloudEventSource
+	}
+	if event.Type == "" {
+		envelope["type"] = defaultCloudEventType
+	}
+	if event.Subject != "" {
+		envelope["subject"] = event.Subject
+	}
+	if event.DataContentType != "" {
+		envelope["datacontenttype"] = event.DataContentType
+	}
+	for name, value := range event.Extensions {
+		envelope[name] = value
+	}
+	envelope[ReplayCountExtension] = replayCount
+	if event.DataBase64 != nil {
+		envelope["data_base64"] = base64.StdEncoding.EncodeToString(event.DataBase64)
+	} else if event.Data != nil {
+		envelope["data"] = event.Data
+	}
+	return json.Marshal(envelope)
+}
+
+// deadLetterMode selects what happens to messages read from a dead-letter topic.
+type deadLetterMode string
+
+const (
+	modePeek   deadLetterMode = "peek"
+	modeDrain  deadLetterMode = "drain"
+	modeReplay deadLetterMode = "replay"
+)
+
+// deadLetterCollector reads messages from a dead-letter topic. Peeked messages
+// are handed back for redelivery, drained messages are acknowledged, and
+// replayed messages are acknowledged only once they were republished.
+type deadLetterCollector struct {
+	mode      deadLetterMode
+	max       int
+	selected  map[string]bool
+	republish func(ReceivedEvent) error
+
+	mu       sync.Mutex
+	inFlight sync.WaitGroup
+	seen     map[string]bool
+	events   []ReceivedEvent
+	replayed []string
+	failed   map[string]string
+	closed   bool
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func newDeadLetterCollector(mode deadLetterMode, max int, ids []string, republish func(ReceivedEvent) error) *deadLetterCollector {
+	c := &deadLetterCollector{
+		mode:      mode,
+		max:       max,
+		republish: republish,
+		seen:      make(map[string]bool),
+		failed:    make(map[string]string),
+		done:      make(chan struct{}),
+	}
+	if len(ids) > 0 {
+		c.selected = make(map[string]bool, len(ids))
+		for _, id := range ids {
+			c.selected[id] = true
+		}
+	}
+	return c
+}
+
+func (c *deadLetterCollector) handle(e *common.TopicEvent) common.SubscriptionResponseStatus {
+	c.mu.Lock()
+	// A peeked message is redelivered to the same stream, so messages already
+	// seen are only handed back.
+	if c.closed || c.seen[e.ID] || len(c.seen) >= c.max || (c.selected != nil && !c.selected[e.ID]) {
+		c.mu.Unlock()
+		return common.SubscriptionResponseStatusRetry
+	}
+	c.seen[e.ID] = true
+	c.inFlight.Add(1)
+	defer c.inFlight.Done()
+
+	switch c.mode {
+	case modePeek:
+		c.add(toReceivedEvent(e, DispositionRetry))
+		c.mu.Unlock()
+		return common.SubscriptionResponseStatusRetry
+	case modeDrain:
+		c.add(toReceivedEvent(e, DispositionAck))
+		c.mu.Unlock()
+		return common.SubscriptionResponseStatusSuccess
+	}
+
+	c.mu.Unlock()
+	event := toReceivedEvent(e, DispositionAck)
+	err := c.republish(event)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		event.Disposition = DispositionRetry
+		c.failed[event.ID] = err.Error()
+		c.add(event)
+		return common.SubscriptionResponseStatusRetry
+	}
+	c.replayed = append(c.replayed, event.ID)
+	c.add(event)
+	return common.SubscriptionResponseStatusSuccess
+}
+
+// add records an event and signals completion once the collector has every
+// message it was asked for. The caller holds c.mu.
+func (c *deadLetterCollector) add(event ReceivedEvent) {
+	c.events = append(c.events, event)
+	if len(c.events) >= c.max || (c.selected != nil && len(c.events) == len(c.selected)) {
+		c.doneOnce.Do(func() { close(c.done) })
+	}
+}
+
+// close stops collecting and waits for messages already being handled, after
+// which the collector is no longer modified.
+func (c *deadLetterCollector) close() {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	c.inFlight.Wait()
+}
+
+// missing returns the selected IDs that were not found on the dead-letter topic.
+func (c *deadLetterCollector) missing() []string {
+	ids := []string{}
+	for id := range c.selected {
+		if !c.seen[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// collectDeadLetters opens a streaming subscription to deadLetterTopic and runs
+// collector until it is done or timeoutSeconds elapse.
+func collectDeadLetters(ctx context.Context, pubsubName, deadLetterTopic string, timeoutSeconds int, collector *deadLetterCollector) (bool, error) {
+	subCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+
+	stop, err := pubsubClient.SubscribeWithHandler(subCtx, dapr.SubscriptionOptions{
+		PubsubName: pubsubName,
+		Topic:      deadLetterTopic,
+	}, collector.handle)
+	if err != nil {
+		return false, err
+	}
+
+	timedOut := false
+	select {
+	case <-collector.done:
+		time.Sleep(ackGracePeriod)
+	case <-subCtx.Done():
+		timedOut = true
+	}
+	collector.close()
+	if err := stop(); err != nil {
+		log.Printf("Closing subscription to dead-letter topic '%s' failed: %v", deadLetterTopic, err)
+	}
+	return timedOut, nil
+}
+
+type DeadLetterArgs struct {
+	PubsubName      string `json:"pubsubName" jsonschema:"The name of the Dapr pubsub component (e.g., 'pubsub')."`
+	Topic           string `json:"topic,omitempty" jsonschema:"The original topic whose dead letters to read (e.g., 'orders'). Its configured dead-letter topic is used unless deadLetterTopic is set."`
+	DeadLetterTopic string `json:"deadLetterTopic,omitempty" jsonschema:"Optional dead-letter topic to read (e.g., 'orders-deadletter'). Overrides the configured one."`
+	MaxMessages     int    `json:"maxMessages,omitempty" jsonschema:"Optional maximum number of messages to read (default 10, max 100)."`
+	TimeoutSeconds  int    `json:"timeoutSeconds,omitempty" jsonschema:"Optional number of seconds to wait for messages (default 10, max 120)."`
+}
+
+type ReplayDeadLettersArgs struct {
+	PubsubName      string   `json:"pubsubName" jsonschema:"The name of the Dapr pubsub component (e.g., 'pubsub')."`
+	Topic           string   `json:"topic" jsonschema:"The original topic to republish the messages to (e.g., 'orders')."`
+	DeadLetterTopic string   `json:"deadLetterTopic,omitempty" jsonschema:"Optional dead-letter topic to read (e.g., 'orders-deadletter'). Overrides the configured one."`
+	IDs             []string `json:"ids,omitempty" jsonschema:"Optional CloudEvent IDs of the messages to replay. When empty, every message read is replayed."`
+	MaxMessages     int      `json:"maxMessages,omitempty" jsonschema:"Optional maximum number of messages to replay (default 10, max 100)."`
+	TimeoutSeconds  int      `json:"timeoutSeconds,omitempty" jsonschema:"Optional number of seconds to wait for messages (default 10, max 120)."`
+}
+
+func readDeadLetters(ctx context.Context, req *mcp.CallToolRequest, args DeadLetterArgs, mode deadLetterMode) (*mcp.CallToolResult, any, error) {
+	operation := string(mode) + "_dead_letters"
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, operation)
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", operation),
+		attribute.String("dapr.pubsub", args.PubsubName),
+		attribute.String("dapr.topic", args.Topic),
+	)
+
+	if args.PubsubName == "" {
+		return invalidArgumentResult(errors.New("pubsubName is required"))
+	}
+	deadLetterTopic, err := resolveDeadLetterTopic(args.PubsubName, args.Topic, args.DeadLetterTopic)
+	if err != nil {
+		return invalidArgumentResult(err)
+	}
+	span.SetAttributes(attribute.String("dapr.dead_letter_topic", deadLetterTopic))
+	maxMessages, timeoutSeconds, err := receiveLimits(args.MaxMessages, args.TimeoutSeconds)
+	if err != nil {
+		return invalidArgumentResult(err)
+	}
+
+	collector := newDeadLetterCollector(mode, maxMessages, nil, nil)
+	timedOut, err := collectDeadLetters(ctx, args.PubsubName, deadLetterTopic, timeoutSeconds, collector)
+	if err != nil {
+		log.Printf("Dapr SubscribeWithHandler failed: %v", err)
+		return toolcall.WithStatus(&mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to subscribe to dead-letter topic '%s' on pubsub '%s'. Dapr Error: %v", deadLetterTopic, args.PubsubName, err)}},
+			IsError: true,
+		}, err), nil, nil
+	}
+	span.SetAttributes(attribute.Int("dapr.messages_count", len(collector.events)))
+
+	message := fmt.Sprintf("Read %d message(s) from dead-letter topic '%s' on pubsub component '%s'", len(collector.events), deadLetterTopic, args.PubsubName)
+	if timedOut {
+		message += fmt.Sprintf(" before the %ds timeout", timeoutSeconds)
+	}
+	if mode == modeDrain {
+		message += "; messages were removed."
+	} else {
+		message += "; messages were left on the topic."
+	}
+	log.Println(message)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message}},
+	}, map[string]interface{}{
+		"pubsub_name":       args.PubsubName,
+		"topic":             args.Topic,
+		"dead_letter_topic": deadLetterTopic,
+		"count":             len(collector.events),
+		"timed_out":         timedOut,
+		"events":            collector.events,
+	}, nil
+}
+
+func peekDeadLettersTool(ctx context.Context, req *mcp.CallToolRequest, args DeadLetterArgs) (*mcp.CallToolResult, any, error) {
+	return readDeadLetters(ctx, req, args, modePeek)
+}
+
+func drainDeadLettersTool(ctx context.Context, req *mcp.CallToolRequest, args DeadLetterArgs) (*mcp.CallToolResult, any, error) {
+	return readDeadLetters(ctx, req, args, modeDrain)
+}
+
+func replayDeadLettersTool(ctx context.Context, req *mcp.CallToolRequest, args ReplayDeadLettersArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "replay_dead_letters")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "replay_dead_letters"),
+		attribute.String("dapr.pubsub", args.PubsubName),
+		attribute.String("dapr.topic", args.Topic),
+	)
+
+	if args.PubsubName == "" || args.Topic == "" {
+		return invalidArgumentResult(errors.New("pubsubName and topic are required"))
+	}
+	deadLetterTopic, err := resolveDeadLetterTopic(args.PubsubName, args.Topic, args.DeadLetterTopic)
+	if err != nil {
+		return invalidArgumentResult(err)
+	}
+	if deadLetterTopic == args.Topic {
+		return invalidArgumentResult(errors.New("deadLetterTopic must differ from topic"))
+	}
+	span.SetAttributes(attribute.String("dapr.dead_letter_topic", deadLetterTopic))
+	maxMessages, timeoutSeconds, err := receiveLimits(args.MaxMessages, args.TimeoutSeconds)
+	if err != nil {
+		return invalidArgumentResult(err)
+	}
+	if len(args.IDs) > maxMessages {
+		maxMessages = len(args.IDs)
+	}
+	if maxMessages > maxReceiveMessages {
+		return invalidArgumentResult(fmt.Errorf("cannot replay more than %d messages at once", maxReceiveMessages))
+	}
+
+	republish := func(event ReceivedEvent) error {
+		data, err := replayEnvelope(event, nextReplayCount(args.PubsubName, args.Topic, event))
+		if err != nil {
+			return err
+		}
+		return pubsubClient.PublishEvent(ctx, args.PubsubName, args.Topic, data, dapr.PublishEventWithContentType(cloudEventContentType))
+	}
+	collector := newDeadLetterCollector(modeReplay, maxMessages, args.IDs, republish)
+	timedOut, err := collectDeadLetters(ctx, args.PubsubName, deadLetterTopic, timeoutSeconds, collector)
+	if err != nil {
+		log.Printf("Dapr SubscribeWithHandler failed: %v", err)
+		return toolcall.WithStatus(&mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to subscribe to dead-letter topic '%s' on pubsub '%s'. Dapr Error: %v", deadLetterTopic, args.PubsubName, err)}},
+			IsError: true,
+		}, err), nil, nil
+	}
+
+	replayed := append([]string{}, collector.replayed...)
+	missing := collector.missing()
+	span.SetAttributes(
+		attribute.Int("dapr.replayed_count", len(replayed)),
+		attribute.Int("dapr.failed_count", len(collector.failed)),
+	)
+
+	message := fmt.Sprintf("Replayed %d message(s) from dead-letter topic '%s' to topic '%s' on pubsub component '%s'.", len(replayed), deadLetterTopic, args.Topic, args.PubsubName)
+	if len(collector.failed) > 0 {
+		message += fmt.Sprintf(" %d message(s) failed to republish and were left on the dead-letter topic.", len(collector.failed))
+	}
+	if len(missing) > 0 {
+		message += fmt.Sprintf(" Not found before the %ds timeout: %s.", timeoutSeconds, strings.Join(missing, ", "))
+	}
+	log.Println(message)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message}},
+		IsError: len(collector.failed) > 0,
+	}, map[string]interface{}{
+		"pubsub_name":       args.PubsubName,
+		"topic":             args.Topic,
+		"dead_letter_topic": deadLetterTopic,
+		"replayed_ids":      replayed,
+		"failed":            collector.failed,
+		"missing_ids":       missing,
+		"timed_out":         timedOut,
+		"events":            collector.events,
+	}, nil
+}
@@ -0,0 +1,225 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/dapr/go-sdk/service/common"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+)
+
+func TestParseDeadLetterTopics(t *testing.T) {
+	topics, err := ParseDeadLetterTopics(" pubsub/orders=orders-dlq, kafka/payments=payments-dlq ,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"pubsub/orders":  "orders-dlq",
+		"kafka/payments": "payments-dlq",
+	}, topics)
+
+	for _, spec := range []string{"pubsub/orders", "orders=orders-dlq", "pubsub/=dlq", "pubsub/orders="} {
+		_, err := ParseDeadLetterTopics(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestResolveDeadLetterTopic(t *testing.T) {
+	SetDeadLetterTopics(map[string]string{"pubsub/orders": "orders-dlq"})
+	defer SetDeadLetterTopics(nil)
+
+	topic, err := resolveDeadLetterTopic("pubsub", "orders", "")
+	require.NoError(t, err)
+	assert.Equal(t, "orders-dlq", topic)
+
+	topic, err = resolveDeadLetterTopic("pubsub", "orders", "custom-dlq")
+	require.NoError(t, err)
+	assert.Equal(t, "custom-dlq", topic)
+
+	_, err = resolveDeadLetterTopic("pubsub", "payments", "")
+	assert.ErrorContains(t, err, "no dead-letter topic is configured")
+
+	_, err = resolveDeadLetterTopic("pubsub", "", "")
+	assert.ErrorContains(t, err, "topic or deadLetterTopic is required")
+}
+
+func TestPeekDeadLettersTool(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	var statuses []common.SubscriptionResponseStatus
+	// Peeked messages come back on the same stream and must not be counted twice.
+	deliver(mockClient, []*common.TopicEvent{orderEvent("1"), orderEvent("1"), orderEvent("2")}, &statuses)
+	pubsubClient = mockClient
+
+	result, structured, err := peekDeadLettersTool(context.Background(), &mcp.CallToolRequest{}, DeadLetterArgs{
+		PubsubName:      "pubsub",
+		DeadLetterTopic: "orders-dlq",
+		MaxMessages:     2,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "left on the topic")
+	structuredMap := structured.(map[string]interface{})
+	assert.Equal(t, 2, structuredMap["count"])
+	assert.Equal(t, "orders-dlq", structuredMap["dead_letter_topic"])
+	events := structuredMap["events"].([]ReceivedEvent)
+	assert.Equal(t, DispositionRetry, events[0].Disposition)
+
+	assert.Equal(t, []common.SubscriptionResponseStatus{
+		common.SubscriptionResponseStatusRetry,
+		common.SubscriptionResponseStatusRetry,
+		common.SubscriptionResponseStatusRetry,
+	}, statuses)
+	mockClient.AssertCalled(t, "SubscribeWithHandler", mock.Anything, mock.MatchedBy(func(opts dapr.SubscriptionOptions) bool {
+		return opts.PubsubName == "pubsub" && opts.Topic == "orders-dlq"
+	}), mock.Anything)
+}
+
+func TestDrainDeadLettersTool(t *testing.T) {
+	SetDeadLetterTopics(map[string]string{"pubsub/orders": "orders-dlq"})
+	defer SetDeadLetterTopics(nil)
+
+	mockClient := new(mocks.MockDaprClient)
+	var statuses []common.SubscriptionResponseStatus
+	deliver(mockClient, []*common.TopicEvent{orderEvent("1")}, &statuses)
+	pubsubClient = mockClient
+
+	result, structured, err := drainDeadLettersTool(context.Background(), &mcp.CallToolRequest{}, DeadLetterArgs{
+		PubsubName:  "pubsub",
+		Topic:       "orders",
+		MaxMessages: 1,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "messages were removed")
+	assert.Equal(t, "orders-dlq", structured.(map[string]interface{})["dead_letter_topic"])
+	assert.Equal(t, []common.SubscriptionResponseStatus{common.SubscriptionResponseStatusSuccess}, statuses)
+}
+
+func TestDeadLettersRequireTopic(t *testing.T) {
+	result, _, err := peekDeadLettersTool(context.Background(), &mcp.CallToolRequest{}, DeadLetterArgs{PubsubName: "pubsub", Topic: "unknown"})
+
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "no dead-letter topic is configured")
+}
+
+func TestReplayDeadLettersTool(t *testing.T) {
+	t.Run("republishes selected messages with their original ID", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		var statuses []common.SubscriptionResponseStatus
+		deliver(mockClient, []*common.TopicEvent{orderEvent("replay-1"), orderEvent("replay-2")}, &statuses)
+		var data []byte
+		req := &pb.PublishEventRequest{}
+		capturePublish(mockClient, &data, req)
+		pubsubClient = mockClient
+
+		result, structured, err := replayDeadLettersTool(context.Background(), &mcp.CallToolRequest{}, ReplayDeadLettersArgs{
+			PubsubName:      "pubsub",
+			Topic:           "orders",
+			DeadLetterTopic: "orders-dlq",
+			IDs:             []string{"replay-2"},
+		})
+
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		structuredMap := structured.(map[string]interface{})
+		assert.Equal(t, []string{"replay-2"}, structuredMap["replayed_ids"])
+		assert.Empty(t, structuredMap["missing_ids"])
+		assert.Equal(t, []common.SubscriptionResponseStatus{
+			common.SubscriptionResponseStatusRetry,
+			common.SubscriptionResponseStatusSuccess,
+		}, statuses)
+
+		assert.Equal(t, cloudEventContentType, req.DataContentType)
+		var event map[string]any
+		require.NoError(t, json.Unmarshal(data, &event))
+		assert.Equal(t, "replay-2", event["id"])
+		assert.Equal(t, "order.created", event["type"])
+		assert.Equal(t, "orders", event["source"])
+		assert.Equal(t, float64(1), event[ReplayCountExtension])
+		assert.Equal(t, map[string]any{"orderId": "replay-2"}, event["data"])
+	})
+
+	t.Run("leaves messages that fail to republish", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		var statuses []common.SubscriptionResponseStatus
+		deliver(mockClient, []*common.TopicEvent{orderEvent("replay-3")}, &statuses)
+		mockClient.On("PublishEvent", mock.Anything, "pubsub", "orders", mock.Anything, mock.Anything).Return(errors.New("broker unavailable"))
+		pubsubClient = mockClient
+
+		result, structured, err := replayDeadLettersTool(context.Background(), &mcp.CallToolRequest{}, ReplayDeadLettersArgs{
+			PubsubName:      "pubsub",
+			Topic:           "orders",
+			DeadLetterTopic: "orders-dlq",
+			MaxMessages:     1,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		structuredMap := structured.(map[string]interface{})
+		assert.Equal(t, map[string]string{"replay-3": "broker unavailable"}, structuredMap["failed"])
+		assert.Empty(t, structuredMap["replayed_ids"])
+		assert.Equal(t, []common.SubscriptionResponseStatus{common.SubscriptionResponseStatusRetry}, statuses)
+	})
+
+	t.Run("rejects replaying onto the dead-letter topic", func(t *testing.T) {
+		result, _, err := replayDeadLettersTool(context.Background(), &mcp.CallToolRequest{}, ReplayDeadLettersArgs{
+			PubsubName:      "pubsub",
+			Topic:           "orders",
+			DeadLetterTopic: "orders",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+}
+
+func TestNextReplayCount(t *testing.T) {
+	event := ReceivedEvent{ID: "count-1", Extensions: map[string]any{ReplayCountExtension: float64(3)}}
+	assert.Equal(t, 4, nextReplayCount("pubsub", "orders", event))
+
+	// The extension is lost on streaming reads; the server's own count is used.
+	assert.Equal(t, 5, nextReplayCount("pubsub", "orders", ReceivedEvent{ID: "count-1"}))
+	assert.Equal(t, 1, nextReplayCount("pubsub", "payments", ReceivedEvent{ID: "count-1"}))
+}
+
+func TestNextReplayCountBounded(t *testing.T) {
+	for i := 0; i < maxReplayCounts+1; i++ {
+		nextReplayCount("pubsub", "bounded", ReceivedEvent{ID: strconv.Itoa(i)})
+	}
+
+	replayCounts.Lock()
+	assert.LessOrEqual(t, len(replayCounts.counts), maxReplayCounts)
+	assert.Len(t, replayCounts.order, len(replayCounts.counts))
+	replayCounts.Unlock()
+	// The oldest count was dropped; the newest is kept.
+	assert.Equal(t, 1, nextReplayCount("pubsub", "bounded", ReceivedEvent{ID: "0"}))
+	assert.Equal(t, 2, nextReplayCount("pubsub", "bounded", ReceivedEvent{ID: strconv.Itoa(maxReplayCounts)}))
+}
+
+func TestReplayEnvelope(t *testing.T) {
+	data, err := replayEnvelope(ReceivedEvent{
+		ID:         "abc",
+		DataBase64: []byte{0xff, 0x00},
+		Extensions: map[string]any{"tenant": "acme", ReplayCountExtension: float64(1)},
+	}, 2)
+	require.NoError(t, err)
+
+	event, err := parseCloudEvent(data)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", event["id"])
+	assert.Equal(t, defaultCloudEventSource, event["source"])
+	assert.Equal(t, "acme", event["tenant"])
+	assert.Equal(t, float64(2), event[ReplayCountExtension])
+	assert.Equal(t, "/wA=", event["data_base64"])
+}
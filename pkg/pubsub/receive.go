@@ -0,0 +1,236 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/dapr/go-sdk/service/common"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+const (
+	// DispositionAck acknowledges received messages so they are not redelivered.
+	DispositionAck = "ack"
+	// DispositionDrop drops received messages, sending them to the dead-letter topic if one is configured.
+	DispositionDrop = "drop"
+	// DispositionRetry leaves received messages on the topic for redelivery.
+	DispositionRetry = "retry"
+
+	defaultReceiveMessages = 10
+	maxReceiveMessages     = 100
+	defaultReceiveTimeout  = 10
+	maxReceiveTimeout      = 120
+
+	// ackGracePeriod gives the SDK time to send the response for the last
+	// collected message before the stream is closed.
+	ackGracePeriod = 500 * time.Millisecond
+)
+
+type ReceiveEventsArgs struct {
+	PubsubName      string            `json:"pubsubName" jsonschema:"The name of the Dapr pubsub component (e.g., 'pubsub')."`
+	Topic           string            `json:"topic" jsonschema:"The topic to receive messages from (e.g., 'orders')."`
+	MaxMessages     int               `json:"maxMessages,omitempty" jsonschema:"Optional maximum number of messages to collect (default 10, max 100)."`
+	TimeoutSeconds  int               `json:"timeoutSeconds,omitempty" jsonschema:"Optional number of seconds to wait for messages (default 10, max 120)."`
+	Disposition     string            `json:"disposition,omitempty" jsonschema:"Optional disposition applied to every collected message. 'ack' (default) removes them from the topic; 'drop' discards them, sending them to the dead-letter topic if one is set; 'retry' leaves them on the topic for redelivery."`
+	DeadLetterTopic string            `json:"deadLetterTopic,omitempty" jsonschema:"Optional dead-letter topic for dropped messages."`
+	Metadata        map[string]string `json:"metadata,omitempty" jsonschema:"Optional subscription metadata for the pubsub component."`
+}
+
+// ReceivedEvent is a message collected by receive_events, in CloudEvent form.
+type ReceivedEvent struct {
+	ID              string            `json:"id"`
+	SpecVersion     string            `json:"specversion,omitempty"`
+	Type            string            `json:"type,omitempty"`
+	Source          string            `json:"source,omitempty"`
+	Subject         string            `json:"subject,omitempty"`
+	DataContentType string            `json:"datacontenttype,omitempty"`
+	Data            any               `json:"data,omitempty"`
+	DataBase64      []byte            `json:"data_base64,omitempty"`
+	Topic           string            `json:"topic"`
+	PubsubName      string            `json:"pubsubname"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	Extensions      map[string]any    `json:"extensions,omitempty"`
+	Disposition     string            `json:"disposition"`
+}
+
+func toReceivedEvent(e *common.TopicEvent, disposition string) ReceivedEvent {
+	event := ReceivedEvent{
+		ID:              e.ID,
+		SpecVersion:     e.SpecVersion,
+		Type:            e.Type,
+		Source:          e.Source,
+		Subject:         e.Subject,
+		DataContentType: e.DataContentType,
+		Topic:           e.Topic,
+		PubsubName:      e.PubsubName,
+		Metadata:        e.Metadata,
+		Disposition:     disposition,
+	}
+	switch data := e.Data.(type) {
+	case []byte:
+		if utf8.Valid(data) {
+			event.Data = string(data)
+		} else {
+			event.DataBase64 = data
+		}
+	default:
+		event.Data = data
+	}
+	return event
+}
+
+// eventCollector gathers up to max events from concurrent subscription
+// handlers and applies the same disposition to each of them.
+type eventCollector struct {
+	mu          sync.Mutex
+	max         int
+	disposition string
+	events      []ReceivedEvent
+	seen        map[string]bool
+	closed      bool
+	full        chan struct{}
+}
+
+func newEventCollector(max int, disposition string) *eventCollector {
+	return &eventCollector{max: max, disposition: disposition, seen: make(map[string]bool), full: make(chan struct{})}
+}
+
+// handle collects an event, or asks Dapr to redeliver it once the collector is
+// full or closed so that no message is lost.
+func (c *eventCollector) handle(e *common.TopicEvent) common.SubscriptionResponseStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// A retried message may be redelivered to the same stream, so messages
+	// already collected are only handed back.
+	if c.closed || len(c.events) >= c.max || c.seen[e.ID] {
+		return common.SubscriptionResponseStatusRetry
+	}
+	c.seen[e.ID] = true
+	c.events = append(c.events, toReceivedEvent(e, c.disposition))
+	if len(c.events) == c.max {
+		close(c.full)
+	}
+	switch c.disposition {
+	case DispositionDrop:
+		return common.SubscriptionResponseStatusDrop
+	case DispositionRetry:
+		return common.SubscriptionResponseStatusRetry
+	}
+	return common.SubscriptionResponseStatusSuccess
+}
+
+func (c *eventCollector) close() []ReceivedEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return c.events
+}
+
+// receiveLimits applies the defaults and bounds shared by the tools that read
+// from a topic.
+func receiveLimits(maxMessages, timeoutSeconds int) (int, int, error) {
+	if maxMessages <= 0 {
+		maxMessages = defaultReceiveMessages
+	}
+	if maxMessages > maxReceiveMessages {
+		return 0, 0, fmt.Errorf("maxMessages cannot exceed %d", maxReceiveMessages)
+	}
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = defaultReceiveTimeout
+	}
+	if timeoutSeconds > maxReceiveTimeout {
+		return 0, 0, fmt.Errorf("timeoutSeconds cannot exceed %d", maxReceiveTimeout)
+	}
+	return maxMessages, timeoutSeconds, nil
+}
+
+func receiveEventsTool(ctx context.Context, req *mcp.CallToolRequest, args ReceiveEventsArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "receive_events")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "receive_events"),
+		attribute.String("dapr.pubsub", args.PubsubName),
+		attribute.String("dapr.topic", args.Topic),
+	)
+
+	if args.PubsubName == "" || args.Topic == "" {
+		return invalidArgumentResult(errors.New("pubsubName and topic are required"))
+	}
+	maxMessages, timeoutSeconds, err := receiveLimits(args.MaxMessages, args.TimeoutSeconds)
+	if err != nil {
+		return invalidArgumentResult(err)
+	}
+	disposition := args.Disposition
+	if disposition == "" {
+		disposition = DispositionAck
+	}
+	if disposition != DispositionAck && disposition != DispositionDrop && disposition != DispositionRetry {
+		return invalidArgumentResult(fmt.Errorf("disposition must be '%s', '%s' or '%s'", DispositionAck, DispositionDrop, DispositionRetry))
+	}
+
+	opts := dapr.SubscriptionOptions{
+		PubsubName: args.PubsubName,
+		Topic:      args.Topic,
+		Metadata:   args.Metadata,
+	}
+	if args.DeadLetterTopic != "" {
+		opts.DeadLetterTopic = &args.DeadLetterTopic
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+
+	collector := newEventCollector(maxMessages, disposition)
+	stop, err := pubsubClient.SubscribeWithHandler(subCtx, opts, collector.handle)
+	if err != nil {
+		log.Printf("Dapr SubscribeWithHandler failed: %v", err)
+		return toolcall.WithStatus(&mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("failed to subscribe to topic '%s' on pubsub '%s'. Dapr Error: %v", args.Topic, args.PubsubName, err)}},
+			IsError: true,
+		}, err), nil, nil
+	}
+
+	timedOut := false
+	select {
+	case <-collector.full:
+		time.Sleep(ackGracePeriod)
+	case <-subCtx.Done():
+		timedOut = true
+	}
+	events := collector.close()
+	if err := stop(); err != nil {
+		log.Printf("Closing subscription to topic '%s' failed: %v", args.Topic, err)
+	}
+	span.SetAttributes(attribute.Int("dapr.messages_count", len(events)))
+
+	message := fmt.Sprintf("Received %d message(s) from topic '%s' on pubsub component '%s'", len(events), args.Topic, args.PubsubName)
+	if timedOut {
+		message += fmt.Sprintf(" before the %ds timeout", timeoutSeconds)
+	}
+	message += fmt.Sprintf("; messages were %s.", map[string]string{
+		DispositionAck:   "acknowledged",
+		DispositionDrop:  "dropped",
+		DispositionRetry: "left on the topic for redelivery",
+	}[disposition])
+	log.Println(message)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message}},
+	}, map[string]interface{}{
+		"pubsub_name": args.PubsubName,
+		"topic":       args.Topic,
+		"count":       len(events),
+		"timed_out":   timedOut,
+		"events":      events,
+	}, nil
+}
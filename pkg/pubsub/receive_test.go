@@ -0,0 +1,157 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/dapr/go-sdk/service/common"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+)
+
+// deliver mocks a subscription that hands events to the handler and records
+// the status returned for each.
+func deliver(m *mocks.MockDaprClient, events []*common.TopicEvent, statuses *[]common.SubscriptionResponseStatus) {
+	m.On("SubscribeWithHandler", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			handler := args.Get(2).(dapr.SubscriptionHandleFunction)
+			for _, e := range events {
+				*statuses = append(*statuses, handler(e))
+			}
+		}).
+		Return(func() error { return nil }, nil)
+}
+
+func orderEvent(id string) *common.TopicEvent {
+	return &common.TopicEvent{
+		ID:              id,
+		SpecVersion:     "1.0",
+		Type:            "order.created",
+		Source:          "orders",
+		DataContentType: "application/json",
+		Data:            map[string]interface{}{"orderId": id},
+		Topic:           "orders",
+		PubsubName:      "pubsub",
+	}
+}
+
+func TestReceiveEventsTool(t *testing.T) {
+	t.Run("collects up to maxMessages and acks them", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		var statuses []common.SubscriptionResponseStatus
+		deliver(mockClient, []*common.TopicEvent{orderEvent("1"), orderEvent("2"), orderEvent("3")}, &statuses)
+		pubsubClient = mockClient
+
+		result, structured, err := receiveEventsTool(context.Background(), &mcp.CallToolRequest{}, ReceiveEventsArgs{
+			PubsubName:  "pubsub",
+			Topic:       "orders",
+			MaxMessages: 2,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "Received 2 message(s)")
+		structuredMap := structured.(map[string]interface{})
+		assert.Equal(t, 2, structuredMap["count"])
+		assert.Equal(t, false, structuredMap["timed_out"])
+
+		events := structuredMap["events"].([]ReceivedEvent)
+		assert.Equal(t, "1", events[0].ID)
+		assert.Equal(t, "order.created", events[0].Type)
+		assert.Equal(t, map[string]interface{}{"orderId": "1"}, events[0].Data)
+		assert.Equal(t, DispositionAck, events[0].Disposition)
+
+		assert.Equal(t, []common.SubscriptionResponseStatus{
+			common.SubscriptionResponseStatusSuccess,
+			common.SubscriptionResponseStatusSuccess,
+			common.SubscriptionResponseStatusRetry,
+		}, statuses)
+	})
+
+	t.Run("drops messages and times out", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		var statuses []common.SubscriptionResponseStatus
+		deliver(mockClient, []*common.TopicEvent{orderEvent("1")}, &statuses)
+		pubsubClient = mockClient
+
+		result, structured, err := receiveEventsTool(context.Background(), &mcp.CallToolRequest{}, ReceiveEventsArgs{
+			PubsubName:      "pubsub",
+			Topic:           "orders",
+			TimeoutSeconds:  1,
+			Disposition:     DispositionDrop,
+			DeadLetterTopic: "orders-dlq",
+		})
+
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "before the 1s timeout; messages were dropped")
+		assert.Equal(t, true, structured.(map[string]interface{})["timed_out"])
+		assert.Equal(t, []common.SubscriptionResponseStatus{common.SubscriptionResponseStatusDrop}, statuses)
+
+		opts := mockClient.Calls[0].Arguments.Get(1).(dapr.SubscriptionOptions)
+		require.NotNil(t, opts.DeadLetterTopic)
+		assert.Equal(t, "orders-dlq", *opts.DeadLetterTopic)
+	})
+
+	t.Run("retries messages without collecting redeliveries", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		var statuses []common.SubscriptionResponseStatus
+		deliver(mockClient, []*common.TopicEvent{orderEvent("1"), orderEvent("1"), orderEvent("2")}, &statuses)
+		pubsubClient = mockClient
+
+		result, structured, err := receiveEventsTool(context.Background(), &mcp.CallToolRequest{}, ReceiveEventsArgs{
+			PubsubName:     "pubsub",
+			Topic:          "orders",
+			TimeoutSeconds: 1,
+			Disposition:    DispositionRetry,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "messages were left on the topic for redelivery")
+		events := structured.(map[string]interface{})["events"].([]ReceivedEvent)
+		require.Len(t, events, 2)
+		assert.Equal(t, DispositionRetry, events[0].Disposition)
+		assert.Equal(t, []common.SubscriptionResponseStatus{
+			common.SubscriptionResponseStatusRetry,
+			common.SubscriptionResponseStatusRetry,
+			common.SubscriptionResponseStatusRetry,
+		}, statuses)
+	})
+
+	t.Run("binary data is base64 encoded", func(t *testing.T) {
+		event := toReceivedEvent(&common.TopicEvent{ID: "1", Data: []byte{0xff, 0xfe}}, DispositionAck)
+
+		assert.Nil(t, event.Data)
+		assert.Equal(t, []byte{0xff, 0xfe}, event.DataBase64)
+	})
+
+	t.Run("subscribe failure", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		mockClient.On("SubscribeWithHandler", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("streaming subscriptions not supported"))
+		pubsubClient = mockClient
+
+		result, _, err := receiveEventsTool(context.Background(), &mcp.CallToolRequest{}, ReceiveEventsArgs{PubsubName: "pubsub", Topic: "orders"})
+
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "streaming subscriptions not supported")
+	})
+
+	t.Run("invalid disposition", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		pubsubClient = mockClient
+
+		result, _, err := receiveEventsTool(context.Background(), &mcp.CallToolRequest{}, ReceiveEventsArgs{PubsubName: "pubsub", Topic: "orders", Disposition: "requeue"})
+
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		mockClient.AssertNotCalled(t, "SubscribeWithHandler")
+	})
+}
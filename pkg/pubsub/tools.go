@@ -2,6 +2,7 @@ package pubsub
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 
@@ -10,17 +11,28 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
 )
 
 // PubSubClient defines the interface for pub/sub operations.
 type PubSubClient interface {
 	PublishEvent(ctx context.Context, pubsubName, topicName string, data interface{}, opts ...dapr.PublishEventOption) error
+	PublishEvents(ctx context.Context, pubsubName, topicName string, events []interface{}, opts ...dapr.PublishEventsOption) dapr.PublishEventsResponse
+	SubscribeWithHandler(ctx context.Context, opts dapr.SubscriptionOptions, handler dapr.SubscriptionHandleFunction) (func() error, error)
 }
 
 type PublishArgs struct {
 	PubsubName string `json:"pubsubName" jsonschema:"The name of the Dapr pubsub component (e.g., 'pubsub')."`
 	Topic      string `json:"topic" jsonschema:"The topic to publish the message to (e.g., 'orders')."`
 	Message    string `json:"message" jsonschema:"The message payload to publish, typically a JSON string."`
+	// ContentType is the content type of Message. application/cloudevents+json
+	// publishes Message as a pre-built CloudEvent.
+	ContentType string `json:"contentType,omitempty" jsonschema:"Optional content type of the message (default 'application/json'). Use 'application/cloudevents+json' to publish a pre-built CloudEvent as-is."`
+	// CloudEvent overrides the envelope attributes Dapr would otherwise generate.
+	CloudEvent *CloudEventArgs `json:"cloudEvent,omitempty" jsonschema:"Optional CloudEvent envelope attributes (type, source, subject, id, dataschema, extensions)."`
+	// RawPayload publishes Message without a CloudEvent envelope.
+	RawPayload bool `json:"rawPayload,omitempty" jsonschema:"Optional. When true, Message is published as-is without a CloudEvent envelope."`
 }
 
 var pubsubClient PubSubClient
@@ -35,33 +47,72 @@ func publishEventTool(ctx context.Context, req *mcp.CallToolRequest, args Publis
 	)
 
 	data := []byte(args.Message)
+	contentType := args.ContentType
+	if contentType == "" {
+		contentType = defaultContentType
+	}
 
 	propagator := otel.GetTextMapPropagator()
 	metadata := make(map[string]string)
 	propagator.Inject(ctx, propagation.MapCarrier(metadata))
 
+	structuredResult := map[string]interface{}{
+		"status":       "published",
+		"pubsub_name":  args.PubsubName,
+		"topic":        args.Topic,
+		"content_type": contentType,
+	}
+	successMessage := fmt.Sprintf("Successfully published message to topic '%s' on pubsub component '%s'.", args.Topic, args.PubsubName)
+
+	var event map[string]any
+	var err error
+	switch {
+	case args.RawPayload:
+		if args.CloudEvent != nil || isCloudEventContentType(contentType) {
+			return invalidArgumentResult(errEnvelopeConflict)
+		}
+		structuredResult["raw_payload"] = true
+		successMessage = fmt.Sprintf("Successfully published raw '%s' payload to topic '%s' on pubsub component '%s'.", contentType, args.Topic, args.PubsubName)
+	case isCloudEventContentType(contentType):
+		if args.CloudEvent != nil {
+			return invalidArgumentResult(errEnvelopeConflict)
+		}
+		if event, err = parseCloudEvent(data); err != nil {
+			return invalidArgumentResult(err)
+		}
+	case args.CloudEvent != nil:
+		if event, err = buildCloudEvent(*args.CloudEvent, data, contentType); err != nil {
+			return invalidArgumentResult(err)
+		}
+		if data, err = json.Marshal(event); err != nil {
+			return invalidArgumentResult(err)
+		}
+		contentType = cloudEventContentType
+	}
+	if event != nil {
+		structuredResult["cloud_event"] = envelopeAttributes(event)
+		successMessage = fmt.Sprintf("Successfully published CloudEvent '%v' of type '%v' to topic '%s' on pubsub component '%s'.", event["id"], event["type"], args.Topic, args.PubsubName)
+	}
+
 	opts := []dapr.PublishEventOption{
-		dapr.PublishEventWithContentType("application/json"),
+		dapr.PublishEventWithContentType(contentType),
 		dapr.PublishEventWithMetadata(metadata),
 	}
+	if args.RawPayload {
+		opts = append(opts, dapr.PublishEventWithRawPayload())
+	}
 
 	if err := pubsubClient.PublishEvent(ctx, args.PubsubName, args.Topic, data, opts...); err != nil {
 		log.Printf("Dapr PublishEvent failed: %v", err)
-		return &mcp.CallToolResult{
+		return toolcall.WithStatus(&mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{
 				Text: fmt.Sprintf("Failed to publish event to topic '%s' on pubsub '%s'. Dapr Error: %v", args.Topic, args.PubsubName, err),
 			}},
-		}, nil, nil
+			IsError: true,
+		}, err), nil, nil
 	}
 
-	successMessage := fmt.Sprintf("Successfully published message to topic '%s' on pubsub component '%s'.", args.Topic, args.PubsubName)
-
 	log.Println(successMessage)
-	structuredResult := map[string]interface{}{
-		"status":      "published",
-		"pubsub_name": args.PubsubName,
-		"topic":       args.Topic,
-	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{&mcp.TextContent{Text: successMessage}},
@@ -94,10 +145,10 @@ func publishEventWithMetadataTool(ctx context.Context, req *mcp.CallToolRequest,
 	if err := pubsubClient.PublishEvent(ctx, args.PubsubName, args.Topic, data, opts...); err != nil {
 		log.Printf("Dapr PublishEventWithMetadata failed: %v", err)
 		toolErrorMessage := fmt.Sprintf("failed to publish event to topic '%s' on pubsub '%s' with metadata. Dapr Error: %v", args.Topic, args.PubsubName, err)
-		return &mcp.CallToolResult{
+		return toolcall.WithStatus(&mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
 			IsError: true,
-		}, nil, nil
+		}, err), nil, nil
 	}
 
 	successMessage := fmt.Sprintf("Successfully published message with %d metadata key(s) to topic '%s' on pubsub component '%s'.", len(args.Metadata), args.Topic, args.PubsubName)
@@ -115,10 +166,18 @@ func publishEventWithMetadataTool(ctx context.Context, req *mcp.CallToolRequest,
 	}, structuredResult, nil
 }
 
+func invalidArgumentResult(err error) (*mcp.CallToolResult, any, error) {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("invalid argument: %v", err)}},
+		IsError: true,
+	}, nil, nil
+}
+
 func RegisterTools(server *mcp.Server, client PubSubClient) {
 	pubsubClient = client
 
 	notDestructive := false
+	isDestructive := true
 	notIdempotent := false
 	isOpenWorld := true
 
@@ -133,7 +192,9 @@ func RegisterTools(server *mcp.Server, client PubSubClient) {
 			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `PubsubName`, `Topic`, and `Message`.\n" +
 			"2. **NEVER INVENT**: You must NOT invent `PubsubName` or `Topic` names.\n" +
 			"3. **MESSAGE RULE**: The `Message` MUST be the content the user wishes to publish and should reflect user intent.\n" +
-			"4. **CLARIFICATION**: If any required input is missing, you MUST ask the user for clarification.",
+			"4. **CLOUDEVENTS**: Dapr wraps the message in a CloudEvent. Set `CloudEvent` to choose its `type`, `source`, `subject`, `id`, `dataschema` or extensions. To publish a complete CloudEvent you already have, set `ContentType` to `application/cloudevents+json`.\n" +
+			"5. **RAW PAYLOAD**: Set `RawPayload` only when subscribers expect the bare message without a CloudEvent envelope, and set `ContentType` to match the message.\n" +
+			"6. **CLARIFICATION**: If any required input is missing, you MUST ask the user for clarification.",
 		Annotations: &mcp.ToolAnnotations{
 			ReadOnlyHint:    false,
 			DestructiveHint: &notDestructive,
@@ -159,4 +220,92 @@ func RegisterTools(server *mcp.Server, client PubSubClient) {
 			OpenWorldHint:   &isOpenWorld,
 		},
 	}, publishEventWithMetadataTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "publish_events_bulk",
+		Title: "Publish Events (Bulk)",
+		Description: "Publishes a batch of messages to a topic in a single call using the Dapr bulk publish API. **This is a SIDE-EFFECT action.** Prefer this over repeated `publish_event` calls when fanning out many messages.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Use the `get_components` tool to discover available pubsub components and their names before invoking this tool.\n" +
+			"2. Each entry is published independently; the result lists the `failed_entry_ids` of any entries that were not published.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `PubsubName`, `Topic`, and a non-empty list of `Entries`.\n" +
+			"2. **ENTRY IDS**: `EntryID` is optional but MUST be unique within the batch. Set it when you need to retry only the failed entries.\n" +
+			"3. **RETRIES**: Only republish the entries listed in `failed_entry_ids`; the others were already delivered.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: &notDestructive,
+			IdempotentHint:  notIdempotent,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, publishEventsBulkTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "receive_events",
+		Title: "Receive Events From Topic",
+		Description: "Opens a short-lived streaming subscription to a topic and collects up to `MaxMessages` messages or until `TimeoutSeconds` elapses, returning them as structured CloudEvents. **This is a SIDE-EFFECT action: collected messages are consumed.** Use it to verify that events published by an application actually arrive on a topic.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Use the `get_components` tool to discover available pubsub components and their names before invoking this tool.\n" +
+			"2. The subscription only sees messages published while it is open, unless the broker retains messages for this app's consumer group.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `PubsubName` and `Topic`.\n" +
+			"2. **DISPOSITION**: `Disposition` applies to EVERY message collected by the call; choose it before calling, since messages cannot be handled one by one. `ack` (default) acknowledges each collected message; `drop` discards it, routing it to `DeadLetterTopic` if set; `retry` leaves it on the topic for redelivery, which the broker may count as a failed delivery attempt. Messages beyond `MaxMessages` are left for redelivery.\n" +
+			"3. **NEVER INVENT**: You must NOT invent `PubsubName` or `Topic` names.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: &notDestructive,
+			IdempotentHint:  notIdempotent,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, receiveEventsTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "peek_dead_letters",
+		Title: "Peek Dead Letters",
+		Description: "Reads up to `MaxMessages` messages from a topic's dead-letter topic and returns them as structured CloudEvents **without removing them**: each message is handed back for redelivery, which the broker may count as a failed delivery attempt and may reorder. Use it to find out why a consumer is failing before draining or replaying.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Use the `get_components` tool to discover available pubsub components and their names before invoking this tool.\n" +
+			"2. Note the `id` of the messages to pass to `replay_dead_letters`.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `PubsubName` and either the original `Topic` (to use its configured dead-letter topic) or `DeadLetterTopic`.\n" +
+			"2. **NEVER INVENT**: You must NOT invent `PubsubName`, `Topic` or `DeadLetterTopic` names.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: &notDestructive,
+			IdempotentHint:  notIdempotent,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, peekDeadLettersTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "drain_dead_letters",
+		Title: "Drain Dead Letters",
+		Description: "Reads up to `MaxMessages` messages from a topic's dead-letter topic, returns them as structured CloudEvents and **acknowledges them, permanently removing them from the dead-letter topic**.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Prefer `peek_dead_letters` to inspect messages, and `replay_dead_letters` to reprocess them. Drain only messages the user wants discarded.\n" +
+			"2. Use the `get_components` tool to discover available pubsub components and their names before invoking this tool.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `PubsubName` and either the original `Topic` (to use its configured dead-letter topic) or `DeadLetterTopic`.\n" +
+			"2. **CONFIRMATION**: Confirm with the user before draining; drained messages cannot be replayed.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: &isDestructive,
+			IdempotentHint:  notIdempotent,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, drainDeadLettersTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "replay_dead_letters",
+		Title: "Replay Dead Letters",
+		Description: "Republishes messages from a topic's dead-letter topic back to the original `Topic`, keeping their original CloudEvent `id`, `source`, `type`, `subject` and data and setting a `replaycount` extension. Other CloudEvent extensions are not preserved, and the replay count is only tracked by this server process. **This is a SIDE-EFFECT action: consumers of `Topic` process the messages again.** Each message is removed from the dead-letter topic only after it was republished.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Use `peek_dead_letters` first to inspect the messages and pick their IDs.\n" +
+			"2. Only replay after the cause of the failure was fixed, or the messages will be dead-lettered again.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `PubsubName` and `Topic`.\n" +
+			"2. **SELECTION**: Set `IDs` to replay only those messages; other messages are left on the dead-letter topic. When `IDs` is empty, up to `MaxMessages` messages are replayed.\n" +
+			"3. **RESULT**: `failed` lists messages that could not be republished and `missing_ids` lists requested IDs that were not found.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: &notDestructive,
+			IdempotentHint:  notIdempotent,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, replayDeadLettersTool)
 }
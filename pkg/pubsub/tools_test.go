@@ -9,7 +9,12 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/dapr/dapr-mcp-server/pkg/health"
+	"github.com/dapr/dapr-mcp-server/pkg/resiliency"
 	"github.com/dapr/dapr-mcp-server/test/mocks"
 )
 
@@ -60,7 +65,7 @@ func TestPublishEventTool(t *testing.T) {
 				m.On("PublishEvent", mock.Anything, "pubsub", "orders", mock.Anything, mock.Anything).
 					Return(errors.New("connection refused"))
 			},
-			wantErr:     false, // Note: this function returns error in content, not IsError
+			wantErr:     true,
 			wantContent: "Failed to publish event",
 		},
 		{
@@ -74,7 +79,7 @@ func TestPublishEventTool(t *testing.T) {
 				m.On("PublishEvent", mock.Anything, "nonexistent", "topic", mock.Anything, mock.Anything).
 					Return(errors.New("pubsub component not found"))
 			},
-			wantErr:     false,
+			wantErr:     true,
 			wantContent: "Failed to publish event",
 		},
 		{
@@ -103,6 +108,7 @@ func TestPublishEventTool(t *testing.T) {
 			result, _, err := publishEventTool(context.Background(), &mcp.CallToolRequest{}, tt.args)
 
 			assert.NoError(t, err)
+			assert.Equal(t, tt.wantErr, result.IsError)
 			if len(result.Content) > 0 {
 				textContent, ok := result.Content[0].(*mcp.TextContent)
 				assert.True(t, ok)
@@ -206,6 +212,38 @@ func TestPublishEventWithMetadataTool(t *testing.T) {
 	}
 }
 
+func TestPublishEventToolFailureTripsBreaker(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	mockClient.On("PublishEvent", mock.Anything, "pubsub", "orders", mock.Anything, mock.Anything).
+		Return(status.Error(codes.Unavailable, "connection refused"))
+
+	threshold := 1
+	wrapper := resiliency.New(resiliency.Config{Default: resiliency.PolicySpec{BreakerThreshold: &threshold}})
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	RegisterTools(server, mockClient)
+	server.AddReceivingMiddleware(wrapper.Middleware)
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	defer serverSession.Close()
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "publish_event", Arguments: map[string]any{
+		"pubsubName": "pubsub", "topic": "orders", "message": `{"orderId": "123"}`,
+	}})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+
+	checks := wrapper.Check(ctx)
+	require.Len(t, checks, 1)
+	assert.Equal(t, health.StatusDegraded, checks[0].Status)
+	assert.Equal(t, "circuit-breaker:publish_event/pubsub", checks[0].Component)
+}
+
 func TestRegisterTools(t *testing.T) {
 	mockClient := new(mocks.MockDaprClient)
 	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil)
@@ -226,6 +264,19 @@ func (m *mockPubSubClient) PublishEvent(ctx context.Context, pubsubName, topicNa
 	return args.Error(0)
 }
 
+func (m *mockPubSubClient) PublishEvents(ctx context.Context, pubsubName, topicName string, events []interface{}, opts ...dapr.PublishEventsOption) dapr.PublishEventsResponse {
+	args := m.Called(ctx, pubsubName, topicName, events, opts)
+	return args.Get(0).(dapr.PublishEventsResponse)
+}
+
+func (m *mockPubSubClient) SubscribeWithHandler(ctx context.Context, opts dapr.SubscriptionOptions, handler dapr.SubscriptionHandleFunction) (func() error, error) {
+	args := m.Called(ctx, opts, handler)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(func() error), args.Error(1)
+}
+
 func TestPublishEventToolWithInterfaceMock(t *testing.T) {
 	mockPubsub := new(mockPubSubClient)
 	mockPubsub.On("PublishEvent", mock.Anything, "test-pubsub", "test-topic", mock.Anything, mock.Anything).
@@ -0,0 +1,371 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/dapr/go-sdk/service/common"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/dapr/dapr-mcp-server/pkg/resources"
+)
+
+const (
+	// TopicURIPrefix prefixes the URIs of topic resources.
+	TopicURIPrefix = "dapr://pubsub/"
+	// TopicURITemplate is the URI template of topic resources.
+	TopicURITemplate = TopicURIPrefix + "{name}/{topic}"
+	// DefaultTopicBufferSize is the number of recent messages kept per topic.
+	DefaultTopicBufferSize = 100
+	// declarativeRoutePrefix is the app route Dapr delivers declarative subscriptions to.
+	declarativeRoutePrefix = "/dapr/events/"
+)
+
+// TopicURI returns the resource URI of a topic.
+func TopicURI(pubsubName, topic string) string {
+	return TopicURIPrefix + url.PathEscape(pubsubName) + "/" + url.PathEscape(topic)
+}
+
+// parseTopicURI splits a topic resource URI into its pubsub and topic names.
+func parseTopicURI(uri string) (string, string, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "dapr" || u.Host != "pubsub" {
+		return "", "", fmt.Errorf("'%s' is not a pubsub topic resource", uri)
+	}
+	pubsubName, topic, ok := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	if !ok || pubsubName == "" || topic == "" {
+		return "", "", fmt.Errorf("'%s' is not a pubsub topic resource", uri)
+	}
+	return pubsubName, topic, nil
+}
+
+// DeclarativeSubscription is an entry of the app's /dapr/subscribe response.
+type DeclarativeSubscription struct {
+	PubsubName string `json:"pubsubname"`
+	Topic      string `json:"topic"`
+	Route      string `json:"route"`
+}
+
+type topicFeed struct {
+	pubsubName  string
+	topic       string
+	declarative bool
+	refs        int
+	buffer      *resources.Ring[ReceivedEvent]
+	starting    bool
+	stop        func() error
+}
+
+// TopicBridge exposes pubsub topics as MCP resources. Messages arrive either
+// through declarative subscriptions delivered to the HTTP server, or through a
+// streaming subscription opened while at least one client is subscribed to the
+// topic resource. Recent messages are kept in a bounded buffer per topic and
+// every arrival sends a resources/updated notification.
+type TopicBridge struct {
+	server     *mcp.Server
+	client     PubSubClient
+	bufferSize int
+
+	mu    sync.Mutex
+	feeds map[string]*topicFeed
+}
+
+// NewTopicBridge creates a TopicBridge keeping bufferSize messages per topic.
+func NewTopicBridge(server *mcp.Server, client PubSubClient, bufferSize int) *TopicBridge {
+	if bufferSize <= 0 {
+		bufferSize = DefaultTopicBufferSize
+	}
+	return &TopicBridge{
+		server:     server,
+		client:     client,
+		bufferSize: bufferSize,
+		feeds:      make(map[string]*topicFeed),
+	}
+}
+
+// Declare buffers a topic delivered through a declarative subscription, and
+// lists it as a concrete resource.
+func (b *TopicBridge) Declare(pubsubName, topic string) {
+	uri := TopicURI(pubsubName, topic)
+	b.mu.Lock()
+	if feed, ok := b.feeds[uri]; ok {
+		feed.declarative = true
+	} else {
+		b.feeds[uri] = &topicFeed{
+			pubsubName:  pubsubName,
+			topic:       topic,
+			declarative: true,
+			buffer:      resources.NewRing[ReceivedEvent](b.bufferSize),
+		}
+	}
+	b.mu.Unlock()
+
+	b.server.AddResource(&mcp.Resource{
+		URI:         uri,
+		Name:        pubsubName + "/" + topic,
+		Title:       fmt.Sprintf("Topic '%s' on pubsub '%s'", topic, pubsubName),
+		Description: "Recent messages delivered to this topic through a declarative subscription.",
+		MIMEType:    "application/json",
+	}, b.readResource)
+}
+
+// DeclarativeSubscriptions returns the subscriptions to advertise on /dapr/subscribe.
+func (b *TopicBridge) DeclarativeSubscriptions() []DeclarativeSubscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := make([]DeclarativeSubscription, 0, len(b.feeds))
+	for _, feed := range b.feeds {
+		if feed.declarative {
+			subs = append(subs, DeclarativeSubscription{
+				PubsubName: feed.pubsubName,
+				Topic:      feed.topic,
+				Route:      declarativeRoutePrefix + url.PathEscape(feed.pubsubName) + "/" + url.PathEscape(feed.topic),
+			})
+		}
+	}
+	return subs
+}
+
+// Subscribe starts a streaming subscription for the topic unless one is
+// already running or the topic is delivered declaratively. The resources.Router
+// calls it once per subscribed session, so refs counts sessions.
+func (b *TopicBridge) Subscribe(ctx context.Context, uri string) error {
+	pubsubName, topic, err := parseTopicURI(uri)
+	if err != nil {
+		return err
+	}
+	uri = TopicURI(pubsubName, topic)
+
+	b.mu.Lock()
+	feed, ok := b.feeds[uri]
+	if !ok {
+		feed = &topicFeed{pubsubName: pubsubName, topic: topic, buffer: resources.NewRing[ReceivedEvent](b.bufferSize)}
+		b.feeds[uri] = feed
+	}
+	feed.refs++
+	start := !feed.declarative && feed.stop == nil && !feed.starting
+	feed.starting = feed.starting || start
+	b.mu.Unlock()
+	if !start {
+		return nil
+	}
+
+	// The subscription is opened without holding the lock, so a slow sidecar
+	// does not block other topics.
+	stop, err := b.client.SubscribeWithHandler(context.Background(), dapr.SubscriptionOptions{
+		PubsubName: pubsubName,
+		Topic:      topic,
+	}, func(e *common.TopicEvent) common.SubscriptionResponseStatus {
+		b.pushFeed(uri, feed, toReceivedEvent(e, DispositionAck))
+		return common.SubscriptionResponseStatusSuccess
+	})
+
+	b.mu.Lock()
+	feed.starting = false
+	if err != nil {
+		feed.refs--
+		if feed.refs <= 0 && !feed.declarative && b.feeds[uri] == feed {
+			delete(b.feeds, uri)
+		}
+		b.mu.Unlock()
+		return fmt.Errorf("failed to subscribe to topic '%s' on pubsub '%s': %w", topic, pubsubName, err)
+	}
+	if b.feeds[uri] != feed {
+		// Every subscriber left while the subscription was being opened.
+		b.mu.Unlock()
+		closeStream(pubsubName, topic, stop)
+		return nil
+	}
+	feed.stop = stop
+	b.mu.Unlock()
+	log.Printf("Streaming subscription opened for topic '%s' on pubsub '%s'", topic, pubsubName)
+	return nil
+}
+
+// Unsubscribe closes the topic's streaming subscription once no client is subscribed.
+func (b *TopicBridge) Unsubscribe(ctx context.Context, uri string) error {
+	pubsubName, topic, err := parseTopicURI(uri)
+	if err != nil {
+		return err
+	}
+	uri = TopicURI(pubsubName, topic)
+
+	b.mu.Lock()
+	feed, ok := b.feeds[uri]
+	if !ok {
+		b.mu.Unlock()
+		return nil
+	}
+	if feed.refs > 0 {
+		feed.refs--
+	}
+	if feed.refs > 0 || feed.declarative {
+		b.mu.Unlock()
+		return nil
+	}
+	delete(b.feeds, uri)
+	stop := feed.stop
+	b.mu.Unlock()
+
+	if stop != nil {
+		closeStream(pubsubName, topic, stop)
+	}
+	return nil
+}
+
+// closeStream closes a streaming subscription.
+func closeStream(pubsubName, topic string, stop func() error) {
+	if err := stop(); err != nil {
+		log.Printf("Closing streaming subscription for topic '%s' failed: %v", topic, err)
+	}
+	log.Printf("Streaming subscription closed for topic '%s' on pubsub '%s'", topic, pubsubName)
+}
+
+// push buffers a message delivered to the topic and notifies subscribed clients.
+func (b *TopicBridge) push(uri string, event ReceivedEvent) {
+	b.mu.Lock()
+	feed := b.feeds[uri]
+	b.mu.Unlock()
+	if feed != nil {
+		b.pushFeed(uri, feed, event)
+	}
+}
+
+// pushFeed buffers a message for feed, unless the feed was closed, and
+// notifies subscribed clients.
+func (b *TopicBridge) pushFeed(uri string, feed *topicFeed, event ReceivedEvent) {
+	b.mu.Lock()
+	active := b.feeds[uri] == feed
+	if active {
+		feed.buffer.Push(event)
+	}
+	b.mu.Unlock()
+	if !active {
+		return
+	}
+	if err := b.server.ResourceUpdated(context.Background(), &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+		log.Printf("Failed to notify subscribers of '%s': %v", uri, err)
+	}
+}
+
+func (b *TopicBridge) readResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	pubsubName, topic, err := parseTopicURI(req.Params.URI)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(req.Params.URI)
+	}
+	uri := TopicURI(pubsubName, topic)
+
+	b.mu.Lock()
+	feed, ok := b.feeds[uri]
+	messages := []ReceivedEvent{}
+	if ok {
+		messages = feed.buffer.Items()
+	}
+	b.mu.Unlock()
+
+	return resources.JSONResult(req.Params.URI, map[string]interface{}{
+		"pubsub_name": pubsubName,
+		"topic":       topic,
+		"active":      ok,
+		"buffer_size": b.bufferSize,
+		"messages":    messages,
+	})
+}
+
+// ServeHTTP receives messages Dapr delivers for declarative subscriptions.
+func (b *TopicBridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	pubsubName, topic, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, declarativeRoutePrefix), "/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	uri := TopicURI(pubsubName, topic)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	event, err := decodeDeliveredEvent(body)
+	if err != nil {
+		// Dropping tells Dapr not to redeliver a message that will never parse.
+		log.Printf("Dropping undecodable message for '%s': %v", uri, err)
+		writeSubscriptionStatus(w, common.SubscriptionResponseStatusDrop)
+		return
+	}
+	if event.Topic == "" {
+		event.Topic = topic
+	}
+	if event.PubsubName == "" {
+		event.PubsubName = pubsubName
+	}
+	b.push(uri, event)
+	writeSubscriptionStatus(w, common.SubscriptionResponseStatusSuccess)
+}
+
+// decodeDeliveredEvent converts a structured CloudEvent delivered over HTTP.
+func decodeDeliveredEvent(body []byte) (ReceivedEvent, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return ReceivedEvent{}, err
+	}
+	str := func(name string) string {
+		s, _ := raw[name].(string)
+		return s
+	}
+	event := ReceivedEvent{
+		ID:              str("id"),
+		SpecVersion:     str("specversion"),
+		Type:            str("type"),
+		Source:          str("source"),
+		Subject:         str("subject"),
+		DataContentType: str("datacontenttype"),
+		Data:            raw["data"],
+		Topic:           str("topic"),
+		PubsubName:      str("pubsubname"),
+		Disposition:     DispositionAck,
+	}
+	if encoded := str("data_base64"); encoded != "" {
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return ReceivedEvent{}, fmt.Errorf("invalid data_base64: %w", err)
+		}
+		event.DataBase64 = data
+	}
+	for name, value := range raw {
+		if coreAttributes[name] || name == "topic" || name == "pubsubname" {
+			continue
+		}
+		if event.Extensions == nil {
+			event.Extensions = make(map[string]any)
+		}
+		event.Extensions[name] = value
+	}
+	return event, nil
+}
+
+func writeSubscriptionStatus(w http.ResponseWriter, status common.SubscriptionResponseStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(common.SubscriptionResponse{Status: status})
+}
+
+// RegisterResources exposes pubsub topics as MCP resources served by bridge.
+func RegisterResources(server *mcp.Server, router *resources.Router, bridge *TopicBridge) {
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: TopicURITemplate,
+		Name:        "pubsub-topic",
+		Title:       "Pub/Sub Topic",
+		Description: "Recent messages on a Dapr pubsub topic. Subscribe to the resource to open a streaming subscription and receive resources/updated notifications as messages arrive; read it to get the buffered messages as CloudEvents.",
+		MIMEType:    "application/json",
+	}, bridge.readResource)
+	router.Handle(TopicURIPrefix, bridge)
+}
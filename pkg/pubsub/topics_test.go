@@ -0,0 +1,160 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/dapr/go-sdk/service/common"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr-mcp-server/pkg/resources"
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+)
+
+func readTopic(t *testing.T, bridge *TopicBridge, uri string) map[string]interface{} {
+	t.Helper()
+	result, err := bridge.readResource(context.Background(), &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: uri}})
+	require.NoError(t, err)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(result.Contents[0].Text), &body))
+	return body
+}
+
+func TestParseTopicURI(t *testing.T) {
+	pubsubName, topic, err := parseTopicURI(TopicURI("pubsub", "orders/eu"))
+	require.NoError(t, err)
+	assert.Equal(t, "pubsub", pubsubName)
+	assert.Equal(t, "orders/eu", topic)
+
+	for _, uri := range []string{"dapr://pubsub/pubsub", "dapr://state/store/key", "https://pubsub/a/b"} {
+		_, _, err := parseTopicURI(uri)
+		assert.Error(t, err, uri)
+	}
+}
+
+func TestTopicBridgeStreamingSubscription(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	var handler dapr.SubscriptionHandleFunction
+	stopped := 0
+	mockClient.On("SubscribeWithHandler", mock.Anything, dapr.SubscriptionOptions{PubsubName: "pubsub", Topic: "orders"}, mock.Anything).
+		Run(func(args mock.Arguments) { handler = args.Get(2).(dapr.SubscriptionHandleFunction) }).
+		Return(func() error { stopped++; return nil }, nil).Once()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	bridge := NewTopicBridge(server, mockClient, 2)
+	uri := TopicURI("pubsub", "orders")
+	ctx := context.Background()
+
+	require.NoError(t, bridge.Subscribe(ctx, uri))
+	require.NoError(t, bridge.Subscribe(ctx, uri))
+	mockClient.AssertNumberOfCalls(t, "SubscribeWithHandler", 1)
+
+	for _, id := range []string{"1", "2", "3"} {
+		assert.Equal(t, common.SubscriptionResponseStatusSuccess, handler(orderEvent(id)))
+	}
+
+	body := readTopic(t, bridge, uri)
+	assert.Equal(t, true, body["active"])
+	messages := body["messages"].([]interface{})
+	require.Len(t, messages, 2)
+	assert.Equal(t, "2", messages[0].(map[string]interface{})["id"])
+	assert.Equal(t, "3", messages[1].(map[string]interface{})["id"])
+
+	require.NoError(t, bridge.Unsubscribe(ctx, uri))
+	assert.Equal(t, 0, stopped)
+	require.NoError(t, bridge.Unsubscribe(ctx, uri))
+	assert.Equal(t, 1, stopped)
+
+	body = readTopic(t, bridge, uri)
+	assert.Equal(t, false, body["active"])
+	assert.Empty(t, body["messages"])
+}
+
+func TestTopicBridgeSubscribeDoesNotBlock(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	release := make(chan struct{})
+	stopped := make(chan struct{}, 1)
+	mockClient.On("SubscribeWithHandler", mock.Anything, dapr.SubscriptionOptions{PubsubName: "pubsub", Topic: "slow"}, mock.Anything).
+		Run(func(mock.Arguments) { <-release }).
+		Return(func() error { stopped <- struct{}{}; return nil }, nil).Once()
+	mockClient.On("SubscribeWithHandler", mock.Anything, dapr.SubscriptionOptions{PubsubName: "pubsub", Topic: "orders"}, mock.Anything).
+		Return(func() error { return nil }, nil).Once()
+
+	bridge := NewTopicBridge(mcp.NewServer(&mcp.Implementation{Name: "test"}, nil), mockClient, 10)
+	ctx := context.Background()
+	slow := TopicURI("pubsub", "slow")
+
+	done := make(chan error, 1)
+	go func() { done <- bridge.Subscribe(ctx, slow) }()
+	require.Eventually(t, func() bool {
+		bridge.mu.Lock()
+		defer bridge.mu.Unlock()
+		return bridge.feeds[slow] != nil && bridge.feeds[slow].starting
+	}, 5*time.Second, time.Millisecond)
+
+	// Other topics, and the slow topic itself, are not blocked while the
+	// subscription is opened.
+	require.NoError(t, bridge.Subscribe(ctx, TopicURI("pubsub", "orders")))
+	require.NoError(t, bridge.Subscribe(ctx, slow))
+	require.NoError(t, bridge.Unsubscribe(ctx, slow))
+	require.NoError(t, bridge.Unsubscribe(ctx, slow))
+
+	// Everyone left before the subscription was open, so it is closed at once.
+	close(release)
+	require.NoError(t, <-done)
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("streaming subscription was not closed")
+	}
+	mockClient.AssertNumberOfCalls(t, "SubscribeWithHandler", 2)
+	assert.Equal(t, false, readTopic(t, bridge, slow)["active"])
+}
+
+func TestTopicBridgeDeclarativeSubscription(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	bridge := NewTopicBridge(server, mockClient, 10)
+	bridge.Declare("pubsub", "orders")
+
+	assert.Equal(t, []DeclarativeSubscription{{PubsubName: "pubsub", Topic: "orders", Route: "/dapr/events/pubsub/orders"}}, bridge.DeclarativeSubscriptions())
+
+	// Subscribing to a declared topic does not open a streaming subscription.
+	require.NoError(t, bridge.Subscribe(context.Background(), TopicURI("pubsub", "orders")))
+	mockClient.AssertNotCalled(t, "SubscribeWithHandler")
+
+	body := `{"specversion":"1.0","id":"evt-1","source":"checkout","type":"order.created","datacontenttype":"application/json","data":{"orderId":"1"},"topic":"orders","pubsubname":"pubsub","traceparent":"00-abc-def-01"}`
+	rec := httptest.NewRecorder()
+	bridge.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/dapr/events/pubsub/orders", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"SUCCESS"}`, rec.Body.String())
+
+	messages := readTopic(t, bridge, TopicURI("pubsub", "orders"))["messages"].([]interface{})
+	require.Len(t, messages, 1)
+	message := messages[0].(map[string]interface{})
+	assert.Equal(t, "evt-1", message["id"])
+	assert.Equal(t, map[string]interface{}{"orderId": "1"}, message["data"])
+	assert.Equal(t, map[string]interface{}{"traceparent": "00-abc-def-01"}, message["extensions"])
+
+	rec = httptest.NewRecorder()
+	bridge.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/dapr/events/pubsub/orders", strings.NewReader("not json")))
+	assert.JSONEq(t, `{"status":"DROP"}`, rec.Body.String())
+}
+
+func TestRegisterResources(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	bridge := NewTopicBridge(server, new(mocks.MockDaprClient), 0)
+
+	assert.NotPanics(t, func() { RegisterResources(server, resources.NewRouter(), bridge) })
+	assert.Equal(t, DefaultTopicBufferSize, bridge.bufferSize)
+}
@@ -0,0 +1,69 @@
+package resiliency
+
+import (
+	"fmt"
+	"time"
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// breaker is a consecutive-failure circuit breaker. It opens after the
+// policy's threshold of transient failures, rejects calls for the cooldown,
+// then lets a single probe through: a successful probe closes it, a failed
+// one opens it again. The caller serializes access.
+type breaker struct {
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// allow reports whether a call may proceed, and why not.
+func (b *breaker) allow(now time.Time, p Policy) error {
+	switch b.state {
+	case breakerOpen:
+		if wait := b.openedAt.Add(p.BreakerCooldown).Sub(now); wait > 0 {
+			return fmt.Errorf("circuit breaker is open after %d consecutive transient failures; retry in %s", b.failures, wait.Round(time.Second))
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return nil
+	case breakerHalfOpen:
+		if b.probing {
+			return fmt.Errorf("circuit breaker is half-open and a probe call is in progress")
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a call it allowed.
+func (b *breaker) record(now time.Time, p Policy, failed bool) {
+	b.probing = false
+	if !failed {
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if p.BreakerThreshold > 0 && (b.state == breakerHalfOpen || b.failures >= p.BreakerThreshold) {
+		b.state = breakerOpen
+		b.openedAt = now
+	}
+}
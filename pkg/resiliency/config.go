@@ -0,0 +1,161 @@
+package resiliency
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Duration is a time.Duration written as a Go duration string in JSON.
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration such as "500ms" or "2m".
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Policy controls how a tool call is run.
+type Policy struct {
+	// Timeout is the deadline of the whole call, including retries. Zero disables it.
+	Timeout time.Duration
+	// MaxRetries is the number of retries of idempotent tools after a transient error.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry; it doubles on each retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+	// BreakerThreshold is the number of consecutive transient failures that
+	// opens the circuit breaker. Zero disables the breaker.
+	BreakerThreshold int
+	// BreakerCooldown is how long an open breaker rejects calls before letting one through.
+	BreakerCooldown time.Duration
+}
+
+// DefaultPolicy is applied to every tool call unless overridden.
+var DefaultPolicy = Policy{
+	Timeout:          5 * time.Minute,
+	MaxRetries:       3,
+	InitialBackoff:   200 * time.Millisecond,
+	MaxBackoff:       5 * time.Second,
+	BreakerThreshold: 5,
+	BreakerCooldown:  30 * time.Second,
+}
+
+// PolicySpec overrides the fields of a Policy that are set.
+type PolicySpec struct {
+	Timeout          *Duration `json:"timeout,omitempty"`
+	MaxRetries       *int      `json:"maxRetries,omitempty"`
+	InitialBackoff   *Duration `json:"initialBackoff,omitempty"`
+	MaxBackoff       *Duration `json:"maxBackoff,omitempty"`
+	BreakerThreshold *int      `json:"breakerThreshold,omitempty"`
+	BreakerCooldown  *Duration `json:"breakerCooldown,omitempty"`
+}
+
+func (s PolicySpec) apply(p Policy) Policy {
+	if s.Timeout != nil {
+		p.Timeout = time.Duration(*s.Timeout)
+	}
+	if s.MaxRetries != nil {
+		p.MaxRetries = *s.MaxRetries
+	}
+	if s.InitialBackoff != nil {
+		p.InitialBackoff = time.Duration(*s.InitialBackoff)
+	}
+	if s.MaxBackoff != nil {
+		p.MaxBackoff = time.Duration(*s.MaxBackoff)
+	}
+	if s.BreakerThreshold != nil {
+		p.BreakerThreshold = *s.BreakerThreshold
+	}
+	if s.BreakerCooldown != nil {
+		p.BreakerCooldown = time.Duration(*s.BreakerCooldown)
+	}
+	return p
+}
+
+// Config holds the default policy and its overrides. Component overrides
+// take precedence over tool overrides, which take precedence over the default.
+type Config struct {
+	Default    PolicySpec            `json:"default"`
+	Tools      map[string]PolicySpec `json:"tools,omitempty"`
+	Components map[string]PolicySpec `json:"components,omitempty"`
+}
+
+// PolicyFor returns the policy of a call to tool on component. The component
+// may be empty for tools that do not target one.
+func (c Config) PolicyFor(tool, component string) Policy {
+	p := c.Default.apply(DefaultPolicy)
+	if spec, ok := c.Tools[tool]; ok {
+		p = spec.apply(p)
+	}
+	if spec, ok := c.Components[component]; ok && component != "" {
+		p = spec.apply(p)
+	}
+	return p
+}
+
+// LoadConfig reads a JSON resiliency configuration from path. An empty path
+// returns a configuration applying DefaultPolicy to every call.
+func LoadConfig(path string) (Config, error) {
+	if path == "" {
+		return Config{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read resiliency configuration: %w", err)
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return Config{}, fmt.Errorf("failed to parse resiliency configuration: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// Validate validates the configuration.
+func (c Config) Validate() error {
+	check := func(scope string, spec PolicySpec) error {
+		p := spec.apply(DefaultPolicy)
+		switch {
+		case p.Timeout < 0:
+			return fmt.Errorf("resiliency %s: timeout must not be negative", scope)
+		case p.MaxRetries < 0:
+			return fmt.Errorf("resiliency %s: maxRetries must not be negative", scope)
+		case p.InitialBackoff < 0 || p.MaxBackoff < 0:
+			return fmt.Errorf("resiliency %s: backoff must not be negative", scope)
+		case p.BreakerThreshold < 0:
+			return fmt.Errorf("resiliency %s: breakerThreshold must not be negative", scope)
+		case p.BreakerCooldown < 0:
+			return fmt.Errorf("resiliency %s: breakerCooldown must not be negative", scope)
+		}
+		return nil
+	}
+	if err := check("default", c.Default); err != nil {
+		return err
+	}
+	for name, spec := range c.Tools {
+		if err := check(fmt.Sprintf("tool '%s'", name), spec); err != nil {
+			return err
+		}
+	}
+	for name, spec := range c.Components {
+		if err := check(fmt.Sprintf("component '%s'", name), spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
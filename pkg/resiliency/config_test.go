@@ -0,0 +1,62 @@
+package resiliency
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resiliency.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"default": {"timeout": "1m", "maxRetries": 2},
+		"tools": {"invoke_service": {"timeout": "2m", "breakerThreshold": 0}},
+		"components": {"statestore": {"maxRetries": 5, "breakerCooldown": "10s"}}
+	}`), 0o600))
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+
+	p := config.PolicyFor("get_state", "")
+	assert.Equal(t, time.Minute, p.Timeout)
+	assert.Equal(t, 2, p.MaxRetries)
+	assert.Equal(t, DefaultPolicy.InitialBackoff, p.InitialBackoff)
+
+	p = config.PolicyFor("invoke_service", "order-service")
+	assert.Equal(t, 2*time.Minute, p.Timeout)
+	assert.Equal(t, 0, p.BreakerThreshold)
+
+	p = config.PolicyFor("get_state", "statestore")
+	assert.Equal(t, 5, p.MaxRetries)
+	assert.Equal(t, 10*time.Second, p.BreakerCooldown)
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	config, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPolicy, config.PolicyFor("get_state", "statestore"))
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"invalid json":      `{`,
+		"invalid duration":  `{"default": {"timeout": "soon"}}`,
+		"numeric duration":  `{"default": {"timeout": 30}}`,
+		"negative retries":  `{"tools": {"get_state": {"maxRetries": -1}}}`,
+		"negative cooldown": `{"components": {"statestore": {"breakerCooldown": "-1s"}}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "resiliency.json")
+			require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+			_, err := LoadConfig(path)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := LoadConfig(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,224 @@
+// Package resiliency runs tool calls under per-call deadlines, retries
+// transient sidecar errors of idempotent tools with exponential backoff, and
+// trips a circuit breaker per tool and component when they keep failing.
+package resiliency
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dapr/dapr-mcp-server/pkg/health"
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+// retryableCodes are the gRPC codes of errors worth retrying.
+var retryableCodes = map[codes.Code]bool{
+	codes.Unavailable:       true,
+	codes.ResourceExhausted: true,
+	codes.Aborted:           true,
+	codes.DeadlineExceeded:  true,
+}
+
+// maxBreakers bounds the number of circuit breakers kept. Breakers are keyed
+// by caller-supplied component names, so the map must not grow with them.
+const maxBreakers = 1024
+
+// Wrapper applies resiliency policies to tool calls. Install Middleware as
+// receiving middleware on the MCP server.
+type Wrapper struct {
+	config Config
+	now    func() time.Time
+
+	annotations *toolcall.Annotations
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+// New creates a Wrapper applying config.
+func New(config Config) *Wrapper {
+	return &Wrapper{
+		config:      config,
+		now:         time.Now,
+		annotations: toolcall.NewAnnotations(),
+		breakers:    make(map[string]*breaker),
+	}
+}
+
+// Middleware runs tools/call requests under the call's policy and passes
+// every other request through.
+func (w *Wrapper) Middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		call, ok := toolcall.Call(method, req)
+		if !ok {
+			return next(ctx, method, req)
+		}
+		return w.callTool(ctx, next, call)
+	}
+}
+
+func (w *Wrapper) callTool(ctx context.Context, next mcp.MethodHandler, call *mcp.CallToolRequest) (mcp.Result, error) {
+	tool := call.Params.Name
+	component := toolcall.Component(call.Params.Arguments)
+	policy := w.config.PolicyFor(tool, component)
+	key := breakerKey(tool, component)
+
+	w.mu.Lock()
+	b := w.breakerFor(key)
+	err := b.allow(w.now(), policy)
+	w.mu.Unlock()
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("tool '%s' is unavailable for '%s': %v", tool, component, err)}},
+			IsError: true,
+		}, nil
+	}
+
+	if policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+
+	attempts := 1
+	if annotations, _ := w.annotations.Lookup(ctx, next, call); annotations != nil && annotations.IdempotentHint {
+		attempts += policy.MaxRetries
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, err := next(ctx, "tools/call", call)
+		code, transient := transientCode(result, err)
+		if !transient || attempt >= attempts || ctx.Err() != nil {
+			w.record(key, b, policy, transient)
+			return result, err
+		}
+
+		delay := backoff(policy, attempt)
+		log.Printf("Tool '%s' on '%s' failed with %s (attempt %d of %d); retrying in %s", tool, component, code, attempt, attempts, delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			w.record(key, b, policy, true)
+			return result, err
+		case <-timer.C:
+		}
+	}
+}
+
+// breakerFor returns the breaker of key, creating it when there is room. Once
+// maxBreakers are kept, a closed breaker is dropped to make room; when every
+// breaker is tripped, the call gets a breaker that is not kept. The caller
+// holds w.mu.
+func (w *Wrapper) breakerFor(key string) *breaker {
+	if b, ok := w.breakers[key]; ok {
+		return b
+	}
+	b := &breaker{}
+	if len(w.breakers) >= maxBreakers {
+		for other, kept := range w.breakers {
+			if kept.state == breakerClosed {
+				delete(w.breakers, other)
+				break
+			}
+		}
+	}
+	if len(w.breakers) < maxBreakers {
+		w.breakers[key] = b
+	}
+	return b
+}
+
+// record updates b with the outcome of a call, and drops it once it is closed
+// without failures, as it then holds no state worth keeping.
+func (w *Wrapper) record(key string, b *breaker, policy Policy, failed bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	b.record(w.now(), policy, failed)
+	if b.state == breakerClosed && b.failures == 0 && w.breakers[key] == b {
+		delete(w.breakers, key)
+	}
+}
+
+// Check reports the circuit breakers that are not closed, for the readiness probe.
+func (w *Wrapper) Check(ctx context.Context) []health.CheckResult {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	keys := make([]string, 0, len(w.breakers))
+	for key, b := range w.breakers {
+		if b.state != breakerClosed {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return []health.CheckResult{{Status: health.StatusHealthy, Component: "circuit-breakers", Message: "all circuit breakers closed"}}
+	}
+	sort.Strings(keys)
+
+	checks := make([]health.CheckResult, 0, len(keys))
+	for _, key := range keys {
+		b := w.breakers[key]
+		checks = append(checks, health.CheckResult{
+			Status:    health.StatusDegraded,
+			Component: "circuit-breaker:" + key,
+			Message:   fmt.Sprintf("circuit breaker %s after %d consecutive transient failures", b.state, b.failures),
+		})
+	}
+	return checks
+}
+
+// breakerKey names the circuit breaker of a tool and the component it
+// targets, so that one tool failing against a component does not trip calls
+// of other tools, or of the same tool against other components.
+func breakerKey(tool, component string) string {
+	if component == "" {
+		return tool
+	}
+	return tool + "/" + component
+}
+
+// transientCode reports the gRPC code of a failed call and whether it is retryable.
+func transientCode(result mcp.Result, err error) (codes.Code, bool) {
+	if err != nil {
+		if s, ok := status.FromError(err); ok {
+			return s.Code(), retryableCodes[s.Code()]
+		}
+		return codes.Unknown, false
+	}
+	res, ok := result.(*mcp.CallToolResult)
+	if !ok || !res.IsError {
+		return codes.OK, false
+	}
+	// Tool handlers record the status of sidecar errors in the result.
+	code, ok := toolcall.Status(res)
+	if !ok {
+		return codes.Unknown, false
+	}
+	return code, retryableCodes[code]
+}
+
+// backoff returns the delay before retry number attempt, doubling from the
+// initial backoff up to the maximum, with up to 20% jitter.
+func backoff(p Policy, attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if jitter := int64(delay) / 5; jitter > 0 {
+		delay += time.Duration(rand.Int64N(jitter))
+	}
+	return delay
+}
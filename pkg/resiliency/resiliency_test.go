@@ -0,0 +1,213 @@
+package resiliency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dapr/dapr-mcp-server/pkg/health"
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+type stateArgs struct {
+	StoreName string `json:"storeName"`
+}
+
+func duration(d time.Duration) *Duration {
+	v := Duration(d)
+	return &v
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// testConfig retries quickly and opens the breaker after three failures.
+var testConfig = Config{Default: PolicySpec{
+	InitialBackoff:   duration(time.Millisecond),
+	MaxBackoff:       duration(2 * time.Millisecond),
+	BreakerThreshold: intPtr(3),
+	BreakerCooldown:  duration(time.Minute),
+}}
+
+// flakyTool fails with err for the first failures calls, then succeeds.
+func flakyTool(calls *atomic.Int32, failures int32, err error) func(context.Context, *mcp.CallToolRequest, stateArgs) (*mcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args stateArgs) (*mcp.CallToolResult, any, error) {
+		if calls.Add(1) <= failures {
+			return toolcall.WithStatus(&mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}}, IsError: true}, err), nil, nil
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil, nil
+	}
+}
+
+// startServer connects a client to a server with the wrapper installed.
+func startServer(t *testing.T, w *Wrapper, register func(*mcp.Server)) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	register(server)
+	server.AddReceivingMiddleware(w.Middleware)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+	clientSession, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = clientSession.Close() })
+	return clientSession
+}
+
+func callTool(t *testing.T, session *mcp.ClientSession, name, store string) *mcp.CallToolResult {
+	t.Helper()
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: map[string]any{"storeName": store}})
+	require.NoError(t, err)
+	return res
+}
+
+func TestMiddlewareRetriesIdempotentTools(t *testing.T) {
+	var getCalls, saveCalls, badCalls atomic.Int32
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	session := startServer(t, New(testConfig), func(server *mcp.Server) {
+		mcp.AddTool(server, &mcp.Tool{Name: "get_state", Annotations: &mcp.ToolAnnotations{IdempotentHint: true}}, flakyTool(&getCalls, 2, unavailable))
+		mcp.AddTool(server, &mcp.Tool{Name: "save_state"}, flakyTool(&saveCalls, 2, unavailable))
+		mcp.AddTool(server, &mcp.Tool{Name: "get_bulk_state", Annotations: &mcp.ToolAnnotations{IdempotentHint: true}},
+			flakyTool(&badCalls, 2, status.Error(codes.InvalidArgument, "bad key")))
+	})
+
+	res := callTool(t, session, "get_state", "statestore")
+	assert.False(t, res.IsError)
+	assert.Equal(t, int32(3), getCalls.Load())
+
+	res = callTool(t, session, "save_state", "statestore")
+	assert.True(t, res.IsError, "non-idempotent tools are not retried")
+	assert.Equal(t, int32(1), saveCalls.Load())
+
+	res = callTool(t, session, "get_bulk_state", "statestore")
+	assert.True(t, res.IsError, "non-transient errors are not retried")
+	assert.Equal(t, int32(1), badCalls.Load())
+}
+
+func TestMiddlewareCircuitBreaker(t *testing.T) {
+	var calls, otherCalls atomic.Int32
+	w := New(testConfig)
+	now := time.Now()
+	w.now = func() time.Time { return now }
+	session := startServer(t, w, func(server *mcp.Server) {
+		mcp.AddTool(server, &mcp.Tool{Name: "save_state"}, flakyTool(&calls, 3, status.Error(codes.Unavailable, "sidecar down")))
+		mcp.AddTool(server, &mcp.Tool{Name: "delete_state"}, flakyTool(&otherCalls, 0, nil))
+	})
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, callTool(t, session, "save_state", "statestore").IsError)
+	}
+	checks := w.Check(context.Background())
+	require.Len(t, checks, 1)
+	assert.Equal(t, health.StatusDegraded, checks[0].Status)
+	assert.Equal(t, "circuit-breaker:save_state/statestore", checks[0].Component)
+	assert.Contains(t, checks[0].Message, "open after 3 consecutive transient failures")
+
+	res := callTool(t, session, "save_state", "statestore")
+	assert.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "tool 'save_state' is unavailable for 'statestore': circuit breaker is open")
+	assert.Equal(t, int32(3), calls.Load())
+
+	// The breaker covers one tool on one component.
+	assert.False(t, callTool(t, session, "delete_state", "statestore").IsError)
+	assert.Equal(t, int32(1), otherCalls.Load())
+
+	// After the cooldown a probe call closes the breaker again
+	now = now.Add(time.Minute)
+	assert.False(t, callTool(t, session, "save_state", "statestore").IsError)
+	checks = w.Check(context.Background())
+	assert.Equal(t, health.StatusHealthy, checks[0].Status)
+}
+
+func TestBreakersAreBounded(t *testing.T) {
+	w := New(testConfig)
+	policy := w.config.PolicyFor("get_state", "")
+
+	// A call that succeeds leaves no breaker behind.
+	b := w.breakerFor("get_state/a")
+	w.record("get_state/a", b, policy, false)
+	assert.Empty(t, w.breakers)
+
+	for i := 0; i < maxBreakers; i++ {
+		key := fmt.Sprintf("get_state/store-%d", i)
+		w.record(key, w.breakerFor(key), policy, true)
+	}
+	require.Len(t, w.breakers, maxBreakers)
+
+	// A closed breaker makes room for a new one.
+	w.breakerFor("get_state/new")
+	assert.Len(t, w.breakers, maxBreakers)
+	assert.Contains(t, w.breakers, "get_state/new")
+
+	// Tripped breakers are never dropped.
+	for _, b := range w.breakers {
+		b.state = breakerOpen
+	}
+	w.breakerFor("get_state/other")
+	assert.Len(t, w.breakers, maxBreakers)
+	assert.NotContains(t, w.breakers, "get_state/other")
+}
+
+func TestMiddlewareTimeout(t *testing.T) {
+	config := Config{Tools: map[string]PolicySpec{"slow": {Timeout: duration(10 * time.Millisecond), MaxRetries: intPtr(0)}}}
+	session := startServer(t, New(config), func(server *mcp.Server) {
+		mcp.AddTool(server, &mcp.Tool{Name: "slow"}, func(ctx context.Context, req *mcp.CallToolRequest, args stateArgs) (*mcp.CallToolResult, any, error) {
+			<-ctx.Done()
+			return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: ctx.Err().Error()}}, IsError: true}, nil, nil
+		})
+	})
+
+	res := callTool(t, session, "slow", "")
+	assert.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "deadline exceeded")
+}
+
+func TestTransientCode(t *testing.T) {
+	code, transient := transientCode(nil, status.Error(codes.ResourceExhausted, "busy"))
+	assert.Equal(t, codes.ResourceExhausted, code)
+	assert.True(t, transient)
+
+	_, transient = transientCode(nil, errors.New("plain error"))
+	assert.False(t, transient)
+
+	_, transient = transientCode(toolcall.WithStatus(&mcp.CallToolResult{}, status.Error(codes.Unavailable, "x")), nil)
+	assert.False(t, transient, "successful results are never retried")
+
+	code, transient = transientCode(toolcall.WithStatus(&mcp.CallToolResult{IsError: true}, status.Error(codes.NotFound, "x")), nil)
+	assert.Equal(t, codes.NotFound, code)
+	assert.False(t, transient)
+
+	code, transient = transientCode(toolcall.WithStatus(&mcp.CallToolResult{IsError: true}, status.Error(codes.Unavailable, "x")), nil)
+	assert.Equal(t, codes.Unavailable, code)
+	assert.True(t, transient)
+
+	_, transient = transientCode(&mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "rpc error: code = Unavailable desc = x"}}}, nil)
+	assert.False(t, transient, "the status is not read from the message")
+}
+
+func TestBreakerKey(t *testing.T) {
+	assert.Equal(t, "get_state/statestore", breakerKey("get_state", "statestore"))
+	assert.Equal(t, "list_components", breakerKey("list_components", ""))
+}
+
+func TestBackoff(t *testing.T) {
+	p := Policy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 300 * time.Millisecond, 6: 300 * time.Millisecond} {
+		got := backoff(p, attempt)
+		assert.GreaterOrEqual(t, got, want, attempt)
+		assert.Less(t, got, want+want/5+time.Nanosecond, attempt)
+	}
+}
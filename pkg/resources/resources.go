@@ -0,0 +1,190 @@
+// Package resources holds the shared plumbing for MCP resources backed by Dapr:
+// routing resource subscriptions to the package that serves them, and
+// buffering recent updates.
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Subscriber starts and stops the feed behind a subscribable resource.
+type Subscriber interface {
+	Subscribe(ctx context.Context, uri string) error
+	Unsubscribe(ctx context.Context, uri string) error
+}
+
+type route struct {
+	prefix     string
+	subscriber Subscriber
+}
+
+// Router dispatches resources/subscribe and resources/unsubscribe requests to
+// the Subscriber registered for the longest matching URI prefix. It tracks the
+// subscriptions of every session, so that a Subscriber sees each session
+// subscribe to a URI at most once, and unsubscribes whatever a session left
+// behind when it disconnects.
+type Router struct {
+	mu       sync.RWMutex
+	routes   []route
+	sessions map[*mcp.ServerSession]map[string]bool
+}
+
+// NewRouter creates an empty Router.
+func NewRouter() *Router {
+	return &Router{sessions: make(map[*mcp.ServerSession]map[string]bool)}
+}
+
+// Handle registers s for every resource URI starting with prefix.
+func (r *Router) Handle(prefix string, s Subscriber) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes = append(r.routes, route{prefix: prefix, subscriber: s})
+}
+
+func (r *Router) lookup(uri string) (Subscriber, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var match *route
+	for i := range r.routes {
+		if strings.HasPrefix(uri, r.routes[i].prefix) && (match == nil || len(r.routes[i].prefix) > len(match.prefix)) {
+			match = &r.routes[i]
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("resource '%s' does not support subscriptions", uri)
+	}
+	return match.subscriber, nil
+}
+
+// Subscribe is an mcp.ServerOptions.SubscribeHandler. Repeated subscriptions
+// of a session to the same URI are ignored.
+func (r *Router) Subscribe(ctx context.Context, req *mcp.SubscribeRequest) error {
+	uri := req.Params.URI
+	s, err := r.lookup(uri)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	uris, known := r.sessions[req.Session]
+	if _, ok := uris[uri]; ok {
+		r.mu.Unlock()
+		return nil
+	}
+	if !known {
+		uris = make(map[string]bool)
+		r.sessions[req.Session] = uris
+	}
+	// The subscription is pending until the Subscriber accepted it.
+	uris[uri] = false
+	r.mu.Unlock()
+	if !known && req.Session != nil {
+		go r.watch(req.Session)
+	}
+
+	err = s.Subscribe(ctx, uri)
+
+	r.mu.Lock()
+	_, wanted := r.sessions[req.Session][uri]
+	if err != nil || !wanted {
+		delete(r.sessions[req.Session], uri)
+	} else {
+		r.sessions[req.Session][uri] = true
+	}
+	r.mu.Unlock()
+	if err == nil && !wanted {
+		// The session unsubscribed or disconnected in the meantime.
+		return s.Unsubscribe(context.Background(), uri)
+	}
+	return err
+}
+
+// Unsubscribe is an mcp.ServerOptions.UnsubscribeHandler. Unsubscribing from
+// a URI the session is not subscribed to does nothing.
+func (r *Router) Unsubscribe(ctx context.Context, req *mcp.UnsubscribeRequest) error {
+	uri := req.Params.URI
+	s, err := r.lookup(uri)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	active := r.sessions[req.Session][uri]
+	delete(r.sessions[req.Session], uri)
+	r.mu.Unlock()
+	if !active {
+		return nil
+	}
+	return s.Unsubscribe(ctx, uri)
+}
+
+// watch unsubscribes the session from everything once it disconnects; the
+// SDK drops the session without calling the unsubscribe handler.
+func (r *Router) watch(session *mcp.ServerSession) {
+	_ = session.Wait()
+
+	r.mu.Lock()
+	uris := r.sessions[session]
+	delete(r.sessions, session)
+	r.mu.Unlock()
+
+	for uri, active := range uris {
+		s, err := r.lookup(uri)
+		if err != nil || !active {
+			continue
+		}
+		if err := s.Unsubscribe(context.Background(), uri); err != nil {
+			log.Printf("Failed to unsubscribe closed session from '%s': %v", uri, err)
+		}
+	}
+}
+
+// Ring keeps the most recent items up to a fixed capacity.
+type Ring[T any] struct {
+	items []T
+	next  int
+	full  bool
+}
+
+// NewRing creates a Ring holding at most size items.
+func NewRing[T any](size int) *Ring[T] {
+	return &Ring[T]{items: make([]T, size)}
+}
+
+// Push adds item, evicting the oldest item when the ring is full.
+func (r *Ring[T]) Push(item T) {
+	if len(r.items) == 0 {
+		return
+	}
+	r.items[r.next] = item
+	r.next = (r.next + 1) % len(r.items)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Items returns the buffered items, oldest first.
+func (r *Ring[T]) Items() []T {
+	if !r.full {
+		return append([]T{}, r.items[:r.next]...)
+	}
+	return append(append([]T{}, r.items[r.next:]...), r.items[:r.next]...)
+}
+
+// JSONResult builds a resources/read result holding v as JSON.
+func JSONResult(uri string, v any) (*mcp.ReadResourceResult, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resource '%s': %w", uri, err)
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: "application/json", Text: string(data)}},
+	}, nil
+}
@@ -0,0 +1,130 @@
+package resources
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSubscriber struct {
+	mu           sync.Mutex
+	subscribed   []string
+	unsubscribed []string
+}
+
+func (s *recordingSubscriber) Subscribe(_ context.Context, uri string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribed = append(s.subscribed, uri)
+	return nil
+}
+
+func (s *recordingSubscriber) Unsubscribe(_ context.Context, uri string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unsubscribed = append(s.unsubscribed, uri)
+	return nil
+}
+
+func (s *recordingSubscriber) unsubscribedURIs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.unsubscribed...)
+}
+
+func TestRouter(t *testing.T) {
+	router := NewRouter()
+	generic := &recordingSubscriber{}
+	specific := &recordingSubscriber{}
+	router.Handle("dapr://", generic)
+	router.Handle("dapr://pubsub/", specific)
+	ctx := context.Background()
+
+	require.NoError(t, router.Subscribe(ctx, &mcp.SubscribeRequest{Params: &mcp.SubscribeParams{URI: "dapr://pubsub/pubsub/orders"}}))
+	require.NoError(t, router.Subscribe(ctx, &mcp.SubscribeRequest{Params: &mcp.SubscribeParams{URI: "dapr://configuration/store/key"}}))
+	require.NoError(t, router.Unsubscribe(ctx, &mcp.UnsubscribeRequest{Params: &mcp.UnsubscribeParams{URI: "dapr://pubsub/pubsub/orders"}}))
+
+	assert.Equal(t, []string{"dapr://pubsub/pubsub/orders"}, specific.subscribed)
+	assert.Equal(t, []string{"dapr://pubsub/pubsub/orders"}, specific.unsubscribed)
+	assert.Equal(t, []string{"dapr://configuration/store/key"}, generic.subscribed)
+
+	err := router.Subscribe(ctx, &mcp.SubscribeRequest{Params: &mcp.SubscribeParams{URI: "file:///etc/hosts"}})
+	assert.ErrorContains(t, err, "does not support subscriptions")
+}
+
+func TestRouterSessions(t *testing.T) {
+	router := NewRouter()
+	subscriber := &recordingSubscriber{}
+	router.Handle("dapr://pubsub/", subscriber)
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, &mcp.ServerOptions{
+		SubscribeHandler:   router.Subscribe,
+		UnsubscribeHandler: router.Unsubscribe,
+	})
+	ctx := context.Background()
+	connect := func() *mcp.ClientSession {
+		serverTransport, clientTransport := mcp.NewInMemoryTransports()
+		_, err := server.Connect(ctx, serverTransport, nil)
+		require.NoError(t, err)
+		session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, clientTransport, nil)
+		require.NoError(t, err)
+		return session
+	}
+	orders := &mcp.SubscribeParams{URI: "dapr://pubsub/pubsub/orders"}
+	payments := &mcp.SubscribeParams{URI: "dapr://pubsub/pubsub/payments"}
+
+	first, second := connect(), connect()
+	defer second.Close()
+	require.NoError(t, first.Subscribe(ctx, orders))
+	require.NoError(t, first.Subscribe(ctx, orders))
+	require.NoError(t, first.Subscribe(ctx, payments))
+	require.NoError(t, second.Subscribe(ctx, orders))
+	// A repeated subscription reaches the subscriber only once per session.
+	assert.Equal(t, []string{orders.URI, orders.URI, payments.URI}, sorted(subscriber.subscribed))
+
+	// Unsubscribing twice, or from a URI never subscribed to, is a no-op.
+	require.NoError(t, second.Unsubscribe(ctx, &mcp.UnsubscribeParams{URI: orders.URI}))
+	require.NoError(t, second.Unsubscribe(ctx, &mcp.UnsubscribeParams{URI: orders.URI}))
+	require.NoError(t, second.Unsubscribe(ctx, &mcp.UnsubscribeParams{URI: payments.URI}))
+	assert.Equal(t, []string{orders.URI}, subscriber.unsubscribedURIs())
+
+	// A session that disconnects is unsubscribed from everything.
+	require.NoError(t, first.Close())
+	assert.Eventually(t, func() bool { return len(subscriber.unsubscribedURIs()) == 3 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{orders.URI, orders.URI, payments.URI}, sorted(subscriber.unsubscribedURIs()))
+}
+
+func sorted(s []string) []string {
+	s = append([]string{}, s...)
+	sort.Strings(s)
+	return s
+}
+
+func TestRing(t *testing.T) {
+	ring := NewRing[int](3)
+	assert.Empty(t, ring.Items())
+
+	ring.Push(1)
+	ring.Push(2)
+	assert.Equal(t, []int{1, 2}, ring.Items())
+
+	ring.Push(3)
+	ring.Push(4)
+	ring.Push(5)
+	assert.Equal(t, []int{3, 4, 5}, ring.Items())
+}
+
+func TestJSONResult(t *testing.T) {
+	result, err := JSONResult("dapr://pubsub/pubsub/orders", map[string]int{"count": 1})
+
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, "application/json", result.Contents[0].MIMEType)
+	assert.JSONEq(t, `{"count": 1}`, result.Contents[0].Text)
+}
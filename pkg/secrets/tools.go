@@ -10,6 +10,8 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
 )
 
 // SecretsClient defines the interface for secrets operations.
@@ -47,10 +49,10 @@ func getSecretTool(ctx context.Context, req *mcp.CallToolRequest, args GetSecret
 	if err != nil {
 		log.Printf("Dapr GetSecret failed: %v", err)
 		toolErrorMessage := fmt.Errorf("failed to get secret '%s': %w", args.SecretName, err).Error()
-		return &mcp.CallToolResult{
+		return toolcall.WithStatus(&mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
 			IsError: true,
-		}, nil, nil
+		}, err), nil, nil
 	}
 
 	var secretKeys []string
@@ -90,10 +92,10 @@ func getBulkSecretTool(ctx context.Context, req *mcp.CallToolRequest, args GetBu
 	if err != nil {
 		log.Printf("Dapr GetBulkSecret failed: %v", err)
 		toolErrorMessage := fmt.Errorf("failed to get bulk secrets from store '%s': %w", args.StoreName, err).Error()
-		return &mcp.CallToolResult{
+		return toolcall.WithStatus(&mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
 			IsError: true,
-		}, nil, nil
+		}, err), nil, nil
 	}
 
 	var secretNames []string
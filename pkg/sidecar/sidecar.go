@@ -0,0 +1,150 @@
+// Package sidecar calls services through the Dapr sidecar's HTTP API.
+package sidecar
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultSidecarHTTPPort is the Dapr sidecar's default HTTP port.
+	defaultSidecarHTTPPort = "3500"
+	// apiTokenHeader carries the Dapr API token on sidecar requests.
+	apiTokenHeader = "dapr-api-token"
+	// maxResponseBytes bounds the response body read from a service.
+	maxResponseBytes = 64 << 20
+)
+
+// InvokeRequest is a service invocation request.
+type InvokeRequest struct {
+	AppID       string
+	Method      string
+	Verb        string
+	Query       url.Values
+	Headers     map[string]string
+	ContentType string
+	Body        []byte
+}
+
+// InvokeResponse is a service's response to an InvokeRequest.
+type InvokeResponse struct {
+	StatusCode  int
+	Headers     http.Header
+	ContentType string
+	Body        []byte
+}
+
+// Client invokes services through the Dapr sidecar's HTTP API, which,
+// unlike the gRPC invocation API, passes query strings and headers through and
+// reports the response status and headers.
+type Client struct {
+	endpoint   string
+	apiToken   string
+	httpClient *http.Client
+}
+
+// DefaultEndpoint returns the sidecar's HTTP endpoint from the DAPR_HTTP_ENDPOINT
+// or DAPR_HTTP_PORT environment variables.
+func DefaultEndpoint() string {
+	if endpoint := os.Getenv("DAPR_HTTP_ENDPOINT"); endpoint != "" {
+		return endpoint
+	}
+	port := os.Getenv("DAPR_HTTP_PORT")
+	if port == "" {
+		port = defaultSidecarHTTPPort
+	}
+	return "http://127.0.0.1:" + port
+}
+
+// NewClient creates a Client for the sidecar at endpoint, authenticating with
+// the DAPR_API_TOKEN environment variable when it is set. A nil httpClient uses
+// a client with a 60 second timeout.
+func NewClient(endpoint string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	return &Client{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		apiToken:   os.Getenv("DAPR_API_TOKEN"),
+		httpClient: httpClient,
+	}
+}
+
+// setInvocationPath appends the invocation path of a method to the endpoint
+// URL. Method segments may already be percent-encoded, as in paths built from
+// an OpenAPI document; each one is decoded and escaped again, and '.' and '..'
+// segments are rejected, so a method can never resolve to another sidecar API
+// such as /v1.0/secrets.
+func setInvocationPath(u *url.URL, appID, method string) error {
+	segments := []string{"v1.0", "invoke", url.PathEscape(appID), "method"}
+	segments = append(segments, strings.Split(strings.TrimPrefix(method, "/"), "/")...)
+	rawPath := strings.TrimSuffix(u.EscapedPath(), "/")
+	path := strings.TrimSuffix(u.Path, "/")
+	for i, segment := range segments {
+		decoded, err := url.PathUnescape(segment)
+		if err != nil {
+			decoded = segment
+		}
+		if decoded == "." || decoded == ".." || (i == 2 && decoded == "") {
+			return fmt.Errorf("invalid invocation target: app ID '%s' and method '%s' must not contain '.' or '..' path segments", appID, method)
+		}
+		rawPath += "/" + url.PathEscape(decoded)
+		path += "/" + decoded
+	}
+	u.Path = path
+	u.RawPath = rawPath
+	return nil
+}
+
+// InvokeService calls a method on another Dapr application.
+func (c *Client) InvokeService(ctx context.Context, req *InvokeRequest) (*InvokeResponse, error) {
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sidecar endpoint '%s': %w", c.endpoint, err)
+	}
+	if err := setInvocationPath(u, req.AppID, req.Method); err != nil {
+		return nil, err
+	}
+	u.RawQuery = req.Query.Encode()
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Verb, u.String(), bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if req.ContentType != "" && len(req.Body) > 0 {
+		httpReq.Header.Set("Content-Type", req.ContentType)
+	}
+	if c.apiToken != "" {
+		httpReq.Header.Set(apiTokenHeader, c.apiToken)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(body) > maxResponseBytes {
+		return nil, fmt.Errorf("response exceeds %d bytes", maxResponseBytes)
+	}
+	return &InvokeResponse{
+		StatusCode:  resp.StatusCode,
+		Headers:     resp.Header,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        body,
+	}, nil
+}
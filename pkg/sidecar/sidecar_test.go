@@ -0,0 +1,92 @@
+package sidecar
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientInvokeService(t *testing.T) {
+	t.Setenv("DAPR_API_TOKEN", "secret")
+	var got *http.Request
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Add("X-Request-Id", "abc")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"accepted":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", nil)
+	resp, err := client.InvokeService(context.Background(), &InvokeRequest{
+		AppID:       "order-service",
+		Method:      "orders/123",
+		Verb:        "PUT",
+		Query:       url.Values{"force": {"true"}},
+		Headers:     map[string]string{"X-Tenant": "acme"},
+		ContentType: "text/plain",
+		Body:        []byte("hello"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPut, got.Method)
+	assert.Equal(t, "/v1.0/invoke/order-service/method/orders/123", got.URL.Path)
+	assert.Equal(t, "force=true", got.URL.RawQuery)
+	assert.Equal(t, "acme", got.Header.Get("X-Tenant"))
+	assert.Equal(t, "text/plain", got.Header.Get("Content-Type"))
+	assert.Equal(t, "secret", got.Header.Get(apiTokenHeader))
+	assert.Equal(t, "hello", string(gotBody))
+
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.ContentType)
+	assert.Equal(t, "abc", resp.Headers.Get("X-Request-Id"))
+	assert.Equal(t, `{"accepted":true}`, string(resp.Body))
+}
+
+func TestClientInvokeServicePath(t *testing.T) {
+	var requestURI string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURI = r.RequestURI
+	}))
+	defer server.Close()
+	client := NewClient(server.URL+"/base", nil)
+
+	_, err := client.InvokeService(context.Background(), &InvokeRequest{AppID: "order-service", Method: "/orders/a b/eu%2F1?x/100%", Verb: "GET"})
+	require.NoError(t, err)
+	assert.Equal(t, "/base/v1.0/invoke/order-service/method/orders/a%20b/eu%2F1%3Fx/100%25", requestURI)
+
+	for _, req := range []InvokeRequest{
+		{AppID: "order-service", Method: "../../../secrets/vault/db"},
+		{AppID: "order-service", Method: "orders/./1"},
+		{AppID: "order-service", Method: "%2e%2e/%2E%2E/v1.0/secrets/vault/db"},
+		{AppID: "..", Method: "v1.0/state/statestore/key"},
+		{AppID: "", Method: "orders"},
+	} {
+		requestURI = ""
+		req.Verb = "GET"
+		_, err := client.InvokeService(context.Background(), &req)
+		assert.Error(t, err, req.Method)
+		assert.Empty(t, requestURI, "request must not reach the sidecar")
+	}
+}
+
+func TestDefaultEndpoint(t *testing.T) {
+	t.Setenv("DAPR_HTTP_ENDPOINT", "")
+	t.Setenv("DAPR_HTTP_PORT", "")
+	assert.Equal(t, "http://127.0.0.1:3500", DefaultEndpoint())
+
+	t.Setenv("DAPR_HTTP_PORT", "3600")
+	assert.Equal(t, "http://127.0.0.1:3600", DefaultEndpoint())
+
+	t.Setenv("DAPR_HTTP_ENDPOINT", "https://dapr.example.com")
+	assert.Equal(t, "https://dapr.example.com", DefaultEndpoint())
+}
@@ -0,0 +1,113 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/dapr/dapr-mcp-server/pkg/dryrun"
+	"github.com/dapr/dapr-mcp-server/pkg/metadata"
+)
+
+// describeOptions renders state options the way they would be sent to the sidecar.
+func describeOptions(opts *dapr.StateOptions) map[string]string {
+	if opts == nil {
+		return nil
+	}
+	described := make(map[string]string, 2)
+	if opts.Concurrency != dapr.StateConcurrencyUndefined {
+		described["concurrency"] = opts.Concurrency.String()
+	}
+	if opts.Consistency != dapr.StateConsistencyUndefined {
+		described["consistency"] = opts.Consistency.String()
+	}
+	return described
+}
+
+func planDeleteState(ctx context.Context, args DeleteStateArgs, opts *dapr.StateOptions) (*mcp.CallToolResult, any, error) {
+	if args.StoreName == "" || args.Key == "" {
+		return dryrun.Invalid("delete_state", errors.New("storeName and key are required"))
+	}
+	comp, err := metadata.FindComponent(ctx, stateClient, args.StoreName, "state.")
+	if err != nil {
+		return dryrun.Invalid("delete_state", err)
+	}
+
+	operation := "DeleteState"
+	request := map[string]interface{}{
+		"storeName": args.StoreName,
+		"key":       args.Key,
+		"metadata":  args.Metadata,
+	}
+	if args.ETag != "" || opts != nil {
+		operation = "DeleteStateWithETag"
+		request["etag"] = args.ETag
+		request["options"] = describeOptions(opts)
+	}
+
+	return dryrun.Result(dryrun.Plan{
+		Tool:          "delete_state",
+		Operation:     operation,
+		Component:     comp.Name,
+		ComponentType: comp.Type,
+		Request:       request,
+	})
+}
+
+func planTransaction(ctx context.Context, args ExecuteTransactionArgs, meta map[string]string, ops []*dapr.StateOperation) (*mcp.CallToolResult, any, error) {
+	if args.StoreName == "" {
+		return dryrun.Invalid("execute_transaction", errors.New("storeName is required"))
+	}
+	if len(ops) == 0 {
+		return dryrun.Invalid("execute_transaction", errors.New("at least one item is required"))
+	}
+	comp, err := metadata.FindComponent(ctx, stateClient, args.StoreName, "state.")
+	if err != nil {
+		return dryrun.Invalid("execute_transaction", err)
+	}
+
+	operations := make([]map[string]interface{}, 0, len(ops))
+	for i, op := range ops {
+		if op.Item.Key == "" {
+			return dryrun.Invalid("execute_transaction", fmt.Errorf("item %d has an empty key", i))
+		}
+		described := map[string]interface{}{
+			"operationType": op.Type.String(),
+			"key":           op.Item.Key,
+		}
+		if op.Type == dapr.StateOperationTypeUpsert {
+			described["value"] = dryrun.Payload(op.Item.Value)
+		}
+		if op.Item.Etag != nil {
+			described["etag"] = op.Item.Etag.Value
+		}
+		if len(op.Item.Metadata) > 0 {
+			described["metadata"] = op.Item.Metadata
+		}
+		if op.Item.Options != nil {
+			described["options"] = describeOptions(op.Item.Options)
+		}
+		operations = append(operations, described)
+	}
+
+	var warnings []string
+	if !comp.HasCapability(metadata.CapabilityTransactional) {
+		warnings = append(warnings, fmt.Sprintf("state store '%s' does not report the %s capability; the sidecar is likely to reject the transaction", comp.Name, metadata.CapabilityTransactional))
+	}
+
+	return dryrun.Result(dryrun.Plan{
+		Tool:          "execute_transaction",
+		Operation:     "ExecuteStateTransaction",
+		Component:     comp.Name,
+		ComponentType: comp.Type,
+		Request: map[string]interface{}{
+			"storeName":  args.StoreName,
+			"metadata":   meta,
+			"operations": operations,
+		},
+		Warnings: warnings,
+	})
+}
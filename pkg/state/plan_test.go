@@ -0,0 +1,119 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dapr/dapr-mcp-server/pkg/dryrun"
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+)
+
+func mockStoreMetadata(m *mocks.MockDaprClient, capabilities ...string) {
+	m.On("GetMetadata", mock.Anything).Return(&client.GetMetadataResponse{
+		RegisteredComponents: []*client.MetadataRegisteredComponents{
+			{Name: "statestore", Type: "state.redis", Capabilities: capabilities},
+		},
+	}, nil)
+}
+
+func TestDeleteStateDryRun(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	mockStoreMetadata(mockClient)
+	stateClient = mockClient
+
+	result, structured, err := deleteStateTool(context.Background(), &mcp.CallToolRequest{}, DeleteStateArgs{
+		StoreName: "statestore",
+		Key:       "order-1",
+		ETag:      "3",
+		DryRun:    true,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "would call DeleteStateWithETag on component 'statestore' (state.redis)")
+	plan := structured.(map[string]interface{})["plan"].(dryrun.Plan)
+	assert.Equal(t, "3", plan.Request.(map[string]interface{})["etag"])
+	mockClient.AssertNotCalled(t, "DeleteState")
+	mockClient.AssertNotCalled(t, "DeleteStateWithETag")
+}
+
+func TestDeleteStateServerWideDryRun(t *testing.T) {
+	dryrun.SetEnabled(true)
+	t.Cleanup(func() { dryrun.SetEnabled(false) })
+
+	mockClient := new(mocks.MockDaprClient)
+	mockClient.On("GetMetadata", mock.Anything).Return(&client.GetMetadataResponse{}, nil)
+	stateClient = mockClient
+
+	result, _, err := deleteStateTool(context.Background(), &mcp.CallToolRequest{}, DeleteStateArgs{StoreName: "missing", Key: "k"})
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "no state component named 'missing'")
+	mockClient.AssertNotCalled(t, "DeleteState")
+}
+
+func TestExecuteTransactionDryRun(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	mockStoreMetadata(mockClient)
+	stateClient = mockClient
+
+	result, structured, err := executeTransactionTool(context.Background(), &mcp.CallToolRequest{}, ExecuteTransactionArgs{
+		StoreName: "statestore",
+		Items: []TransactionItem{
+			{Key: "a", Value: map[string]any{"n": 1.0}},
+			{Key: "b", IsDelete: true, Concurrency: "first-write"},
+		},
+		DryRun: true,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsError)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "would call ExecuteStateTransaction")
+	assert.Contains(t, text, "WARNING: state store 'statestore' does not report the TRANSACTIONAL capability")
+	plan := structured.(map[string]interface{})["plan"].(dryrun.Plan)
+	operations := plan.Request.(map[string]interface{})["operations"].([]map[string]interface{})
+	assert.Equal(t, "upsert", operations[0]["operationType"])
+	assert.Equal(t, `{"n":1}`, operations[0]["value"])
+	assert.Equal(t, map[string]string{"concurrency": "first-write"}, operations[1]["options"])
+	mockClient.AssertNotCalled(t, "ExecuteStateTransaction")
+}
+
+func TestExecuteTransactionDryRunMetadata(t *testing.T) {
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	mockClient := new(mocks.MockDaprClient)
+	mockStoreMetadata(mockClient, "TRANSACTIONAL")
+	stateClient = mockClient
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	_, structured, err := executeTransactionTool(ctx, &mcp.CallToolRequest{}, ExecuteTransactionArgs{
+		StoreName: "statestore",
+		Items:     []TransactionItem{{Key: "a", Value: "x"}},
+		Metadata:  map[string]string{"partitionKey": "eu"},
+		DryRun:    true,
+	})
+
+	assert.NoError(t, err)
+	// The plan reports the metadata that would be sent, including the trace context.
+	plan := structured.(map[string]interface{})["plan"].(dryrun.Plan)
+	metadata := plan.Request.(map[string]interface{})["metadata"].(map[string]string)
+	assert.Equal(t, "eu", metadata["partitionKey"])
+	assert.Contains(t, metadata, "traceparent")
+	assert.Empty(t, plan.Warnings)
+}
@@ -0,0 +1,71 @@
+package state
+
+import (
+	"context"
+	"log"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dapr/dapr-mcp-server/pkg/auth"
+)
+
+// ErrCodeAccessDenied is returned in the structured result when the state
+// policy does not allow the caller to access a store or key.
+const ErrCodeAccessDenied = "ACCESS_DENIED"
+
+var statePolicy *auth.StatePolicy
+
+// SetPolicy restricts the state tools to the stores and keys allowed for the
+// caller's identity. A nil policy allows all access.
+func SetPolicy(policy *auth.StatePolicy) {
+	statePolicy = policy
+}
+
+// authorize checks every key against the state policy for the identity in ctx
+// and records any denial as a span event.
+func authorize(ctx context.Context, op auth.StateOperation, storeName string, keys ...string) error {
+	id := auth.GetIdentity(ctx)
+	for _, key := range keys {
+		if err := statePolicy.Authorize(id, op, storeName, key); err != nil {
+			recordDenial(ctx, op, storeName, key, id, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// authorizeStore checks that the policy allows op on at least some keys of the store.
+func authorizeStore(ctx context.Context, op auth.StateOperation, storeName string) error {
+	id := auth.GetIdentity(ctx)
+	if err := statePolicy.AuthorizeStore(id, op, storeName); err != nil {
+		recordDenial(ctx, op, storeName, "", id, err)
+		return err
+	}
+	return nil
+}
+
+func recordDenial(ctx context.Context, op auth.StateOperation, storeName, key string, id *auth.Identity, err error) {
+	subject := ""
+	if id != nil {
+		subject = id.Subject
+	}
+	trace.SpanFromContext(ctx).AddEvent("state.access_denied", trace.WithAttributes(
+		attribute.String("dapr.state.operation", string(op)),
+		attribute.String("dapr.store", storeName),
+		attribute.String("dapr.key", key),
+		attribute.String("auth.subject", subject),
+	))
+	log.Printf("State access denied: %v", err)
+}
+
+func accessDeniedResult(storeName string, err error) (*mcp.CallToolResult, any, error) {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}},
+		IsError: true,
+	}, map[string]interface{}{
+		"error_code": ErrCodeAccessDenied,
+		"store_name": storeName,
+	}, nil
+}
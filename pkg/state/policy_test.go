@@ -0,0 +1,165 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/dapr/dapr-mcp-server/pkg/auth"
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+)
+
+func withTestPolicy(t *testing.T) {
+	t.Helper()
+	SetPolicy(&auth.StatePolicy{
+		Rules: []auth.StateRule{
+			{
+				Subjects:   []string{"*"},
+				Stores:     []string{"statestore"},
+				Keys:       []string{"{subject}||*"},
+				Operations: []auth.StateOperation{auth.StateRead, auth.StateWrite},
+			},
+		},
+	})
+	t.Cleanup(func() { SetPolicy(nil) })
+}
+
+func TestStatePolicyEnforcement(t *testing.T) {
+	withTestPolicy(t)
+	alice := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "alice"})
+
+	t.Run("write to own prefix is allowed", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		mockClient.On("SaveState", mock.Anything, "statestore", "alice||cart", []byte("v"), mock.Anything, mock.Anything).Return(nil)
+		stateClient = mockClient
+
+		result, _, err := saveStateTool(alice, &mcp.CallToolRequest{}, SaveStateArgs{StoreName: "statestore", Key: "alice||cart", Value: "v"})
+
+		assert.NoError(t, err)
+		assert.False(t, result.IsError)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("write to another prefix is denied", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		stateClient = mockClient
+
+		result, structured, err := saveStateTool(alice, &mcp.CallToolRequest{}, SaveStateArgs{StoreName: "statestore", Key: "bob||cart", Value: "v"})
+
+		assert.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "access denied")
+		assert.Equal(t, ErrCodeAccessDenied, structured.(map[string]interface{})["error_code"])
+		mockClient.AssertNotCalled(t, "SaveState")
+	})
+
+	t.Run("delete is denied without the delete operation", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		stateClient = mockClient
+
+		result, _, err := deleteStateTool(alice, &mcp.CallToolRequest{}, DeleteStateArgs{StoreName: "statestore", Key: "alice||cart"})
+
+		assert.NoError(t, err)
+		assert.True(t, result.IsError)
+		mockClient.AssertNotCalled(t, "DeleteState")
+	})
+
+	t.Run("transaction with one denied item is rejected", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		stateClient = mockClient
+
+		result, _, err := executeTransactionTool(alice, &mcp.CallToolRequest{}, ExecuteTransactionArgs{
+			StoreName: "statestore",
+			Items: []TransactionItem{
+				{Key: "alice||a", Value: "1"},
+				{Key: "bob||b", Value: "2"},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, result.IsError)
+		mockClient.AssertNotCalled(t, "ExecuteStateTransaction")
+	})
+
+	t.Run("unauthenticated read is denied", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		stateClient = mockClient
+
+		result, _, err := getStateTool(context.Background(), &mcp.CallToolRequest{}, GetStateArgs{StoreName: "statestore", Key: "alice||cart"})
+
+		assert.NoError(t, err)
+		assert.True(t, result.IsError)
+		mockClient.AssertNotCalled(t, "GetState")
+	})
+
+	t.Run("query hides keys outside the allowed prefix", func(t *testing.T) {
+		mockClient := new(mocks.MockDaprClient)
+		mockClient.On("QueryStateAlpha1", mock.Anything, "statestore", mock.Anything, mock.Anything).
+			Return(&client.QueryResponse{Results: []client.QueryItem{{Key: "alice||1"}, {Key: "bob||1"}}}, nil)
+		stateClient = mockClient
+
+		result, structured, err := queryStateTool(alice, &mcp.CallToolRequest{}, QueryStateArgs{StoreName: "statestore", Query: map[string]any{}})
+
+		assert.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Equal(t, 1, structured.(map[string]interface{})["count"])
+		text := result.Content[0].(*mcp.TextContent).Text
+		assert.Contains(t, text, "returned 1 result(s)")
+		assert.NotContains(t, text, "hidden", "the number of keys the caller may not read is not revealed")
+	})
+}
+
+func TestImportDryRunWithoutReadAccess(t *testing.T) {
+	SetPolicy(&auth.StatePolicy{
+		Rules: []auth.StateRule{
+			{Subjects: []string{"*"}, Stores: []string{"statestore"}, Operations: []auth.StateOperation{auth.StateWrite}},
+		},
+	})
+	t.Cleanup(func() { SetPolicy(nil) })
+	writer := auth.WithIdentity(context.Background(), &auth.Identity{Subject: "writer"})
+
+	mockClient := new(mocks.MockDaprClient)
+	mockClient.On("GetMetadata", mock.Anything).Return(&client.GetMetadataResponse{
+		RegisteredComponents: []*client.MetadataRegisteredComponents{
+			{Name: "statestore", Type: "state.redis", Capabilities: []string{"TRANSACTIONAL"}},
+		},
+	}, nil)
+	stateClient = mockClient
+
+	result, structured, err := importStateTool(writer, &mcp.CallToolRequest{}, ImportStateArgs{
+		Snapshot: Snapshot{Version: snapshotVersion, StoreName: "statestore", Items: []SnapshotItem{{Key: "a", Value: "1"}}},
+		DryRun:   true,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "would write 1 key(s)")
+	assert.Equal(t, []ImportChange{{Key: "a", Action: "write"}}, structured.(map[string]interface{})["changes"])
+	mockClient.AssertNotCalled(t, "GetBulkState", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStatePolicyDenialRecordsSpanEvent(t *testing.T) {
+	withTestPolicy(t)
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	ctx, span := provider.Tracer("test").Start(auth.WithIdentity(context.Background(), &auth.Identity{Subject: "alice"}), "test")
+	err := authorize(ctx, auth.StateWrite, "statestore", "bob||cart")
+	span.End()
+
+	assert.ErrorIs(t, err, auth.ErrAccessDenied)
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) && assert.Len(t, spans[0].Events(), 1) {
+		event := spans[0].Events()[0]
+		assert.Equal(t, "state.access_denied", event.Name)
+		assert.Contains(t, event.Attributes, attribute.String("dapr.key", "bob||cart"))
+		assert.Contains(t, event.Attributes, attribute.String("auth.subject", "alice"))
+	}
+}
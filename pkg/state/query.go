@@ -0,0 +1,344 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"math"
+	"strings"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/dapr/dapr-mcp-server/pkg/auth"
+)
+
+const (
+	defaultQueryPages = 1
+	maxQueryPages     = 10
+)
+
+type QueryStateArgs struct {
+	StoreName string            `json:"storeName" jsonschema:"The name of a Dapr state store component that reports the QUERY_API capability."`
+	Query     map[string]any    `json:"query" jsonschema:"The query document. May contain 'filter' (e.g., {\"EQ\": {\"state\": \"CA\"}}), 'sort' (e.g., [{\"key\": \"id\", \"order\": \"DESC\"}]) and 'page' (e.g., {\"limit\": 10})."`
+	Token     string            `json:"token,omitempty" jsonschema:"Optional continuation token returned by a previous query_state call, used to fetch the next page."`
+	MaxPages  int               `json:"maxPages,omitempty" jsonschema:"Optional number of pages to fetch by following continuation tokens (default 1, maximum 10)."`
+	Metadata  map[string]string `json:"metadata,omitempty" jsonschema:"Optional store-specific query metadata (e.g., 'queryIndexName' for Redis)."`
+	Encoding  string            `json:"encoding,omitempty" jsonschema:"Optional encoding for the returned values: 'text', 'json' or 'base64'. By default JSON values are returned as structured JSON and binary values as base64."`
+}
+
+// QueryResult is a single item returned by query_state.
+type QueryResult struct {
+	Key      string `json:"key"`
+	Value    any    `json:"value,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Etag     string `json:"etag,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// validateQuery checks a query document against the Dapr state query grammar.
+func validateQuery(query map[string]any) error {
+	for section, value := range query {
+		switch section {
+		case "filter":
+			if err := validateFilter(value); err != nil {
+				return fmt.Errorf("invalid filter: %w", err)
+			}
+		case "sort":
+			if err := validateSort(value); err != nil {
+				return fmt.Errorf("invalid sort: %w", err)
+			}
+		case "page":
+			if err := validatePage(value); err != nil {
+				return fmt.Errorf("invalid page: %w", err)
+			}
+		default:
+			return fmt.Errorf("unsupported query section %q (expected 'filter', 'sort' or 'page')", section)
+		}
+	}
+	return nil
+}
+
+func validateFilter(value any) error {
+	filter, ok := value.(map[string]any)
+	if !ok {
+		return errors.New("filter must be an object")
+	}
+	if len(filter) != 1 {
+		return fmt.Errorf("filter must have exactly one operator, got %d", len(filter))
+	}
+
+	for op, operand := range filter {
+		switch op {
+		case "EQ", "NEQ", "GT", "GTE", "LT", "LTE":
+			field, err := singleField(op, operand)
+			if err != nil {
+				return err
+			}
+			switch field.(type) {
+			case string, float64, bool:
+			default:
+				return fmt.Errorf("%s value must be a string, number or boolean", op)
+			}
+		case "IN":
+			field, err := singleField(op, operand)
+			if err != nil {
+				return err
+			}
+			values, ok := field.([]any)
+			if !ok || len(values) == 0 {
+				return errors.New("IN value must be a non-empty array")
+			}
+		case "AND", "OR":
+			children, ok := operand.([]any)
+			if !ok || len(children) < 2 {
+				return fmt.Errorf("%s must be an array of at least 2 filters", op)
+			}
+			for _, child := range children {
+				if err := validateFilter(child); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unsupported filter operator %q", op)
+		}
+	}
+	return nil
+}
+
+// singleField returns the value of a {"<field>": <value>} operand.
+func singleField(op string, operand any) (any, error) {
+	m, ok := operand.(map[string]any)
+	if !ok || len(m) != 1 {
+		return nil, fmt.Errorf("%s must be an object with exactly one field", op)
+	}
+	for field, value := range m {
+		if field == "" {
+			return nil, fmt.Errorf("%s field name must not be empty", op)
+		}
+		return value, nil
+	}
+	return nil, nil
+}
+
+func validateSort(value any) error {
+	sorts, ok := value.([]any)
+	if !ok {
+		return errors.New("sort must be an array")
+	}
+	for i, s := range sorts {
+		entry, ok := s.(map[string]any)
+		if !ok {
+			return fmt.Errorf("sort[%d] must be an object", i)
+		}
+		for k, v := range entry {
+			switch k {
+			case "key":
+				if key, ok := v.(string); !ok || key == "" {
+					return fmt.Errorf("sort[%d].key must be a non-empty string", i)
+				}
+			case "order":
+				if order, ok := v.(string); !ok || (order != "ASC" && order != "DESC") {
+					return fmt.Errorf("sort[%d].order must be 'ASC' or 'DESC'", i)
+				}
+			default:
+				return fmt.Errorf("sort[%d] has unsupported field %q", i, k)
+			}
+		}
+		if _, ok := entry["key"]; !ok {
+			return fmt.Errorf("sort[%d].key is required", i)
+		}
+	}
+	return nil
+}
+
+func validatePage(value any) error {
+	page, ok := value.(map[string]any)
+	if !ok {
+		return errors.New("page must be an object")
+	}
+	for k, v := range page {
+		switch k {
+		case "limit":
+			limit, ok := v.(float64)
+			if !ok || limit < 0 || limit != math.Trunc(limit) {
+				return errors.New("page.limit must be a non-negative integer")
+			}
+		case "token":
+			if _, ok := v.(string); !ok {
+				return errors.New("page.token must be a string")
+			}
+		default:
+			return fmt.Errorf("page has unsupported field %q", k)
+		}
+	}
+	return nil
+}
+
+func queryStateTool(ctx context.Context, req *mcp.CallToolRequest, args QueryStateArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "query_state")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "query_state"),
+		attribute.String("dapr.store", args.StoreName),
+	)
+
+	if err := authorizeStore(ctx, auth.StateRead, args.StoreName); err != nil {
+		return accessDeniedResult(args.StoreName, err)
+	}
+
+	maxPages := args.MaxPages
+	if maxPages == 0 {
+		maxPages = defaultQueryPages
+	}
+	if maxPages < 0 || maxPages > maxQueryPages {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("maxPages must be between 1 and %d, got %d", maxQueryPages, args.MaxPages)}},
+			IsError: true,
+		}, nil, nil
+	}
+
+	query := make(map[string]any, len(args.Query))
+	for k, v := range args.Query {
+		query[k] = v
+	}
+	if err := validateQuery(query); err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("query rejected before sending to store '%s': %v", args.StoreName, err)}},
+			IsError: true,
+		}, nil, nil
+	}
+
+	items, token, pages, err := runQuery(ctx, args.StoreName, query, args.Token, maxPages, args.Metadata)
+	if err != nil {
+		return stateError("QueryStateAlpha1", err)
+	}
+
+	results := make([]QueryResult, 0, len(items))
+	hidden := 0
+	for _, item := range items {
+		// Keys the caller may not read are dropped rather than failing the whole query.
+		if statePolicy.Authorize(auth.GetIdentity(ctx), auth.StateRead, args.StoreName, item.Key) != nil {
+			hidden++
+			continue
+		}
+		result := QueryResult{
+			Key:   item.Key,
+			Etag:  item.Etag,
+			Error: item.Error,
+		}
+		if result.Error == "" {
+			value, encoding, err := decodeValue(item.Value, "", args.Encoding)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Value, result.Encoding = value, encoding
+			}
+		}
+		results = append(results, result)
+	}
+
+	span.SetAttributes(
+		attribute.Int("dapr.query.pages", pages),
+		attribute.Int("dapr.query.results", len(results)),
+		attribute.Int("dapr.query.hidden", hidden),
+	)
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Query on store '%s' returned %d result(s) across %d page(s).", args.StoreName, len(results), pages)
+	if token != "" {
+		fmt.Fprintf(&text, " More results are available; pass token '%s' to fetch the next page.", token)
+	}
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Fprintf(&text, "\n- %s: ERROR %s", r.Key, r.Error)
+		} else {
+			fmt.Fprintf(&text, "\n- %s: %s", r.Key, displayValue(r.Value, r.Encoding))
+		}
+	}
+	// The number of hidden results is only logged and traced: telling the
+	// caller would reveal that keys it may not read exist.
+	log.Printf("Query on store '%s' returned %d result(s) across %d page(s); %d were hidden by the state access policy.", args.StoreName, len(results), pages, hidden)
+
+	structuredResult := map[string]interface{}{
+		"store_name": args.StoreName,
+		"results":    results,
+		"count":      len(results),
+		"pages":      pages,
+		"token":      token,
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: text.String()}},
+	}, structuredResult, nil
+}
+
+// runQuery sends a validated query to the store, following continuation tokens
+// for up to maxPages pages. It returns the items, the token for the next page
+// (empty when there are no more results) and the number of pages fetched.
+func runQuery(ctx context.Context, storeName string, query map[string]any, token string, maxPages int, meta map[string]string) ([]dapr.QueryItem, string, int, error) {
+	query = maps.Clone(query)
+	page := make(map[string]any)
+	if existing, ok := query["page"].(map[string]any); ok {
+		maps.Copy(page, existing)
+	}
+	if token != "" {
+		page["token"] = token
+	}
+	query["page"] = page
+
+	var items []dapr.QueryItem
+	pages := 0
+	for pages < maxPages {
+		body, err := json.Marshal(query)
+		if err != nil {
+			return nil, "", pages, fmt.Errorf("failed to encode query: %w", err)
+		}
+
+		resp, err := stateClient.QueryStateAlpha1(ctx, storeName, string(body), meta)
+		if err != nil {
+			return nil, "", pages, err
+		}
+		pages++
+		items = append(items, resp.Results...)
+
+		token = resp.Token
+		if token == "" {
+			break
+		}
+		page["token"] = token
+	}
+	return items, token, pages, nil
+}
+
+// RegisterQueryTools registers the state query tool. It should only be called
+// when at least one state store reports the QUERY_API capability.
+func RegisterQueryTools(server *mcp.Server, client StateClient) {
+	stateClient = client
+
+	isReadOnly := true
+	isIdempotent := true
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "query_state",
+		Title: "Query State Store",
+		Description: "Runs a filter/sort/page query against a Dapr state store that supports the Query API. **This is a Data Retrieval operation and IS IDEMPOTENT.** Use to find keys by value when the exact keys are unknown.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Use `get_components` to find a `StoreName` whose capabilities include `QUERY_API`.\n" +
+			"2. The `Query` is validated against the Dapr query grammar before it is sent: `filter` supports `EQ`, `NEQ`, `GT`, `GTE`, `LT`, `LTE`, `IN`, `AND` and `OR`; `sort` is a list of `{key, order}` with order `ASC` or `DESC`; `page` supports `limit` and `token`.\n" +
+			"3. If the result contains a `token`, call this tool again with the same `Query` and that `Token` to get the next page.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide a non-empty `StoreName` and a `Query` object (use `{}` to match everything).\n" +
+			"2. **SERIALIZATION**: `Query` MUST be a JSON object, NEVER a quoted string.\n" +
+			"3. **NEVER INVENT**: Never invent field names; they must come from the user or from previously retrieved values.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   isReadOnly,
+			IdempotentHint: isIdempotent,
+		},
+	}, queryStateTool)
+}
@@ -0,0 +1,208 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+	"github.com/dapr/dapr-mcp-server/test/testutil"
+)
+
+func parseQuery(t *testing.T, raw string) map[string]any {
+	t.Helper()
+	var q map[string]any
+	if err := json.Unmarshal([]byte(raw), &q); err != nil {
+		t.Fatalf("invalid test query: %v", err)
+	}
+	return q
+}
+
+func TestValidateQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{name: "empty query", query: `{}`},
+		{
+			name:  "full query",
+			query: `{"filter": {"AND": [{"EQ": {"state": "CA"}}, {"IN": {"person.org": ["A", "B"]}}]}, "sort": [{"key": "id", "order": "DESC"}], "page": {"limit": 10, "token": "abc"}}`,
+		},
+		{name: "unknown section", query: `{"where": {}}`, wantErr: "unsupported query section"},
+		{name: "two operators", query: `{"filter": {"EQ": {"a": 1}, "NEQ": {"b": 2}}}`, wantErr: "exactly one operator"},
+		{name: "unknown operator", query: `{"filter": {"LIKE": {"a": "x"}}}`, wantErr: "unsupported filter operator"},
+		{name: "EQ with object value", query: `{"filter": {"EQ": {"a": {"b": 1}}}}`, wantErr: "EQ value must be"},
+		{name: "EQ with two fields", query: `{"filter": {"EQ": {"a": 1, "b": 2}}}`, wantErr: "exactly one field"},
+		{name: "IN with scalar", query: `{"filter": {"IN": {"a": 1}}}`, wantErr: "IN value must be a non-empty array"},
+		{name: "AND with one child", query: `{"filter": {"AND": [{"EQ": {"a": 1}}]}}`, wantErr: "at least 2 filters"},
+		{name: "nested invalid child", query: `{"filter": {"OR": [{"EQ": {"a": 1}}, {"GT": {"b": [1]}}]}}`, wantErr: "GT value must be"},
+		{name: "sort bad order", query: `{"sort": [{"key": "id", "order": "UP"}]}`, wantErr: "must be 'ASC' or 'DESC'"},
+		{name: "sort missing key", query: `{"sort": [{"order": "ASC"}]}`, wantErr: "sort[0].key is required"},
+		{name: "page negative limit", query: `{"page": {"limit": -1}}`, wantErr: "page.limit must be a non-negative integer"},
+		{name: "page fractional limit", query: `{"page": {"limit": 1.5}}`, wantErr: "page.limit must be a non-negative integer"},
+		{name: "page unknown field", query: `{"page": {"offset": 10}}`, wantErr: "unsupported field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateQuery(parseQuery(t, tt.query))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQueryStateTool(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        QueryStateArgs
+		setupMock   func(*mocks.MockDaprClient)
+		wantErr     bool
+		wantContent string
+		wantCount   int
+		wantToken   string
+	}{
+		{
+			name: "single page",
+			args: QueryStateArgs{
+				StoreName: "statestore",
+				Query:     parseQuery(t, `{"filter": {"EQ": {"state": "CA"}}}`),
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("QueryStateAlpha1", mock.Anything, "statestore", `{"filter":{"EQ":{"state":"CA"}},"page":{}}`, mock.Anything).
+					Return(&client.QueryResponse{
+						Results: []client.QueryItem{{Key: "1", Value: []byte(`{"state":"CA"}`)}},
+					}, nil)
+			},
+			wantContent: "returned 1 result(s) across 1 page(s)",
+			wantCount:   1,
+		},
+		{
+			name: "follows continuation tokens up to maxPages",
+			args: QueryStateArgs{
+				StoreName: "statestore",
+				Query:     parseQuery(t, `{"page": {"limit": 1}}`),
+				Token:     "start",
+				MaxPages:  2,
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("QueryStateAlpha1", mock.Anything, "statestore", `{"page":{"limit":1,"token":"start"}}`, mock.Anything).
+					Return(&client.QueryResponse{Results: []client.QueryItem{{Key: "1"}}, Token: "t1"}, nil).Once()
+				m.On("QueryStateAlpha1", mock.Anything, "statestore", `{"page":{"limit":1,"token":"t1"}}`, mock.Anything).
+					Return(&client.QueryResponse{Results: []client.QueryItem{{Key: "2"}}, Token: "t2"}, nil).Once()
+			},
+			wantContent: "pass token 't2' to fetch the next page",
+			wantCount:   2,
+			wantToken:   "t2",
+		},
+		{
+			name: "invalid query is rejected before sending",
+			args: QueryStateArgs{
+				StoreName: "statestore",
+				Query:     parseQuery(t, `{"filter": {"LIKE": {"a": "b"}}}`),
+			},
+			setupMock:   func(m *mocks.MockDaprClient) {},
+			wantErr:     true,
+			wantContent: "query rejected before sending to store 'statestore'",
+		},
+		{
+			name: "maxPages out of range",
+			args: QueryStateArgs{
+				StoreName: "statestore",
+				Query:     map[string]any{},
+				MaxPages:  maxQueryPages + 1,
+			},
+			setupMock:   func(m *mocks.MockDaprClient) {},
+			wantErr:     true,
+			wantContent: "maxPages must be between 1 and 10",
+		},
+		{
+			name: "query failure",
+			args: QueryStateArgs{
+				StoreName: "statestore",
+				Query:     map[string]any{},
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("QueryStateAlpha1", mock.Anything, "statestore", mock.Anything, mock.Anything).
+					Return(nil, errors.New("query not supported"))
+			},
+			wantErr:     true,
+			wantContent: "dapr QueryStateAlpha1 failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(mocks.MockDaprClient)
+			tt.setupMock(mockClient)
+
+			stateClient = mockClient
+
+			result, structured, err := queryStateTool(context.Background(), &mcp.CallToolRequest{}, tt.args)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantErr, result.IsError)
+			if len(result.Content) > 0 {
+				textContent, ok := result.Content[0].(*mcp.TextContent)
+				assert.True(t, ok)
+				assert.Contains(t, textContent.Text, tt.wantContent)
+			}
+			if !tt.wantErr {
+				resultMap, ok := structured.(map[string]interface{})
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCount, resultMap["count"])
+				assert.Equal(t, tt.wantToken, resultMap["token"])
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestQueryStateToolDecodesValues(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	mockClient.On("QueryStateAlpha1", mock.Anything, "statestore", mock.Anything, mock.Anything).
+		Return(&client.QueryResponse{
+			Results: []client.QueryItem{
+				{Key: "1", Value: []byte(`{"id":9007199254740993}`)},
+				{Key: "2", Value: []byte("plain")},
+				{Key: "3", Value: []byte{0xff, 0x00}},
+			},
+		}, nil)
+	stateClient = mockClient
+
+	result, structured, err := queryStateTool(context.Background(), &mcp.CallToolRequest{}, QueryStateArgs{StoreName: "statestore", Query: map[string]any{}})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	results := structured.(map[string]interface{})["results"].([]QueryResult)
+	require.Len(t, results, 3)
+	assert.Equal(t, map[string]any{"id": json.Number("9007199254740993")}, results[0].Value)
+	assert.Equal(t, EncodingJSON, results[0].Encoding)
+	assert.Equal(t, "plain", results[1].Value)
+	assert.Equal(t, EncodingText, results[1].Encoding)
+	assert.Equal(t, "/wA=", results[2].Value)
+	assert.Equal(t, EncodingBase64, results[2].Encoding)
+	assert.Contains(t, testutil.ResultText(t, result), `- 1: {"id":9007199254740993}`)
+}
+
+func TestRegisterQueryTools(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil)
+
+	// Should not panic
+	RegisterQueryTools(server, mockClient)
+
+	assert.Equal(t, mockClient, stateClient)
+}
@@ -0,0 +1,389 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/dapr/dapr-mcp-server/pkg/auth"
+	"github.com/dapr/dapr-mcp-server/pkg/dryrun"
+	"github.com/dapr/dapr-mcp-server/pkg/metadata"
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+const (
+	snapshotVersion        = 1
+	defaultImportChunkSize = 50
+	maxImportChunkSize     = 500
+)
+
+// Snapshot is the portable document produced by export_state and consumed by import_state.
+type Snapshot struct {
+	Version    int            `json:"version" jsonschema:"The snapshot format version (currently 1)."`
+	StoreName  string         `json:"storeName" jsonschema:"The state store the snapshot was exported from."`
+	ExportedAt string         `json:"exportedAt,omitempty" jsonschema:"When the snapshot was exported (RFC 3339)."`
+	Items      []SnapshotItem `json:"items" jsonschema:"The exported keys and values."`
+}
+
+// SnapshotItem is a single key and value in a Snapshot.
+type SnapshotItem struct {
+	Key      string `json:"key" jsonschema:"The state key."`
+	Value    any    `json:"value" jsonschema:"The value, interpreted according to encoding."`
+	Encoding string `json:"encoding,omitempty" jsonschema:"How the value is encoded: 'text', 'json' or 'base64'."`
+}
+
+type ExportStateArgs struct {
+	StoreName string            `json:"storeName" jsonschema:"The name of the Dapr state store component to export from."`
+	Keys      []string          `json:"keys,omitempty" jsonschema:"The keys to export. Provide either Keys or Query."`
+	Query     map[string]any    `json:"query,omitempty" jsonschema:"A state query selecting the keys to export (stores with the QUERY_API capability only). Provide either Keys or Query."`
+	MaxPages  int               `json:"maxPages,omitempty" jsonschema:"Optional number of query pages to export (default 1, maximum 10)."`
+	Metadata  map[string]string `json:"metadata,omitempty" jsonschema:"Optional store-specific request metadata."`
+}
+
+type ImportStateArgs struct {
+	StoreName string   `json:"storeName,omitempty" jsonschema:"Optional target state store. Defaults to the snapshot's storeName."`
+	Snapshot  Snapshot `json:"snapshot" jsonschema:"The snapshot document returned by export_state."`
+	ChunkSize int      `json:"chunkSize,omitempty" jsonschema:"Optional number of keys written per transaction (default 50, maximum 500)."`
+	DryRun    bool     `json:"dryRun,omitempty" jsonschema:"If true, compare the snapshot with the target store and report what would change without writing."`
+}
+
+// ImportChange describes how import_state would change a single key.
+type ImportChange struct {
+	Key    string `json:"key"`
+	Action string `json:"action" jsonschema:"One of 'create', 'update' or 'unchanged', or 'write' when the current values could not be compared."`
+}
+
+func exportStateTool(ctx context.Context, req *mcp.CallToolRequest, args ExportStateArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "export_state")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "export_state"),
+		attribute.String("dapr.store", args.StoreName),
+	)
+
+	if (len(args.Keys) == 0) == (args.Query == nil) {
+		return invalidArgumentResult(errors.New("provide either keys or query for export_state"))
+	}
+
+	snapshot := Snapshot{
+		Version:    snapshotVersion,
+		StoreName:  args.StoreName,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Items:      make([]SnapshotItem, 0),
+	}
+	missing := make([]string, 0)
+	failed := make(map[string]string)
+	token := ""
+
+	addItem := func(key string, value []byte, meta map[string]string) {
+		decoded, encoding, err := decodeValue(value, meta[contentTypeMetadataKey], "")
+		if err != nil {
+			failed[key] = err.Error()
+			return
+		}
+		snapshot.Items = append(snapshot.Items, SnapshotItem{Key: key, Value: decoded, Encoding: encoding})
+	}
+
+	if len(args.Keys) > 0 {
+		if err := authorize(ctx, auth.StateRead, args.StoreName, args.Keys...); err != nil {
+			return accessDeniedResult(args.StoreName, err)
+		}
+
+		items, err := stateClient.GetBulkState(ctx, args.StoreName, args.Keys, args.Metadata, 0)
+		if err != nil {
+			return stateError("GetBulkState", err)
+		}
+		for _, item := range items {
+			switch {
+			case item.Error != "":
+				failed[item.Key] = item.Error
+			case !stored(item.Value, item.Etag):
+				missing = append(missing, item.Key)
+			default:
+				addItem(item.Key, item.Value, item.Metadata)
+			}
+		}
+	} else {
+		if err := authorizeStore(ctx, auth.StateRead, args.StoreName); err != nil {
+			return accessDeniedResult(args.StoreName, err)
+		}
+		maxPages := args.MaxPages
+		if maxPages == 0 {
+			maxPages = defaultQueryPages
+		}
+		if maxPages < 0 || maxPages > maxQueryPages {
+			return invalidArgumentResult(fmt.Errorf("maxPages must be between 1 and %d, got %d", maxQueryPages, args.MaxPages))
+		}
+		if err := validateQuery(args.Query); err != nil {
+			return invalidArgumentResult(fmt.Errorf("query rejected before sending to store '%s': %w", args.StoreName, err))
+		}
+		comp, err := metadata.FindComponent(ctx, stateClient, args.StoreName, "state.")
+		if err != nil {
+			return invalidArgumentResult(err)
+		}
+		if !comp.HasCapability(metadata.CapabilityQueryAPI) {
+			return invalidArgumentResult(fmt.Errorf("state store '%s' (%s) does not support queries; export by keys instead", args.StoreName, comp.Type))
+		}
+
+		var items []dapr.QueryItem
+		items, token, _, err = runQuery(ctx, args.StoreName, args.Query, "", maxPages, args.Metadata)
+		if err != nil {
+			return stateError("QueryStateAlpha1", err)
+		}
+		for _, item := range items {
+			if statePolicy.Authorize(auth.GetIdentity(ctx), auth.StateRead, args.StoreName, item.Key) != nil {
+				continue
+			}
+			if item.Error != "" {
+				failed[item.Key] = item.Error
+				continue
+			}
+			addItem(item.Key, item.Value, nil)
+		}
+	}
+
+	span.SetAttributes(attribute.Int("dapr.keys_count", len(snapshot.Items)))
+
+	document, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return invalidArgumentResult(fmt.Errorf("failed to encode snapshot: %w", err))
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Exported %d key(s) from state store '%s' (%d missing, %d failed).", len(snapshot.Items), args.StoreName, len(missing), len(failed))
+	if token != "" {
+		text.WriteString(" The query has more results; increase maxPages to export them.")
+	}
+	fmt.Fprintf(&text, " Snapshot:\n%s", document)
+	log.Printf("Exported %d key(s) from state store '%s'.", len(snapshot.Items), args.StoreName)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: text.String()}},
+	}, map[string]interface{}{
+		"snapshot": snapshot,
+		"missing":  missing,
+		"failed":   failed,
+		"token":    token,
+	}, nil
+}
+
+func importStateTool(ctx context.Context, req *mcp.CallToolRequest, args ImportStateArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "import_state")
+	defer span.End()
+
+	storeName := args.StoreName
+	if storeName == "" {
+		storeName = args.Snapshot.StoreName
+	}
+	span.SetAttributes(
+		attribute.String("dapr.operation", "import_state"),
+		attribute.String("dapr.store", storeName),
+		attribute.Int("dapr.keys_count", len(args.Snapshot.Items)),
+		attribute.Bool("dapr.dry_run", args.DryRun),
+	)
+
+	if storeName == "" {
+		return invalidArgumentResult(errors.New("storeName is required when the snapshot does not name a store"))
+	}
+	if args.Snapshot.Version != snapshotVersion {
+		return invalidArgumentResult(fmt.Errorf("unsupported snapshot version %d (expected %d)", args.Snapshot.Version, snapshotVersion))
+	}
+	if len(args.Snapshot.Items) == 0 {
+		return invalidArgumentResult(errors.New("the snapshot contains no items"))
+	}
+	chunkSize := args.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = defaultImportChunkSize
+	}
+	if chunkSize < 0 || chunkSize > maxImportChunkSize {
+		return invalidArgumentResult(fmt.Errorf("chunkSize must be between 1 and %d, got %d", maxImportChunkSize, args.ChunkSize))
+	}
+
+	keys := make([]string, 0, len(args.Snapshot.Items))
+	seen := make(map[string]bool, len(args.Snapshot.Items))
+	items := make([]*dapr.SetStateItem, 0, len(args.Snapshot.Items))
+	for _, item := range args.Snapshot.Items {
+		if item.Key == "" {
+			return invalidArgumentResult(errors.New("snapshot items must have a non-empty key"))
+		}
+		if seen[item.Key] {
+			return invalidArgumentResult(fmt.Errorf("snapshot contains key '%s' more than once", item.Key))
+		}
+		seen[item.Key] = true
+
+		data, contentType, err := encodeValue(item.Value, item.Encoding)
+		if err != nil {
+			return invalidArgumentResult(fmt.Errorf("item '%s': %w", item.Key, err))
+		}
+		keys = append(keys, item.Key)
+		items = append(items, &dapr.SetStateItem{
+			Key:      item.Key,
+			Value:    data,
+			Metadata: withContentType(nil, contentType),
+		})
+	}
+
+	if err := authorize(ctx, auth.StateWrite, storeName, keys...); err != nil {
+		return accessDeniedResult(storeName, err)
+	}
+
+	// Every chunk is written in a transaction, so a store without transactions
+	// is rejected before anything is read or written.
+	comp, err := metadata.FindComponent(ctx, stateClient, storeName, "state.")
+	if err != nil {
+		return invalidArgumentResult(err)
+	}
+	if !comp.HasCapability(metadata.CapabilityTransactional) {
+		return invalidArgumentResult(fmt.Errorf("state store '%s' (%s) does not support transactions, which import_state requires; use save_bulk_state instead", storeName, comp.Type))
+	}
+
+	if dryrun.Enabled(args.DryRun) {
+		// Comparing with the current values would reveal them, so callers that
+		// may not read every key only see the planned writes.
+		if err := authorize(ctx, auth.StateRead, storeName, keys...); err != nil {
+			return plannedWrites(storeName, items)
+		}
+		return diffSnapshot(ctx, storeName, items)
+	}
+
+	propagator := otel.GetTextMapPropagator()
+	meta := make(map[string]string)
+	propagator.Inject(ctx, propagation.MapCarrier(meta))
+
+	chunks := (len(items) + chunkSize - 1) / chunkSize
+	imported := 0
+	for chunk := 0; chunk < chunks; chunk++ {
+		end := min(imported+chunkSize, len(items))
+		ops := make([]*dapr.StateOperation, 0, end-imported)
+		for _, item := range items[imported:end] {
+			ops = append(ops, &dapr.StateOperation{Type: dapr.StateOperationTypeUpsert, Item: item})
+		}
+
+		if err := stateClient.ExecuteStateTransaction(ctx, storeName, meta, ops); err != nil {
+			log.Printf("Dapr ExecuteStateTransaction failed during import: %v", err)
+			toolErrorMessage := fmt.Sprintf("import into state store '%s' stopped at chunk %d of %d after %d key(s) were imported: %v. Earlier chunks were committed; re-run the import to retry the remaining keys.",
+				storeName, chunk+1, chunks, imported, err)
+			return toolcall.WithStatus(&mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
+				IsError: true,
+			}, err), map[string]interface{}{
+				"store_name":     storeName,
+				"keys_imported":  imported,
+				"chunks_applied": chunk,
+				"chunks_total":   chunks,
+				"pending_keys":   keys[imported:],
+			}, nil
+		}
+		imported = end
+	}
+
+	successMessage := fmt.Sprintf("Successfully imported %d key(s) into state store '%s' in %d transaction(s).", imported, storeName, chunks)
+	log.Println(successMessage)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: successMessage}},
+	}, map[string]interface{}{
+		"store_name":     storeName,
+		"keys_imported":  imported,
+		"chunks_applied": chunks,
+		"chunks_total":   chunks,
+	}, nil
+}
+
+// diffSnapshot compares the snapshot items with the target store without writing.
+func diffSnapshot(ctx context.Context, storeName string, items []*dapr.SetStateItem) (*mcp.CallToolResult, any, error) {
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, item.Key)
+	}
+
+	current, err := stateClient.GetBulkState(ctx, storeName, keys, nil, 0)
+	if err != nil {
+		return stateError("GetBulkState", err)
+	}
+	existing := make(map[string][]byte, len(current))
+	for _, item := range current {
+		if item.Error == "" && stored(item.Value, item.Etag) {
+			existing[item.Key] = item.Value
+		}
+	}
+
+	changes := make([]ImportChange, 0, len(items))
+	counts := map[string]int{"create": 0, "update": 0, "unchanged": 0}
+	var text strings.Builder
+	for _, item := range items {
+		action := "create"
+		if value, ok := existing[item.Key]; ok {
+			action = "update"
+			if sameValue(value, item.Value) {
+				action = "unchanged"
+			}
+		}
+		counts[action]++
+		changes = append(changes, ImportChange{Key: item.Key, Action: action})
+		fmt.Fprintf(&text, "\n- %s: %s", item.Key, action)
+	}
+
+	summary := fmt.Sprintf("Dry run: importing into state store '%s' would create %d, update %d and leave %d key(s) unchanged. Nothing was written.",
+		storeName, counts["create"], counts["update"], counts["unchanged"])
+	log.Println(summary)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: summary + text.String()}},
+	}, map[string]interface{}{
+		"store_name": storeName,
+		"dry_run":    true,
+		"create":     counts["create"],
+		"update":     counts["update"],
+		"unchanged":  counts["unchanged"],
+		"changes":    changes,
+	}, nil
+}
+
+// plannedWrites lists the keys an import would write without reading the
+// target store.
+func plannedWrites(storeName string, items []*dapr.SetStateItem) (*mcp.CallToolResult, any, error) {
+	changes := make([]ImportChange, 0, len(items))
+	var text strings.Builder
+	for _, item := range items {
+		changes = append(changes, ImportChange{Key: item.Key, Action: "write"})
+		fmt.Fprintf(&text, "\n- %s: write", item.Key)
+	}
+
+	summary := fmt.Sprintf("Dry run: importing into state store '%s' would write %d key(s). Current values were not compared because the state access policy does not allow reading every key. Nothing was written.",
+		storeName, len(items))
+	log.Println(summary)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: summary + text.String()}},
+	}, map[string]interface{}{
+		"store_name": storeName,
+		"dry_run":    true,
+		"write":      len(items),
+		"changes":    changes,
+	}, nil
+}
+
+// sameValue reports whether two stored values are equal, ignoring JSON formatting.
+func sameValue(a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var av, bv any
+	if unmarshalJSON(a, &av) != nil || unmarshalJSON(b, &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
@@ -0,0 +1,240 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+)
+
+func TestExportStateTool(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        ExportStateArgs
+		setupMock   func(*mocks.MockDaprClient)
+		wantErr     bool
+		wantContent string
+		wantItems   []SnapshotItem
+	}{
+		{
+			name: "export by keys",
+			args: ExportStateArgs{StoreName: "statestore", Keys: []string{"a", "b", "c", "d"}},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("GetBulkState", mock.Anything, "statestore", []string{"a", "b", "c", "d"}, mock.Anything, int32(0)).
+					Return([]*client.BulkStateItem{
+						{Key: "a", Value: []byte(`{"n":1}`)},
+						{Key: "b", Value: []byte("plain")},
+						{Key: "c"},
+						{Key: "d", Value: []byte{}, Etag: "2"},
+					}, nil)
+			},
+			wantContent: "Exported 3 key(s) from state store 'statestore' (1 missing, 0 failed).",
+			wantItems: []SnapshotItem{
+				{Key: "a", Value: map[string]any{"n": json.Number("1")}, Encoding: EncodingJSON},
+				{Key: "b", Value: "plain", Encoding: EncodingText},
+				{Key: "d", Value: "", Encoding: EncodingText},
+			},
+		},
+		{
+			name: "export by query",
+			args: ExportStateArgs{StoreName: "statestore", Query: map[string]any{}},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("GetMetadata", mock.Anything).Return(&client.GetMetadataResponse{
+					RegisteredComponents: []*client.MetadataRegisteredComponents{
+						{Name: "statestore", Type: "state.redis", Capabilities: []string{"QUERY_API"}},
+					},
+				}, nil)
+				m.On("QueryStateAlpha1", mock.Anything, "statestore", `{"page":{}}`, mock.Anything).
+					Return(&client.QueryResponse{Results: []client.QueryItem{{Key: "a", Value: []byte("x")}}}, nil)
+			},
+			wantContent: "Exported 1 key(s)",
+			wantItems:   []SnapshotItem{{Key: "a", Value: "x", Encoding: EncodingText}},
+		},
+		{
+			name: "query on store without query support",
+			args: ExportStateArgs{StoreName: "statestore", Query: map[string]any{}},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("GetMetadata", mock.Anything).Return(&client.GetMetadataResponse{
+					RegisteredComponents: []*client.MetadataRegisteredComponents{
+						{Name: "statestore", Type: "state.in-memory"},
+					},
+				}, nil)
+			},
+			wantErr:     true,
+			wantContent: "does not support queries",
+		},
+		{
+			name:        "keys and query together",
+			args:        ExportStateArgs{StoreName: "statestore", Keys: []string{"a"}, Query: map[string]any{}},
+			setupMock:   func(m *mocks.MockDaprClient) {},
+			wantErr:     true,
+			wantContent: "provide either keys or query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(mocks.MockDaprClient)
+			tt.setupMock(mockClient)
+			stateClient = mockClient
+
+			result, structured, err := exportStateTool(context.Background(), &mcp.CallToolRequest{}, tt.args)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantErr, result.IsError)
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, tt.wantContent)
+			if !tt.wantErr {
+				snapshot := structured.(map[string]interface{})["snapshot"].(Snapshot)
+				assert.Equal(t, snapshotVersion, snapshot.Version)
+				assert.Equal(t, tt.wantItems, snapshot.Items)
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportStateTool(t *testing.T) {
+	snapshot := Snapshot{
+		Version:   snapshotVersion,
+		StoreName: "statestore",
+		Items: []SnapshotItem{
+			{Key: "a", Value: map[string]any{"n": 1.0}, Encoding: EncodingJSON},
+			{Key: "b", Value: "plain", Encoding: EncodingText},
+			{Key: "c", Value: "AAE=", Encoding: EncodingBase64},
+		},
+	}
+
+	transactional := func(m *mocks.MockDaprClient, storeName string) {
+		m.On("GetMetadata", mock.Anything).Return(&client.GetMetadataResponse{
+			RegisteredComponents: []*client.MetadataRegisteredComponents{
+				{Name: storeName, Type: "state.redis", Capabilities: []string{"TRANSACTIONAL"}},
+			},
+		}, nil)
+	}
+
+	tests := []struct {
+		name        string
+		args        ImportStateArgs
+		setupMock   func(*mocks.MockDaprClient)
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "imports in chunks",
+			args: ImportStateArgs{StoreName: "other", Snapshot: snapshot, ChunkSize: 2},
+			setupMock: func(m *mocks.MockDaprClient) {
+				transactional(m, "other")
+				m.On("ExecuteStateTransaction", mock.Anything, "other", mock.Anything, mock.MatchedBy(func(ops []*client.StateOperation) bool {
+					return len(ops) == 2 && ops[0].Item.Key == "a" && string(ops[0].Item.Value) == `{"n":1}` &&
+						ops[0].Item.Metadata["contentType"] == "application/json"
+				})).Return(nil).Once()
+				m.On("ExecuteStateTransaction", mock.Anything, "other", mock.Anything, mock.MatchedBy(func(ops []*client.StateOperation) bool {
+					return len(ops) == 1 && ops[0].Item.Key == "c" && string(ops[0].Item.Value) == "\x00\x01"
+				})).Return(nil).Once()
+			},
+			wantContent: "Successfully imported 3 key(s) into state store 'other' in 2 transaction(s).",
+		},
+		{
+			name: "stops at failing chunk",
+			args: ImportStateArgs{Snapshot: snapshot, ChunkSize: 2},
+			setupMock: func(m *mocks.MockDaprClient) {
+				transactional(m, "statestore")
+				m.On("ExecuteStateTransaction", mock.Anything, "statestore", mock.Anything, mock.Anything).
+					Return(nil).Once()
+				m.On("ExecuteStateTransaction", mock.Anything, "statestore", mock.Anything, mock.Anything).
+					Return(errors.New("store unavailable")).Once()
+			},
+			wantErr:     true,
+			wantContent: "stopped at chunk 2 of 2 after 2 key(s) were imported",
+		},
+		{
+			name: "dry run reports diff",
+			args: ImportStateArgs{Snapshot: snapshot, DryRun: true},
+			setupMock: func(m *mocks.MockDaprClient) {
+				transactional(m, "statestore")
+				m.On("GetBulkState", mock.Anything, "statestore", []string{"a", "b", "c"}, mock.Anything, int32(0)).
+					Return([]*client.BulkStateItem{
+						{Key: "a", Value: []byte(`{ "n": 1 }`)},
+						{Key: "b", Value: []byte("changed")},
+						{Key: "c"},
+					}, nil)
+			},
+			wantContent: "would create 1, update 1 and leave 1 key(s) unchanged",
+		},
+		{
+			name: "store without transactions",
+			args: ImportStateArgs{Snapshot: snapshot, DryRun: true},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("GetMetadata", mock.Anything).Return(&client.GetMetadataResponse{
+					RegisteredComponents: []*client.MetadataRegisteredComponents{
+						{Name: "statestore", Type: "state.in-memory"},
+					},
+				}, nil)
+			},
+			wantErr:     true,
+			wantContent: "does not support transactions",
+		},
+		{
+			name:        "unsupported version",
+			args:        ImportStateArgs{Snapshot: Snapshot{Version: 2, StoreName: "statestore", Items: snapshot.Items}},
+			setupMock:   func(m *mocks.MockDaprClient) {},
+			wantErr:     true,
+			wantContent: "unsupported snapshot version",
+		},
+		{
+			name: "duplicate keys",
+			args: ImportStateArgs{Snapshot: Snapshot{Version: snapshotVersion, StoreName: "statestore", Items: []SnapshotItem{
+				{Key: "a", Value: "1"}, {Key: "a", Value: "2"},
+			}}},
+			setupMock:   func(m *mocks.MockDaprClient) {},
+			wantErr:     true,
+			wantContent: "more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(mocks.MockDaprClient)
+			tt.setupMock(mockClient)
+			stateClient = mockClient
+
+			result, _, err := importStateTool(context.Background(), &mcp.CallToolRequest{}, tt.args)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantErr, result.IsError)
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, tt.wantContent)
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportStateToolKeepsLargeIntegers(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	mockClient.On("GetMetadata", mock.Anything).Return(&client.GetMetadataResponse{
+		RegisteredComponents: []*client.MetadataRegisteredComponents{
+			{Name: "statestore", Type: "state.redis", Capabilities: []string{"TRANSACTIONAL"}},
+		},
+	}, nil)
+	mockClient.On("ExecuteStateTransaction", mock.Anything, "statestore", mock.Anything, mock.MatchedBy(func(ops []*client.StateOperation) bool {
+		return len(ops) == 1 && string(ops[0].Item.Value) == `{"n":9007199254740993}`
+	})).Return(nil)
+	session := connect(t, mockClient)
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "import_state",
+		Arguments: json.RawMessage(`{"snapshot":{"version":1,"storeName":"statestore","items":[{"key":"a","value":{"n":9007199254740993},"encoding":"json"}]}}`),
+	})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	mockClient.AssertExpectations(t)
+}
@@ -4,52 +4,246 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	dapr "github.com/dapr/go-sdk/client"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dapr/dapr-mcp-server/pkg/auth"
+	"github.com/dapr/dapr-mcp-server/pkg/dryrun"
+	"github.com/dapr/dapr-mcp-server/pkg/metadata"
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
 )
 
+// ErrCodeETagMismatch is returned in the structured result when an
+// optimistic concurrency check fails and the caller should re-read and retry.
+const ErrCodeETagMismatch = "ETAG_MISMATCH"
+
+// ttlMetadataKey is the request metadata key Dapr state stores read the expiry from.
+const ttlMetadataKey = "ttlInSeconds"
+
 // StateClient defines the interface for state operations.
 // This allows for easier testing with mocks.
 type StateClient interface {
+	metadata.MetadataClient
 	SaveState(ctx context.Context, storeName, key string, data []byte, meta map[string]string, so ...dapr.StateOption) error
+	SaveStateWithETag(ctx context.Context, storeName, key string, data []byte, etag string, meta map[string]string, so ...dapr.StateOption) error
 	GetState(ctx context.Context, storeName, key string, meta map[string]string) (*dapr.StateItem, error)
 	DeleteState(ctx context.Context, storeName, key string, meta map[string]string) error
+	DeleteStateWithETag(ctx context.Context, storeName, key string, etag *dapr.ETag, meta map[string]string, opts *dapr.StateOptions) error
 	ExecuteStateTransaction(ctx context.Context, storeName string, meta map[string]string, ops []*dapr.StateOperation) error
+	GetBulkState(ctx context.Context, storeName string, keys []string, meta map[string]string, parallelism int32) ([]*dapr.BulkStateItem, error)
+	SaveBulkState(ctx context.Context, storeName string, items ...*dapr.SetStateItem) error
+	QueryStateAlpha1(ctx context.Context, storeName, query string, meta map[string]string) (*dapr.QueryResponse, error)
 }
 
 type SaveStateArgs struct {
-	StoreName string `json:"storeName" jsonschema:"The name of the Dapr state store component (e.g., 'statestore')."`
-	Key       string `json:"key" jsonschema:"The key under which to save the state."`
-	Value     string `json:"value" jsonschema:"The value (typically a JSON string) to save."`
+	StoreName   string            `json:"storeName" jsonschema:"The name of the Dapr state store component (e.g., 'statestore')."`
+	Key         string            `json:"key" jsonschema:"The key under which to save the state."`
+	Value       any               `json:"value" jsonschema:"The value to save. Strings are stored as-is; objects, arrays, numbers and booleans are stored as JSON."`
+	Encoding    string            `json:"encoding,omitempty" jsonschema:"Optional value encoding: 'text' (string stored as-is), 'json' (any JSON value, stored with content type application/json) or 'base64' (binary payload given as a base64 string)."`
+	ETag        string            `json:"etag,omitempty" jsonschema:"Optional ETag returned by get_state. The save is rejected with ETAG_MISMATCH if the stored value changed since it was read."`
+	Concurrency string            `json:"concurrency,omitempty" jsonschema:"Optional concurrency mode: 'first-write' or 'last-write'. Defaults to the store's behavior."`
+	Consistency string            `json:"consistency,omitempty" jsonschema:"Optional consistency level: 'strong' or 'eventual'. Defaults to the store's behavior."`
+	TTLSeconds  int               `json:"ttlSeconds,omitempty" jsonschema:"Optional time-to-live in seconds after which the key expires. Use -1 to never expire. Requires a store with the TTL capability."`
+	Metadata    map[string]string `json:"metadata,omitempty" jsonschema:"Optional store-specific request metadata (e.g., 'contentType' or 'partitionKey')."`
 }
 
 type GetStateArgs struct {
 	StoreName string `json:"storeName" jsonschema:"The name of the Dapr state store component (e.g., 'statestore')."`
 	Key       string `json:"key" jsonschema:"The key whose value should be retrieved."`
+	Encoding  string `json:"encoding,omitempty" jsonschema:"Optional encoding for the returned value: 'text', 'json' or 'base64'. By default JSON values are returned as structured JSON and binary values as base64."`
 }
 
 type DeleteStateArgs struct {
-	StoreName string `json:"storeName" jsonschema:"The name of the Dapr state store component (e.g., 'statestore')."`
-	Key       string `json:"key" jsonschema:"The key to delete."`
+	StoreName   string            `json:"storeName" jsonschema:"The name of the Dapr state store component (e.g., 'statestore')."`
+	Key         string            `json:"key" jsonschema:"The key to delete."`
+	ETag        string            `json:"etag,omitempty" jsonschema:"Optional ETag returned by get_state. The delete is rejected with ETAG_MISMATCH if the stored value changed since it was read."`
+	Concurrency string            `json:"concurrency,omitempty" jsonschema:"Optional concurrency mode: 'first-write' or 'last-write'. Defaults to the store's behavior."`
+	Consistency string            `json:"consistency,omitempty" jsonschema:"Optional consistency level: 'strong' or 'eventual'. Defaults to the store's behavior."`
+	Metadata    map[string]string `json:"metadata,omitempty" jsonschema:"Optional store-specific request metadata (e.g., 'partitionKey')."`
+	DryRun      bool              `json:"dryRun,omitempty" jsonschema:"If true, validate the request and describe what would be sent to the sidecar without deleting anything."`
 }
 
 type TransactionItem struct {
-	Key      string `json:"key" jsonschema:"The state key."`
-	Value    string `json:"value" jsonschema:"The value to set (or empty for delete)."`
-	IsDelete bool   `json:"isDelete" jsonschema:"Set to true to delete the key, false to save/update it."`
+	Key         string            `json:"key" jsonschema:"The state key."`
+	Value       any               `json:"value" jsonschema:"The value to set (or empty for delete). Strings are stored as-is; objects, arrays, numbers and booleans are stored as JSON."`
+	Encoding    string            `json:"encoding,omitempty" jsonschema:"Optional value encoding: 'text', 'json' or 'base64'."`
+	IsDelete    bool              `json:"isDelete" jsonschema:"Set to true to delete the key, false to save/update it."`
+	ETag        string            `json:"etag,omitempty" jsonschema:"Optional ETag returned by get_state. The whole transaction is rejected with ETAG_MISMATCH if the stored value changed since it was read."`
+	Concurrency string            `json:"concurrency,omitempty" jsonschema:"Optional concurrency mode for this operation: 'first-write' or 'last-write'."`
+	Consistency string            `json:"consistency,omitempty" jsonschema:"Optional consistency level for this operation: 'strong' or 'eventual'."`
+	TTLSeconds  int               `json:"ttlSeconds,omitempty" jsonschema:"Optional time-to-live in seconds for this upsert. Use -1 to never expire. Requires a store with the TTL capability."`
+	Metadata    map[string]string `json:"metadata,omitempty" jsonschema:"Optional store-specific metadata for this operation (e.g., 'contentType')."`
 }
 
 type ExecuteTransactionArgs struct {
 	StoreName string            `json:"storeName" jsonschema:"The name of the Dapr state store component."`
 	Items     []TransactionItem `json:"items" jsonschema:"A list of save and/or delete operations to execute atomically."`
+	Metadata  map[string]string `json:"metadata,omitempty" jsonschema:"Optional store-specific metadata for the whole transaction (e.g., 'partitionKey')."`
+	DryRun    bool              `json:"dryRun,omitempty" jsonschema:"If true, validate the request and describe what would be sent to the sidecar without executing it."`
+}
+
+type GetBulkStateArgs struct {
+	StoreName   string   `json:"storeName" jsonschema:"The name of the Dapr state store component (e.g., 'statestore')."`
+	Keys        []string `json:"keys" jsonschema:"The list of keys whose values should be retrieved."`
+	Parallelism int32    `json:"parallelism,omitempty" jsonschema:"Optional number of keys the sidecar fetches in parallel. 0 uses the sidecar default."`
+	Encoding    string   `json:"encoding,omitempty" jsonschema:"Optional encoding for the returned values: 'text', 'json' or 'base64'. By default JSON values are returned as structured JSON and binary values as base64."`
+}
+
+type BulkSaveItem struct {
+	Key        string            `json:"key" jsonschema:"The key under which to save the state."`
+	Value      any               `json:"value" jsonschema:"The value to save. Strings are stored as-is; objects, arrays, numbers and booleans are stored as JSON."`
+	Encoding   string            `json:"encoding,omitempty" jsonschema:"Optional value encoding: 'text', 'json' or 'base64'."`
+	TTLSeconds int               `json:"ttlSeconds,omitempty" jsonschema:"Optional time-to-live in seconds after which the key expires. Use -1 to never expire. Requires a store with the TTL capability."`
+	Metadata   map[string]string `json:"metadata,omitempty" jsonschema:"Optional store-specific metadata for this item (e.g., 'contentType')."`
+}
+
+type SaveBulkStateArgs struct {
+	StoreName string         `json:"storeName" jsonschema:"The name of the Dapr state store component (e.g., 'statestore')."`
+	Items     []BulkSaveItem `json:"items" jsonschema:"The list of key-value pairs to save."`
+}
+
+// BulkStateResult reports the outcome for a single key of get_bulk_state.
+type BulkStateResult struct {
+	Key      string `json:"key"`
+	Value    any    `json:"value,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Etag     string `json:"etag,omitempty"`
+	Found    bool   `json:"found"`
+	Error    string `json:"error,omitempty"`
+}
+
+// BulkSaveResult reports the outcome for a single key of save_bulk_state.
+type BulkSaveResult struct {
+	Key   string `json:"key"`
+	Saved bool   `json:"saved"`
+	Error string `json:"error,omitempty"`
 }
 
 var stateClient StateClient
 
+// parseStateOptions converts the concurrency and consistency arguments into
+// SDK state options. It returns nil when neither is set so the store default applies.
+func parseStateOptions(concurrency, consistency string) (*dapr.StateOptions, error) {
+	if concurrency == "" && consistency == "" {
+		return nil, nil
+	}
+
+	opts := &dapr.StateOptions{}
+	switch strings.ToLower(concurrency) {
+	case "":
+	case dapr.FirstWriteType:
+		opts.Concurrency = dapr.StateConcurrencyFirstWrite
+	case dapr.LastWriteType:
+		opts.Concurrency = dapr.StateConcurrencyLastWrite
+	default:
+		return nil, fmt.Errorf("invalid concurrency %q: must be '%s' or '%s'", concurrency, dapr.FirstWriteType, dapr.LastWriteType)
+	}
+	switch strings.ToLower(consistency) {
+	case "":
+	case dapr.StrongType:
+		opts.Consistency = dapr.StateConsistencyStrong
+	case dapr.EventualType:
+		opts.Consistency = dapr.StateConsistencyEventual
+	default:
+		return nil, fmt.Errorf("invalid consistency %q: must be '%s' or '%s'", consistency, dapr.StrongType, dapr.EventualType)
+	}
+	return opts, nil
+}
+
+// isETagMismatch reports whether a sidecar error was caused by a failed ETag check.
+// The sidecar maps ETag mismatches to codes.Aborted for single-key operations and
+// reports them in the error message for transactions.
+func isETagMismatch(err error) bool {
+	if st, ok := status.FromError(err); ok && st.Code() == codes.Aborted {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "etag mismatch")
+}
+
+// etagConflictResult builds the tool error returned when an ETag check fails.
+func etagConflictResult(storeName string, keys []string, err error) (*mcp.CallToolResult, any, error) {
+	message := fmt.Sprintf("%s: the ETag for key(s) '%s' in state store '%s' no longer matches the stored value. Re-read the key(s) with get_state and retry with the new ETag. Dapr error: %v",
+		ErrCodeETagMismatch, strings.Join(keys, "', '"), storeName, err)
+	log.Println(message)
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message}},
+		IsError: true,
+	}, map[string]interface{}{
+		"error_code": ErrCodeETagMismatch,
+		"store_name": storeName,
+		"keys":       keys,
+	}, nil
+}
+
+// buildWriteMetadata merges the caller's request metadata with ttlSeconds and
+// reports whether the request asks the store to expire the key.
+func buildWriteMetadata(meta map[string]string, ttlSeconds int) (map[string]string, bool, error) {
+	if ttlSeconds < -1 {
+		return nil, false, fmt.Errorf("invalid ttlSeconds %d: must be a positive number of seconds or -1 to never expire", ttlSeconds)
+	}
+	if len(meta) == 0 && ttlSeconds == 0 {
+		return nil, false, nil
+	}
+
+	merged := make(map[string]string, len(meta)+1)
+	for k, v := range meta {
+		merged[k] = v
+	}
+	if ttlSeconds != 0 {
+		ttl := fmt.Sprintf("%d", ttlSeconds)
+		if existing, ok := merged[ttlMetadataKey]; ok && existing != ttl {
+			return nil, false, fmt.Errorf("ttlSeconds %d conflicts with metadata %s=%q", ttlSeconds, ttlMetadataKey, existing)
+		}
+		merged[ttlMetadataKey] = ttl
+	}
+	_, hasTTL := merged[ttlMetadataKey]
+	return merged, hasTTL, nil
+}
+
+// checkTTLSupport fails when the store does not report the TTL capability,
+// so an expiry is never silently ignored by the sidecar.
+func checkTTLSupport(ctx context.Context, storeName string) error {
+	comp, err := metadata.FindComponent(ctx, stateClient, storeName, "state.")
+	if err != nil {
+		return fmt.Errorf("unable to verify TTL support: %w", err)
+	}
+	if !comp.HasCapability(metadata.CapabilityTTL) {
+		return fmt.Errorf("state store '%s' (%s) does not support TTL; remove ttlSeconds/%s or use a store with the TTL capability", storeName, comp.Type, ttlMetadataKey)
+	}
+	return nil
+}
+
+// stateError reports a failed sidecar call, recording its gRPC status in the
+// result.
+func stateError(operation string, err error) (*mcp.CallToolResult, any, error) {
+	log.Printf("Dapr %s failed: %v", operation, err)
+	return toolcall.WithStatus(&mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("dapr %s failed: %v", operation, err)}},
+		IsError: true,
+	}, err), nil, nil
+}
+
+// stored reports whether a read found the key. Stores return an ETag for every
+// key they hold, so a key saved with an empty value is told apart from a
+// missing one by its ETag.
+func stored(value []byte, etag string) bool {
+	return len(value) > 0 || etag != ""
+}
+
+func invalidArgumentResult(err error) (*mcp.CallToolResult, any, error) {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}},
+		IsError: true,
+	}, nil, nil
+}
+
 func saveStateTool(ctx context.Context, req *mcp.CallToolRequest, args SaveStateArgs) (*mcp.CallToolResult, any, error) {
 	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "save_state")
 	defer span.End()
@@ -59,23 +253,55 @@ func saveStateTool(ctx context.Context, req *mcp.CallToolRequest, args SaveState
 		attribute.String("dapr.key", args.Key),
 	)
 
-	data := []byte(args.Value)
+	if err := authorize(ctx, auth.StateWrite, args.StoreName, args.Key); err != nil {
+		return accessDeniedResult(args.StoreName, err)
+	}
+
+	data, contentType, err := encodeValue(args.Value, args.Encoding)
+	if err != nil {
+		return invalidArgumentResult(err)
+	}
 
-	var err error
+	stateOpts, err := parseStateOptions(args.Concurrency, args.Consistency)
+	if err != nil {
+		return invalidArgumentResult(err)
+	}
+	meta, hasTTL, err := buildWriteMetadata(args.Metadata, args.TTLSeconds)
+	if err != nil {
+		return invalidArgumentResult(err)
+	}
+	meta = withContentType(meta, contentType)
+	if hasTTL {
+		if err := checkTTLSupport(ctx, args.StoreName); err != nil {
+			return invalidArgumentResult(err)
+		}
+	}
+	var so []dapr.StateOption
+	if stateOpts != nil {
+		so = append(so, dapr.WithConcurrency(stateOpts.Concurrency), dapr.WithConsistency(stateOpts.Consistency))
+	}
 
-	if err = stateClient.SaveState(ctx, args.StoreName, args.Key, data, nil); err == nil {
+	if args.ETag != "" {
+		err = stateClient.SaveStateWithETag(ctx, args.StoreName, args.Key, data, args.ETag, meta, so...)
+	} else {
+		err = stateClient.SaveState(ctx, args.StoreName, args.Key, data, meta, so...)
+	}
+	if err == nil {
 		successMessage := fmt.Sprintf("Successfully saved key '%s' to state store '%s'.", args.Key, args.StoreName)
 		log.Println(successMessage)
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: successMessage}},
 		}, map[string]string{"key_saved": args.Key, "store_name": args.StoreName}, nil
 	}
+	if args.ETag != "" && isETagMismatch(err) {
+		return etagConflictResult(args.StoreName, []string{args.Key}, err)
+	}
 	toolErrorMessage := fmt.Errorf("failed to save state to store '%s'. Final error: %v", args.StoreName, err).Error()
 
-	return &mcp.CallToolResult{
+	return toolcall.WithStatus(&mcp.CallToolResult{
 		Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
 		IsError: true,
-	}, nil, nil
+	}, err), nil, nil
 }
 
 func getStateTool(ctx context.Context, req *mcp.CallToolRequest, args GetStateArgs) (*mcp.CallToolResult, any, error) {
@@ -87,29 +313,35 @@ func getStateTool(ctx context.Context, req *mcp.CallToolRequest, args GetStateAr
 		attribute.String("dapr.key", args.Key),
 	)
 
+	if err := authorize(ctx, auth.StateRead, args.StoreName, args.Key); err != nil {
+		return accessDeniedResult(args.StoreName, err)
+	}
+
 	item, err := stateClient.GetState(ctx, args.StoreName, args.Key, nil)
 	if err != nil {
-		log.Printf("Dapr GetState failed: %v", err)
-		toolErrorMessage := fmt.Errorf("dapr GetState failed: %v", err).Error()
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
-			IsError: true,
-		}, nil, nil
+		return stateError("GetState", err)
 	}
 
-	result := string(item.Value)
-	log.Println(result)
-
-	var structuredResult map[string]string
+	var result string
+	var structuredResult map[string]interface{}
 
-	if result == "" {
+	if !stored(item.Value, item.Etag) {
 		result = fmt.Sprintf("Key '%s' not found in state store '%s'.", args.Key, args.StoreName)
 		structuredResult = nil
 	} else {
-		result = fmt.Sprintf("Retrieved key '%s' from '%s'. Value:\n%s", args.Key, args.StoreName, result)
-		structuredResult = map[string]string{
-			"key":   args.Key,
-			"value": string(item.Value),
+		value, encoding, err := decodeValue(item.Value, item.Metadata[contentTypeMetadataKey], args.Encoding)
+		if err != nil {
+			return invalidArgumentResult(fmt.Errorf("key '%s' in store '%s': %w", args.Key, args.StoreName, err))
+		}
+		result = fmt.Sprintf("Retrieved key '%s' from '%s'. Value:\n%s", args.Key, args.StoreName, displayValue(value, encoding))
+		structuredResult = map[string]interface{}{
+			"key":      args.Key,
+			"value":    value,
+			"encoding": encoding,
+		}
+		if item.Etag != "" {
+			result += fmt.Sprintf("\nETag: %s", item.Etag)
+			structuredResult["etag"] = item.Etag
 		}
 	}
 
@@ -129,13 +361,33 @@ func deleteStateTool(ctx context.Context, req *mcp.CallToolRequest, args DeleteS
 		attribute.String("dapr.key", args.Key),
 	)
 
-	if err := stateClient.DeleteState(ctx, args.StoreName, args.Key, nil); err != nil {
-		log.Printf("Dapr DeleteState failed: %v", err)
-		toolErrorMessage := fmt.Errorf("dapr DeleteState failed: %v", err).Error()
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
-			IsError: true,
-		}, nil, nil
+	if err := authorize(ctx, auth.StateDelete, args.StoreName, args.Key); err != nil {
+		return accessDeniedResult(args.StoreName, err)
+	}
+
+	stateOpts, err := parseStateOptions(args.Concurrency, args.Consistency)
+	if err != nil {
+		return invalidArgumentResult(err)
+	}
+
+	if dryrun.Enabled(args.DryRun) {
+		return planDeleteState(ctx, args, stateOpts)
+	}
+
+	if args.ETag != "" || stateOpts != nil {
+		var etag *dapr.ETag
+		if args.ETag != "" {
+			etag = &dapr.ETag{Value: args.ETag}
+		}
+		err = stateClient.DeleteStateWithETag(ctx, args.StoreName, args.Key, etag, args.Metadata, stateOpts)
+	} else {
+		err = stateClient.DeleteState(ctx, args.StoreName, args.Key, args.Metadata)
+	}
+	if err != nil {
+		if args.ETag != "" && isETagMismatch(err) {
+			return etagConflictResult(args.StoreName, []string{args.Key}, err)
+		}
+		return stateError("DeleteState", err)
 	}
 
 	successMessage := fmt.Sprintf("Successfully deleted key '%s' from state store '%s'.", args.Key, args.StoreName)
@@ -155,11 +407,26 @@ func executeTransactionTool(ctx context.Context, req *mcp.CallToolRequest, args
 		attribute.Int("dapr.operations_count", len(args.Items)),
 	)
 
+	for _, item := range args.Items {
+		op := auth.StateWrite
+		if item.IsDelete {
+			op = auth.StateDelete
+		}
+		if err := authorize(ctx, op, args.StoreName, item.Key); err != nil {
+			return accessDeniedResult(args.StoreName, err)
+		}
+	}
+
 	propagator := otel.GetTextMapPropagator()
 	meta := make(map[string]string)
+	for k, v := range args.Metadata {
+		meta[k] = v
+	}
 	propagator.Inject(ctx, propagation.MapCarrier(meta))
+	_, needsTTL := meta[ttlMetadataKey]
 
 	ops := make([]*dapr.StateOperation, 0, len(args.Items))
+	var etagKeys []string
 
 	for _, item := range args.Items {
 		var opType dapr.OperationType
@@ -170,37 +437,222 @@ func executeTransactionTool(ctx context.Context, req *mcp.CallToolRequest, args
 			setItem = &dapr.SetStateItem{Key: item.Key}
 		} else {
 			opType = dapr.StateOperationTypeUpsert
+			data, contentType, err := encodeValue(item.Value, item.Encoding)
+			if err != nil {
+				return invalidArgumentResult(fmt.Errorf("item '%s': %w", item.Key, err))
+			}
 			setItem = &dapr.SetStateItem{
-				Key:   item.Key,
-				Value: []byte(item.Value),
+				Key:      item.Key,
+				Value:    data,
+				Metadata: withContentType(nil, contentType),
 			}
 		}
 
+		stateOpts, err := parseStateOptions(item.Concurrency, item.Consistency)
+		if err != nil {
+			return invalidArgumentResult(fmt.Errorf("item '%s': %w", item.Key, err))
+		}
+		setItem.Options = stateOpts
+		if item.IsDelete && item.TTLSeconds != 0 {
+			return invalidArgumentResult(fmt.Errorf("item '%s': ttlSeconds cannot be set on a delete operation", item.Key))
+		}
+		itemMeta, hasTTL, err := buildWriteMetadata(item.Metadata, item.TTLSeconds)
+		if err != nil {
+			return invalidArgumentResult(fmt.Errorf("item '%s': %w", item.Key, err))
+		}
+		setItem.Metadata = withContentType(itemMeta, setItem.Metadata[contentTypeMetadataKey])
+		needsTTL = needsTTL || hasTTL
+		if item.ETag != "" {
+			setItem.Etag = &dapr.ETag{Value: item.ETag}
+			etagKeys = append(etagKeys, item.Key)
+		}
+
 		ops = append(ops, &dapr.StateOperation{
 			Type: opType,
 			Item: setItem,
 		})
 	}
 
+	if needsTTL {
+		if err := checkTTLSupport(ctx, args.StoreName); err != nil {
+			return invalidArgumentResult(err)
+		}
+	}
+
+	if dryrun.Enabled(args.DryRun) {
+		return planTransaction(ctx, args, meta, ops)
+	}
+
 	if err := stateClient.ExecuteStateTransaction(ctx, args.StoreName, meta, ops); err != nil {
-		log.Printf("Dapr ExecuteStateTransaction failed: %v", err)
-		toolErrorMessage := fmt.Errorf("dapr ExecuteStateTransaction failed: %v", err).Error()
+		if len(etagKeys) > 0 && isETagMismatch(err) {
+			return etagConflictResult(args.StoreName, etagKeys, err)
+		}
+		return stateError("ExecuteStateTransaction", err)
+	}
+
+	successMessage := fmt.Sprintf("Successfully executed %d state operations in a transaction on store '%s'.", len(args.Items), args.StoreName)
+	log.Println(successMessage)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: successMessage}},
+	}, map[string]interface{}{"operations_executed": len(args.Items), "store_name": args.StoreName}, nil
+}
+
+func getBulkStateTool(ctx context.Context, req *mcp.CallToolRequest, args GetBulkStateArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "get_bulk_state")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "get_bulk_state"),
+		attribute.String("dapr.store", args.StoreName),
+		attribute.Int("dapr.keys_count", len(args.Keys)),
+		attribute.Int("dapr.parallelism", int(args.Parallelism)),
+	)
+
+	if len(args.Keys) == 0 {
 		return &mcp.CallToolResult{
-			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
+			Content: []mcp.Content{&mcp.TextContent{Text: "at least one key is required for get_bulk_state"}},
+			IsError: true,
+		}, nil, nil
+	}
+	if args.Parallelism < 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("parallelism must not be negative, got %d", args.Parallelism)}},
 			IsError: true,
 		}, nil, nil
 	}
 
-	successMessage := fmt.Sprintf("Successfully executed %d state operations in a transaction on store '%s'.", len(args.Items), args.StoreName)
+	if err := authorize(ctx, auth.StateRead, args.StoreName, args.Keys...); err != nil {
+		return accessDeniedResult(args.StoreName, err)
+	}
+
+	items, err := stateClient.GetBulkState(ctx, args.StoreName, args.Keys, nil, args.Parallelism)
+	if err != nil {
+		return stateError("GetBulkState", err)
+	}
+
+	results := make([]BulkStateResult, 0, len(items))
+	var found, failed int
+	var text strings.Builder
+	for _, item := range items {
+		result := BulkStateResult{
+			Key:   item.Key,
+			Etag:  item.Etag,
+			Found: stored(item.Value, item.Etag),
+			Error: item.Error,
+		}
+		if result.Found && result.Error == "" {
+			value, encoding, err := decodeValue(item.Value, item.Metadata[contentTypeMetadataKey], args.Encoding)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Value, result.Encoding = value, encoding
+			}
+		}
+		switch {
+		case result.Error != "":
+			failed++
+			fmt.Fprintf(&text, "\n- %s: ERROR %s", result.Key, result.Error)
+		case result.Found:
+			found++
+			fmt.Fprintf(&text, "\n- %s: %s", result.Key, displayValue(result.Value, result.Encoding))
+		default:
+			fmt.Fprintf(&text, "\n- %s: (not found)", result.Key)
+		}
+		results = append(results, result)
+	}
+
+	summary := fmt.Sprintf("Retrieved %d of %d key(s) from '%s' (%d failed).", found, len(args.Keys), args.StoreName, failed)
+	log.Println(summary)
+
+	structuredResult := map[string]interface{}{
+		"store_name": args.StoreName,
+		"found":      found,
+		"failed":     failed,
+		"items":      results,
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: summary + text.String()}},
+	}, structuredResult, nil
+}
+
+func saveBulkStateTool(ctx context.Context, req *mcp.CallToolRequest, args SaveBulkStateArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "save_bulk_state")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "save_bulk_state"),
+		attribute.String("dapr.store", args.StoreName),
+		attribute.Int("dapr.keys_count", len(args.Items)),
+	)
+
+	if len(args.Items) == 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: "at least one item is required for save_bulk_state"}},
+			IsError: true,
+		}, nil, nil
+	}
+
+	for _, item := range args.Items {
+		if err := authorize(ctx, auth.StateWrite, args.StoreName, item.Key); err != nil {
+			return accessDeniedResult(args.StoreName, err)
+		}
+	}
+
+	items := make([]*dapr.SetStateItem, 0, len(args.Items))
+	needsTTL := false
+	for _, item := range args.Items {
+		data, contentType, err := encodeValue(item.Value, item.Encoding)
+		if err != nil {
+			return invalidArgumentResult(fmt.Errorf("item '%s': %w", item.Key, err))
+		}
+		meta, hasTTL, err := buildWriteMetadata(item.Metadata, item.TTLSeconds)
+		if err != nil {
+			return invalidArgumentResult(fmt.Errorf("item '%s': %w", item.Key, err))
+		}
+		needsTTL = needsTTL || hasTTL
+		items = append(items, &dapr.SetStateItem{
+			Key:      item.Key,
+			Value:    data,
+			Metadata: withContentType(meta, contentType),
+		})
+	}
+	if needsTTL {
+		if err := checkTTLSupport(ctx, args.StoreName); err != nil {
+			return invalidArgumentResult(err)
+		}
+	}
+
+	// SaveBulkState is a single sidecar request, so a failure applies to every key.
+	results := make([]BulkSaveResult, 0, len(args.Items))
+	err := stateClient.SaveBulkState(ctx, args.StoreName, items...)
+	for _, item := range args.Items {
+		result := BulkSaveResult{Key: item.Key, Saved: err == nil}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	if err != nil {
+		log.Printf("Dapr SaveBulkState failed: %v", err)
+		toolErrorMessage := fmt.Errorf("dapr SaveBulkState failed for %d key(s) on store '%s': %v", len(args.Items), args.StoreName, err).Error()
+		return toolcall.WithStatus(&mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
+			IsError: true,
+		}, err), map[string]interface{}{"keys_saved": 0, "store_name": args.StoreName, "items": results}, nil
+	}
+
+	successMessage := fmt.Sprintf("Successfully saved %d key(s) to state store '%s'.", len(args.Items), args.StoreName)
 	log.Println(successMessage)
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{&mcp.TextContent{Text: successMessage}},
-	}, map[string]interface{}{"operations_executed": len(args.Items), "store_name": args.StoreName}, nil
+	}, map[string]interface{}{"keys_saved": len(args.Items), "store_name": args.StoreName, "items": results}, nil
 }
 
 func RegisterTools(server *mcp.Server, client StateClient) {
 	stateClient = client
+	dryrun.Plans("delete_state", "execute_transaction", "import_state")
 
 	isReadOnly := true
 	isIdempotent := true
@@ -219,14 +671,16 @@ func RegisterTools(server *mcp.Server, client StateClient) {
 			"**ARGUMENT RULES:**\n" +
 			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `StoreName`, `Key`, and `Value`.\n" +
 			"2. **KEY RULE**: The key SHOULD follow `<AppID>||<ResourceURI>||<Index>` when possible for discoverability.\n" +
-			"3. **VALUE RULE**: The `Value` must be a string (plain or JSON-encoded).\n" +
-			"4. **CLARIFICATION**: If any required input is missing, you MUST ask the user for clarification.",
+			"3. **VALUE RULE**: Pass structured data as a JSON object or array in `Value`, NEVER as a JSON-encoded string; it is stored with content type `application/json`. Strings are stored as-is. For binary data, pass a base64 string and set `Encoding` to `base64`.\n" +
+			"4. **CONCURRENCY**: To avoid overwriting concurrent changes, pass the `ETag` returned by `get_state`. On an `ETAG_MISMATCH` error, re-read the key and retry with the new ETag.\n" +
+			"5. **EXPIRY**: Use `TTLSeconds` to let the key expire. Only stores whose `get_components` capabilities include `TTL` accept it.\n" +
+			"6. **CLARIFICATION**: If any required input is missing, you MUST ask the user for clarification.",
 		Annotations: &mcp.ToolAnnotations{
 			ReadOnlyHint:    notReadOnly,
 			DestructiveHint: &notDestructive,
 			IdempotentHint:  isIdempotent,
 		},
-	}, saveStateTool)
+	}, exactArguments(saveStateTool))
 	mcp.AddTool(server, &mcp.Tool{
 		Name:  "get_state",
 		Title: "Retrieve Single Key State",
@@ -237,7 +691,9 @@ func RegisterTools(server *mcp.Server, client StateClient) {
 			"**ARGUMENT RULES:**\n" +
 			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `StoreName` and `Key`.\n" +
 			"2. **NEVER INVENT**: Never invent a `Key`; it must be provided by the user or discovered.\n" +
-			"3. **CLARIFICATION**: If any required input is missing, you MUST ask the user for clarification.",
+			"3. **ETAG**: The result includes the key's current `etag`, which can be passed to `save_state`, `delete_state` or `execute_transaction` for optimistic concurrency.\n" +
+			"4. **VALUE**: JSON values come back as structured JSON in `value` with `encoding` set to `json`; binary values come back base64-encoded with `encoding` set to `base64`.\n" +
+			"5. **CLARIFICATION**: If any required input is missing, you MUST ask the user for clarification.",
 		Annotations: &mcp.ToolAnnotations{
 			ReadOnlyHint:   isReadOnly,
 			IdempotentHint: isIdempotent,
@@ -252,7 +708,9 @@ func RegisterTools(server *mcp.Server, client StateClient) {
 			"2. Ensure `Key` is explicitly provided by the user or use the key previously used for save.\n\n" +
 			"**ARGUMENT RULES:**\n" +
 			"1. **REQUIRED INPUTS**: You MUST provide non-empty values for `StoreName` and `Key`.\n" +
-			"2. **SECURITY WARNING**: This operation can cause data loss. Ensure user intent is clear and the key is authorized for deletion.",
+			"2. **CONCURRENCY**: Pass the `ETag` returned by `get_state` to only delete the value that was read. On an `ETAG_MISMATCH` error, re-read the key before deciding whether to retry.\n" +
+			"3. **DRY RUN**: Set `DryRun` to preview the delete request without sending it.\n" +
+			"4. **SECURITY WARNING**: This operation can cause data loss. Ensure user intent is clear and the key is authorized for deletion.",
 		Annotations: &mcp.ToolAnnotations{
 			ReadOnlyHint:    notReadOnly,
 			DestructiveHint: &isDestructive,
@@ -268,11 +726,83 @@ func RegisterTools(server *mcp.Server, client StateClient) {
 			"2. Ensure `Items` contains valid save/delete operations.\n\n" +
 			"**ARGUMENT RULES:**\n" +
 			"1. **REQUIRED INPUTS**: You MUST provide a non-empty `StoreName` and a non-empty list of `Items`.\n" +
-			"2. **SECURITY WARNING**: Due to the complexity and potential for destructive operations within the transaction, ensure all actions are fully understood and authorized.",
+			"2. **CONCURRENCY**: Items may carry the `ETag` returned by `get_state`. If any ETag no longer matches, the whole transaction fails with `ETAG_MISMATCH`.\n" +
+			"3. **EXPIRY**: Upserts may set `TTLSeconds`. Only stores whose `get_components` capabilities include `TTL` accept it.\n" +
+			"4. **DRY RUN**: Set `DryRun` to preview the operations that would be sent, including a warning when the store is not transactional.\n" +
+			"5. **SECURITY WARNING**: Due to the complexity and potential for destructive operations within the transaction, ensure all actions are fully understood and authorized.",
 		Annotations: &mcp.ToolAnnotations{
 			ReadOnlyHint:    notReadOnly,
 			DestructiveHint: &isDestructive,
 			IdempotentHint:  false,
 		},
-	}, executeTransactionTool)
+	}, exactArguments(executeTransactionTool))
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "get_bulk_state",
+		Title: "Retrieve Multiple Keys State",
+		Description: "Retrieves the values for a list of keys from a Dapr state store in a single call. **This is a Data Retrieval operation and IS IDEMPOTENT.** Prefer this over repeated `get_state` calls when hydrating several keys.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Use `get_components` to find the `StoreName` of the state store.\n" +
+			"2. Each key is reported individually in the structured result with its value, ETag, and any per-key error.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide a non-empty `StoreName` and a non-empty list of `Keys`.\n" +
+			"2. **NEVER INVENT**: Never invent keys; they must be provided by the user or discovered.\n" +
+			"3. **PARALLELISM**: `Parallelism` is optional; leave it unset unless the user asks to tune it.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   isReadOnly,
+			IdempotentHint: isIdempotent,
+		},
+	}, getBulkStateTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "save_bulk_state",
+		Title: "Save Multiple Key-Value States",
+		Description: "Saves a list of key-value pairs to a Dapr state store in a single call. **This is a SIDE-EFFECT action that alters application state and IS IDEMPOTENT.** Unlike `execute_transaction`, the writes are NOT atomic.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Use `get_components` to find the `StoreName` of the state store.\n" +
+			"2. Use `execute_transaction` instead when all writes must succeed or fail together.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide a non-empty `StoreName` and a non-empty list of `Items`, each with a `Key` and `Value`.\n" +
+			"2. **VALUE RULE**: Pass structured data as a JSON object or array in `Value`, NEVER as a JSON-encoded string. For binary data, pass a base64 string and set `Encoding` to `base64`.\n" +
+			"3. **CLARIFICATION**: If any required input is missing, you MUST ask the user for clarification.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    notReadOnly,
+			DestructiveHint: &notDestructive,
+			IdempotentHint:  isIdempotent,
+		},
+	}, exactArguments(saveBulkStateTool))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "export_state",
+		Title: "Export State Snapshot",
+		Description: "Exports keys from a Dapr state store into a portable JSON snapshot that `import_state` can restore into the same or a different store. **This is a Data Retrieval operation and IS IDEMPOTENT.**\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Use `get_components` to find the `StoreName` of the state store.\n" +
+			"2. Select keys either with an explicit `Keys` list or with a `Query` (same grammar as `query_state`, only for stores with the `QUERY_API` capability).\n" +
+			"3. Keep the returned `snapshot` object unchanged to restore it later.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide a non-empty `StoreName` and exactly one of `Keys` or `Query`.\n" +
+			"2. **NEVER INVENT**: Never invent keys; they must be provided by the user or discovered.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   isReadOnly,
+			IdempotentHint: isIdempotent,
+		},
+	}, exportStateTool)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "import_state",
+		Title: "Import State Snapshot",
+		Description: "Restores a snapshot produced by `export_state` into a Dapr state store, writing keys through transactions in chunks. **This is a SIDE-EFFECT action that overwrites existing keys.**\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. ALWAYS run with `DryRun` set to true first and show the user which keys would be created or updated.\n" +
+			"2. Each chunk is atomic, but the import as a whole is not; if a chunk fails, earlier chunks stay committed and the result lists the pending keys.\n" +
+			"3. The target store MUST report the `TRANSACTIONAL` capability; use `save_bulk_state` for other stores.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide the `Snapshot` object exactly as returned by `export_state`. `StoreName` defaults to the snapshot's store.\n" +
+			"2. **SERIALIZATION**: `Snapshot` MUST be a JSON object, NEVER a quoted string.\n" +
+			"3. **SECURITY WARNING**: Importing overwrites existing values. Ensure the target store is what the user intends.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    notReadOnly,
+			DestructiveHint: &isDestructive,
+			IdempotentHint:  isIdempotent,
+		},
+	}, exactArguments(importStateTool))
 }
@@ -2,14 +2,20 @@ package state
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/dapr/go-sdk/client"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
 	"github.com/dapr/dapr-mcp-server/test/mocks"
 )
 
@@ -49,6 +55,129 @@ func TestSaveStateTool(t *testing.T) {
 			wantErr:     true,
 			wantContent: "failed to save state to store 'statestore'",
 		},
+		{
+			name: "save with etag and options",
+			args: SaveStateArgs{
+				StoreName:   "statestore",
+				Key:         "test-key",
+				Value:       "v2",
+				ETag:        "3",
+				Concurrency: "first-write",
+				Consistency: "strong",
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("SaveStateWithETag", mock.Anything, "statestore", "test-key", []byte("v2"), "3", mock.Anything, mock.MatchedBy(func(so []client.StateOption) bool {
+					return len(so) == 2
+				})).Return(nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully saved key 'test-key'",
+		},
+		{
+			name: "etag mismatch",
+			args: SaveStateArgs{
+				StoreName: "statestore",
+				Key:       "test-key",
+				Value:     "v2",
+				ETag:      "3",
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("SaveStateWithETag", mock.Anything, "statestore", "test-key", []byte("v2"), "3", mock.Anything, mock.Anything).
+					Return(fmt.Errorf("error saving state: %w", status.Error(codes.Aborted, "possible etag mismatch")))
+			},
+			wantErr:     true,
+			wantContent: ErrCodeETagMismatch,
+		},
+		{
+			name: "invalid concurrency",
+			args: SaveStateArgs{
+				StoreName:   "statestore",
+				Key:         "test-key",
+				Value:       "v2",
+				Concurrency: "newest-wins",
+			},
+			setupMock:   func(m *mocks.MockDaprClient) {},
+			wantErr:     true,
+			wantContent: "invalid concurrency",
+		},
+		{
+			name: "save with ttl on supported store",
+			args: SaveStateArgs{
+				StoreName:  "statestore",
+				Key:        "test-key",
+				Value:      "v1",
+				TTLSeconds: 60,
+				Metadata:   map[string]string{"contentType": "text/plain"},
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("GetMetadata", mock.Anything).Return(&client.GetMetadataResponse{
+					RegisteredComponents: []*client.MetadataRegisteredComponents{
+						{Name: "statestore", Type: "state.redis", Capabilities: []string{"ETAG", "TTL"}},
+					},
+				}, nil)
+				m.On("SaveState", mock.Anything, "statestore", "test-key", []byte("v1"),
+					map[string]string{"contentType": "text/plain", "ttlInSeconds": "60"}, mock.Anything).Return(nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully saved key 'test-key'",
+		},
+		{
+			name: "save with ttl on unsupported store",
+			args: SaveStateArgs{
+				StoreName:  "statestore",
+				Key:        "test-key",
+				Value:      "v1",
+				TTLSeconds: 60,
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("GetMetadata", mock.Anything).Return(&client.GetMetadataResponse{
+					RegisteredComponents: []*client.MetadataRegisteredComponents{
+						{Name: "statestore", Type: "state.in-memory", Capabilities: []string{"ETAG"}},
+					},
+				}, nil)
+			},
+			wantErr:     true,
+			wantContent: "does not support TTL",
+		},
+		{
+			name: "ttl conflicts with metadata",
+			args: SaveStateArgs{
+				StoreName:  "statestore",
+				Key:        "test-key",
+				Value:      "v1",
+				TTLSeconds: 60,
+				Metadata:   map[string]string{"ttlInSeconds": "30"},
+			},
+			setupMock:   func(m *mocks.MockDaprClient) {},
+			wantErr:     true,
+			wantContent: "conflicts with metadata",
+		},
+		{
+			name: "invalid ttl",
+			args: SaveStateArgs{
+				StoreName:  "statestore",
+				Key:        "test-key",
+				Value:      "v1",
+				TTLSeconds: -5,
+			},
+			setupMock:   func(m *mocks.MockDaprClient) {},
+			wantErr:     true,
+			wantContent: "invalid ttlSeconds",
+		},
+		{
+			name: "save json value",
+			args: SaveStateArgs{
+				StoreName: "statestore",
+				Key:       "test-key",
+				Value:     map[string]any{"count": 3.0},
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("SaveState", mock.Anything, "statestore", "test-key", []byte(`{"count":3}`),
+					map[string]string{"contentType": "application/json"}, mock.Anything).Return(nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully saved key 'test-key'",
+		},
 	}
 
 	for _, tt := range tests {
@@ -74,6 +203,27 @@ func TestSaveStateTool(t *testing.T) {
 	}
 }
 
+func TestSaveStateToolRecordsStatus(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	stateClient = mockClient
+	mockClient.On("SaveState", mock.Anything, "statestore", "a", mock.Anything, mock.Anything, mock.Anything).
+		Return(fmt.Errorf("error saving state: %w", status.Error(codes.Unavailable, "connection refused")))
+	mockClient.On("SaveStateWithETag", mock.Anything, "statestore", "b", mock.Anything, "3", mock.Anything, mock.Anything).
+		Return(fmt.Errorf("error saving state: %w", status.Error(codes.Aborted, "possible etag mismatch")))
+
+	result, _, err := saveStateTool(context.Background(), &mcp.CallToolRequest{}, SaveStateArgs{StoreName: "statestore", Key: "a", Value: "v"})
+	assert.NoError(t, err)
+	code, ok := toolcall.Status(result)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unavailable, code)
+
+	// An ETag mismatch is reported as a conflict, which is never retried.
+	result, _, err = saveStateTool(context.Background(), &mcp.CallToolRequest{}, SaveStateArgs{StoreName: "statestore", Key: "b", Value: "v", ETag: "3"})
+	assert.NoError(t, err)
+	_, ok = toolcall.Status(result)
+	assert.False(t, ok)
+}
+
 func TestGetStateTool(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -98,6 +248,39 @@ func TestGetStateTool(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Retrieved key 'test-key' from 'statestore'",
 		},
+		{
+			name: "get returns etag",
+			args: GetStateArgs{
+				StoreName: "statestore",
+				Key:       "test-key",
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("GetState", mock.Anything, "statestore", "test-key", mock.Anything).
+					Return(&client.StateItem{
+						Key:   "test-key",
+						Value: []byte("v1"),
+						Etag:  "7",
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "ETag: 7",
+		},
+		{
+			name: "get json value",
+			args: GetStateArgs{
+				StoreName: "statestore",
+				Key:       "test-key",
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("GetState", mock.Anything, "statestore", "test-key", mock.Anything).
+					Return(&client.StateItem{
+						Key:   "test-key",
+						Value: []byte(`{"count": 3}`),
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `{"count":3}`,
+		},
 		{
 			name: "key not found",
 			args: GetStateArgs{
@@ -114,6 +297,23 @@ func TestGetStateTool(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Key 'nonexistent-key' not found",
 		},
+		{
+			name: "empty value with etag is found",
+			args: GetStateArgs{
+				StoreName: "statestore",
+				Key:       "empty-key",
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("GetState", mock.Anything, "statestore", "empty-key", mock.Anything).
+					Return(&client.StateItem{
+						Key:   "empty-key",
+						Value: []byte{},
+						Etag:  "2",
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Retrieved key 'empty-key' from 'statestore'.",
+		},
 		{
 			name: "get failure",
 			args: GetStateArgs{
@@ -185,6 +385,49 @@ func TestDeleteStateTool(t *testing.T) {
 			wantErr:     true,
 			wantContent: "dapr DeleteState failed",
 		},
+		{
+			name: "delete with etag",
+			args: DeleteStateArgs{
+				StoreName: "statestore",
+				Key:       "test-key",
+				ETag:      "5",
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("DeleteStateWithETag", mock.Anything, "statestore", "test-key", &client.ETag{Value: "5"}, mock.Anything, (*client.StateOptions)(nil)).
+					Return(nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully deleted key 'test-key'",
+		},
+		{
+			name: "delete etag mismatch",
+			args: DeleteStateArgs{
+				StoreName: "statestore",
+				Key:       "test-key",
+				ETag:      "5",
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("DeleteStateWithETag", mock.Anything, "statestore", "test-key", mock.Anything, mock.Anything, mock.Anything).
+					Return(status.Error(codes.Aborted, "possible etag mismatch"))
+			},
+			wantErr:     true,
+			wantContent: ErrCodeETagMismatch,
+		},
+		{
+			name: "delete with consistency only",
+			args: DeleteStateArgs{
+				StoreName:   "statestore",
+				Key:         "test-key",
+				Consistency: "eventual",
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("DeleteStateWithETag", mock.Anything, "statestore", "test-key", (*client.ETag)(nil), mock.Anything,
+					&client.StateOptions{Consistency: client.StateConsistencyEventual}).
+					Return(nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully deleted key 'test-key'",
+		},
 	}
 
 	for _, tt := range tests {
@@ -249,6 +492,59 @@ func TestExecuteTransactionTool(t *testing.T) {
 			wantErr:     true,
 			wantContent: "ExecuteStateTransaction failed",
 		},
+		{
+			name: "transaction etag mismatch",
+			args: ExecuteTransactionArgs{
+				StoreName: "statestore",
+				Items: []TransactionItem{
+					{Key: "key1", Value: "value1", ETag: "2", Concurrency: "first-write"},
+				},
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("ExecuteStateTransaction", mock.Anything, "statestore", mock.Anything, mock.MatchedBy(func(ops []*client.StateOperation) bool {
+					item := ops[0].Item
+					return item.Etag != nil && item.Etag.Value == "2" && item.Options.Concurrency == client.StateConcurrencyFirstWrite
+				})).Return(errors.New("error executing state transaction: possible etag mismatch"))
+			},
+			wantErr:     true,
+			wantContent: ErrCodeETagMismatch,
+		},
+		{
+			name: "transaction with item ttl and metadata",
+			args: ExecuteTransactionArgs{
+				StoreName: "statestore",
+				Items: []TransactionItem{
+					{Key: "key1", Value: "value1", TTLSeconds: 120},
+				},
+				Metadata: map[string]string{"partitionKey": "p1"},
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("GetMetadata", mock.Anything).Return(&client.GetMetadataResponse{
+					RegisteredComponents: []*client.MetadataRegisteredComponents{
+						{Name: "statestore", Type: "state.redis", Capabilities: []string{"TRANSACTIONAL", "TTL"}},
+					},
+				}, nil)
+				m.On("ExecuteStateTransaction", mock.Anything, "statestore", mock.MatchedBy(func(meta map[string]string) bool {
+					return meta["partitionKey"] == "p1"
+				}), mock.MatchedBy(func(ops []*client.StateOperation) bool {
+					return ops[0].Item.Metadata["ttlInSeconds"] == "120"
+				})).Return(nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully executed 1 state operations",
+		},
+		{
+			name: "ttl on delete item",
+			args: ExecuteTransactionArgs{
+				StoreName: "statestore",
+				Items: []TransactionItem{
+					{Key: "key1", IsDelete: true, TTLSeconds: 120},
+				},
+			},
+			setupMock:   func(m *mocks.MockDaprClient) {},
+			wantErr:     true,
+			wantContent: "cannot be set on a delete operation",
+		},
 	}
 
 	for _, tt := range tests {
@@ -273,6 +569,203 @@ func TestExecuteTransactionTool(t *testing.T) {
 	}
 }
 
+func TestGetBulkStateTool(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        GetBulkStateArgs
+		setupMock   func(*mocks.MockDaprClient)
+		wantErr     bool
+		wantContent string
+		wantFound   int
+		wantFailed  int
+	}{
+		{
+			name: "mixed found, missing and failed keys",
+			args: GetBulkStateArgs{
+				StoreName:   "statestore",
+				Keys:        []string{"key1", "key2", "key3"},
+				Parallelism: 5,
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("GetBulkState", mock.Anything, "statestore", []string{"key1", "key2", "key3"}, mock.Anything, int32(5)).
+					Return([]*client.BulkStateItem{
+						{Key: "key1", Value: []byte(`{"a":1}`), Etag: "1"},
+						{Key: "key2", Value: []byte{}},
+						{Key: "key3", Error: "timeout"},
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Retrieved 1 of 3 key(s) from 'statestore' (1 failed).",
+			wantFound:   1,
+			wantFailed:  1,
+		},
+		{
+			name: "empty value with etag is found",
+			args: GetBulkStateArgs{
+				StoreName: "statestore",
+				Keys:      []string{"key1", "key2"},
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("GetBulkState", mock.Anything, "statestore", []string{"key1", "key2"}, mock.Anything, int32(0)).
+					Return([]*client.BulkStateItem{
+						{Key: "key1", Value: []byte{}, Etag: "4"},
+						{Key: "key2"},
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Retrieved 1 of 2 key(s) from 'statestore' (0 failed).\n- key1: \n- key2: (not found)",
+			wantFound:   1,
+			wantFailed:  0,
+		},
+		{
+			name: "no keys",
+			args: GetBulkStateArgs{
+				StoreName: "statestore",
+			},
+			setupMock:   func(m *mocks.MockDaprClient) {},
+			wantErr:     true,
+			wantContent: "at least one key is required",
+		},
+		{
+			name: "negative parallelism",
+			args: GetBulkStateArgs{
+				StoreName:   "statestore",
+				Keys:        []string{"key1"},
+				Parallelism: -1,
+			},
+			setupMock:   func(m *mocks.MockDaprClient) {},
+			wantErr:     true,
+			wantContent: "parallelism must not be negative",
+		},
+		{
+			name: "get bulk failure",
+			args: GetBulkStateArgs{
+				StoreName: "statestore",
+				Keys:      []string{"key1"},
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("GetBulkState", mock.Anything, "statestore", []string{"key1"}, mock.Anything, int32(0)).
+					Return(nil, errors.New("connection refused"))
+			},
+			wantErr:     true,
+			wantContent: "dapr GetBulkState failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(mocks.MockDaprClient)
+			tt.setupMock(mockClient)
+
+			stateClient = mockClient
+
+			result, structured, err := getBulkStateTool(context.Background(), &mcp.CallToolRequest{}, tt.args)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantErr, result.IsError)
+			if len(result.Content) > 0 {
+				textContent, ok := result.Content[0].(*mcp.TextContent)
+				assert.True(t, ok)
+				assert.Contains(t, textContent.Text, tt.wantContent)
+			}
+			if !tt.wantErr {
+				resultMap, ok := structured.(map[string]interface{})
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantFound, resultMap["found"])
+				assert.Equal(t, tt.wantFailed, resultMap["failed"])
+				assert.Len(t, resultMap["items"], len(tt.args.Keys))
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSaveBulkStateTool(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        SaveBulkStateArgs
+		setupMock   func(*mocks.MockDaprClient)
+		wantErr     bool
+		wantContent string
+		wantSaved   bool
+	}{
+		{
+			name: "successful bulk save",
+			args: SaveBulkStateArgs{
+				StoreName: "statestore",
+				Items: []BulkSaveItem{
+					{Key: "key1", Value: "value1"},
+					{Key: "key2", Value: "value2"},
+				},
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("SaveBulkState", mock.Anything, "statestore", mock.MatchedBy(func(items []*client.SetStateItem) bool {
+					return len(items) == 2 && items[0].Key == "key1" && string(items[1].Value) == "value2"
+				})).Return(nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully saved 2 key(s) to state store 'statestore'.",
+			wantSaved:   true,
+		},
+		{
+			name: "no items",
+			args: SaveBulkStateArgs{
+				StoreName: "statestore",
+			},
+			setupMock:   func(m *mocks.MockDaprClient) {},
+			wantErr:     true,
+			wantContent: "at least one item is required",
+		},
+		{
+			name: "bulk save failure",
+			args: SaveBulkStateArgs{
+				StoreName: "statestore",
+				Items: []BulkSaveItem{
+					{Key: "key1", Value: "value1"},
+				},
+			},
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("SaveBulkState", mock.Anything, "statestore", mock.Anything).
+					Return(errors.New("connection refused"))
+			},
+			wantErr:     true,
+			wantContent: "dapr SaveBulkState failed for 1 key(s)",
+			wantSaved:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(mocks.MockDaprClient)
+			tt.setupMock(mockClient)
+
+			stateClient = mockClient
+
+			result, structured, err := saveBulkStateTool(context.Background(), &mcp.CallToolRequest{}, tt.args)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantErr, result.IsError)
+			if len(result.Content) > 0 {
+				textContent, ok := result.Content[0].(*mcp.TextContent)
+				assert.True(t, ok)
+				assert.Contains(t, textContent.Text, tt.wantContent)
+			}
+			if len(tt.args.Items) > 0 {
+				resultMap, ok := structured.(map[string]interface{})
+				assert.True(t, ok)
+				items, ok := resultMap["items"].([]BulkSaveResult)
+				assert.True(t, ok)
+				for _, item := range items {
+					assert.Equal(t, tt.wantSaved, item.Saved)
+				}
+			}
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
 func TestRegisterTools(t *testing.T) {
 	mockClient := new(mocks.MockDaprClient)
 	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil)
@@ -282,3 +775,73 @@ func TestRegisterTools(t *testing.T) {
 
 	assert.Equal(t, mockClient, stateClient)
 }
+
+// connect registers the state tools backed by client and returns a client
+// session calling them over an in-memory transport, so that arguments take
+// the same path as those of a real client.
+func connect(t *testing.T, client StateClient) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	RegisterTools(server, client)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func TestToolsKeepLargeIntegers(t *testing.T) {
+	const value = `{"id":9007199254740993}`
+	tests := []struct {
+		name      string
+		tool      string
+		arguments string
+		setupMock func(*mocks.MockDaprClient)
+	}{
+		{
+			name:      "save_state",
+			tool:      "save_state",
+			arguments: `{"storeName":"statestore","key":"a","value":` + value + `}`,
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("SaveState", mock.Anything, "statestore", "a", []byte(value), mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:      "execute_transaction",
+			tool:      "execute_transaction",
+			arguments: `{"storeName":"statestore","items":[{"key":"a","value":` + value + `,"isDelete":false}]}`,
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("ExecuteStateTransaction", mock.Anything, "statestore", mock.Anything, mock.MatchedBy(func(ops []*client.StateOperation) bool {
+					return len(ops) == 1 && string(ops[0].Item.Value) == value
+				})).Return(nil)
+			},
+		},
+		{
+			name:      "save_bulk_state",
+			tool:      "save_bulk_state",
+			arguments: `{"storeName":"statestore","items":[{"key":"a","value":` + value + `}]}`,
+			setupMock: func(m *mocks.MockDaprClient) {
+				m.On("SaveBulkState", mock.Anything, "statestore", mock.MatchedBy(func(items []*client.SetStateItem) bool {
+					return len(items) == 1 && string(items[0].Value) == value
+				})).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(mocks.MockDaprClient)
+			tt.setupMock(mockClient)
+			session := connect(t, mockClient)
+
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: tt.tool, Arguments: json.RawMessage(tt.arguments)})
+			require.NoError(t, err)
+			assert.False(t, result.IsError, "%v", result.Content)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
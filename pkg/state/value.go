@@ -0,0 +1,160 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Value encodings accepted by the state tools.
+const (
+	EncodingText   = "text"
+	EncodingJSON   = "json"
+	EncodingBase64 = "base64"
+)
+
+const (
+	contentTypeMetadataKey = "contentType"
+	jsonContentType        = "application/json"
+)
+
+// encodeValue converts a tool argument into the bytes saved by the sidecar. It
+// returns the content type to record with the value, or "" to leave it unset.
+//
+// With no explicit encoding, strings are stored verbatim and any other JSON
+// value (object, array, number, boolean) is stored as JSON.
+func encodeValue(value any, encoding string) ([]byte, string, error) {
+	switch strings.ToLower(encoding) {
+	case "":
+		if s, ok := value.(string); ok || value == nil {
+			return []byte(s), "", nil
+		}
+		return encodeJSON(value)
+	case EncodingText:
+		s, ok := value.(string)
+		if !ok && value != nil {
+			return nil, "", fmt.Errorf("encoding '%s' requires a string value, got %T", EncodingText, value)
+		}
+		return []byte(s), "", nil
+	case EncodingJSON:
+		return encodeJSON(value)
+	case EncodingBase64:
+		s, ok := value.(string)
+		if !ok {
+			return nil, "", fmt.Errorf("encoding '%s' requires a base64 string value, got %T", EncodingBase64, value)
+		}
+		data, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, "", fmt.Errorf("value is not valid base64: %w", err)
+		}
+		return data, "", nil
+	default:
+		return nil, "", fmt.Errorf("invalid encoding %q: must be '%s', '%s' or '%s'", encoding, EncodingText, EncodingJSON, EncodingBase64)
+	}
+}
+
+func encodeJSON(value any) ([]byte, string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, "", fmt.Errorf("value cannot be encoded as JSON: %w", err)
+	}
+	return data, jsonContentType, nil
+}
+
+// withContentType records contentType in the request metadata unless the caller
+// already set one explicitly.
+func withContentType(meta map[string]string, contentType string) map[string]string {
+	if contentType == "" {
+		return meta
+	}
+	if _, ok := meta[contentTypeMetadataKey]; ok {
+		return meta
+	}
+	if meta == nil {
+		meta = make(map[string]string, 1)
+	}
+	meta[contentTypeMetadataKey] = contentType
+	return meta
+}
+
+// decodeValue converts stored bytes into a value for a structured result and
+// returns the encoding that was applied.
+//
+// With no explicit encoding, values stored as JSON (by content type, or because
+// they are a JSON object or array) are returned as structured JSON, invalid
+// UTF-8 is returned as base64, and everything else is returned as text.
+func decodeValue(data []byte, contentType, encoding string) (any, string, error) {
+	switch strings.ToLower(encoding) {
+	case "":
+		trimmed := bytes.TrimSpace(data)
+		isContainer := len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+		if (strings.HasPrefix(contentType, jsonContentType) || isContainer) && json.Valid(data) {
+			return decodeValue(data, contentType, EncodingJSON)
+		}
+		if !utf8.Valid(data) {
+			return decodeValue(data, contentType, EncodingBase64)
+		}
+		return string(data), EncodingText, nil
+	case EncodingText:
+		return string(data), EncodingText, nil
+	case EncodingJSON:
+		var value any
+		if err := unmarshalJSON(data, &value); err != nil {
+			return nil, "", fmt.Errorf("stored value is not valid JSON: %w", err)
+		}
+		return value, EncodingJSON, nil
+	case EncodingBase64:
+		return base64.StdEncoding.EncodeToString(data), EncodingBase64, nil
+	default:
+		return nil, "", fmt.Errorf("invalid encoding %q: must be '%s', '%s' or '%s'", encoding, EncodingText, EncodingJSON, EncodingBase64)
+	}
+}
+
+// unmarshalJSON decodes data into v, keeping numbers as json.Number so that
+// integers beyond 2^53 are not rounded through float64.
+func unmarshalJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("unexpected data after the JSON value")
+	}
+	return nil
+}
+
+// displayValue renders a decoded value for the text content of a tool result.
+func displayValue(value any, encoding string) string {
+	if encoding == EncodingJSON {
+		if data, err := json.Marshal(value); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+// exactArguments decodes the raw arguments of a call again before handing them
+// to h, keeping numbers in values as json.Number. The SDK validates arguments
+// through a map[string]any, which rounds integers beyond 2^53 before the
+// handler sees them.
+func exactArguments[In any](h mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
+		if req.Params != nil && len(req.Params.Arguments) > 0 {
+			var exact In
+			if err := unmarshalJSON(req.Params.Arguments, &exact); err != nil {
+				return invalidArgumentResult(fmt.Errorf("invalid arguments: %w", err))
+			}
+			args = exact
+		}
+		return h(ctx, req, args)
+	}
+}
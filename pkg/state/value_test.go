@@ -0,0 +1,77 @@
+package state
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeValue(t *testing.T) {
+	tests := []struct {
+		name            string
+		value           any
+		encoding        string
+		wantData        string
+		wantContentType string
+		wantErr         string
+	}{
+		{name: "string stored verbatim", value: `{"a":1}`, wantData: `{"a":1}`},
+		{name: "object stored as json", value: map[string]any{"a": 1.0}, wantData: `{"a":1}`, wantContentType: jsonContentType},
+		{name: "number stored as json", value: 42.0, wantData: `42`, wantContentType: jsonContentType},
+		{name: "decoded number stored exactly", value: map[string]any{"id": json.Number("9007199254740993")}, wantData: `{"id":9007199254740993}`, wantContentType: jsonContentType},
+		{name: "string with json encoding", value: "hi", encoding: "json", wantData: `"hi"`, wantContentType: jsonContentType},
+		{name: "base64", value: "AAEC", encoding: "base64", wantData: "\x00\x01\x02"},
+		{name: "invalid base64", value: "%%%", encoding: "base64", wantErr: "not valid base64"},
+		{name: "base64 requires string", value: 1.0, encoding: "base64", wantErr: "requires a base64 string"},
+		{name: "text requires string", value: []any{1.0}, encoding: "text", wantErr: "requires a string"},
+		{name: "unknown encoding", value: "x", encoding: "hex", wantErr: "invalid encoding"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, contentType, err := encodeValue(tt.value, tt.encoding)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantData, string(data))
+			assert.Equal(t, tt.wantContentType, contentType)
+		})
+	}
+}
+
+func TestDecodeValue(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         []byte
+		contentType  string
+		encoding     string
+		wantValue    any
+		wantEncoding string
+		wantErr      string
+	}{
+		{name: "object is returned as json", data: []byte(`{"a":1}`), wantValue: map[string]any{"a": json.Number("1")}, wantEncoding: EncodingJSON},
+		{name: "large integers are kept exact", data: []byte(`{"id":9007199254740993}`), wantValue: map[string]any{"id": json.Number("9007199254740993")}, wantEncoding: EncodingJSON},
+		{name: "scalar is returned as text", data: []byte(`42`), wantValue: "42", wantEncoding: EncodingText},
+		{name: "json content type", data: []byte(`42`), contentType: jsonContentType, wantValue: json.Number("42"), wantEncoding: EncodingJSON},
+		{name: "invalid utf8 is returned as base64", data: []byte{0xff, 0x00}, wantValue: "/wA=", wantEncoding: EncodingBase64},
+		{name: "explicit text", data: []byte(`{"a":1}`), encoding: "text", wantValue: `{"a":1}`, wantEncoding: EncodingText},
+		{name: "explicit json with trailing data", data: []byte(`{} {}`), encoding: "json", wantErr: "not valid JSON"},
+		{name: "explicit json on invalid data", data: []byte(`nope`), encoding: "json", wantErr: "not valid JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, encoding, err := decodeValue(tt.data, tt.contentType, tt.encoding)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantValue, value)
+			assert.Equal(t, tt.wantEncoding, encoding)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Package toolcall holds the shared plumbing for receiving middleware on
+// tools/call requests: finding the component a call targets and reading the
+// annotations of the called tool, as well as listing the server's tools.
+package toolcall
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StatusMetaKey is the _meta key of a tool result holding the gRPC status
+// code of the sidecar error the result reports.
+const StatusMetaKey = "dapr.io/grpc-status"
+
+// componentArguments are the tool arguments naming the component or app a
+// call targets, in order of preference.
+var componentArguments = []string{"storeName", "pubsubName", "bindingName", "componentName", "component", "appID"}
+
+// Call returns the tools/call request handled by method, if it is one.
+func Call(method string, req mcp.Request) (*mcp.CallToolRequest, bool) {
+	call, ok := req.(*mcp.CallToolRequest)
+	if method != "tools/call" || !ok || call.Params == nil {
+		return nil, false
+	}
+	return call, true
+}
+
+// Component returns the component or app named in a call's arguments.
+func Component(arguments json.RawMessage) string {
+	var args map[string]any
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return ""
+	}
+	for _, name := range componentArguments {
+		if s, ok := args[name].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// WithStatus records the gRPC status code of err in the _meta of an error
+// result, so that middleware can tell transient failures from others without
+// reading the message. Errors that carry no gRPC status are not recorded.
+func WithStatus(result *mcp.CallToolResult, err error) *mcp.CallToolResult {
+	s, ok := status.FromError(err)
+	if err == nil || !ok {
+		return result
+	}
+	if result.Meta == nil {
+		result.Meta = mcp.Meta{}
+	}
+	result.Meta[StatusMetaKey] = s.Code().String()
+	return result
+}
+
+// Status returns the gRPC status code recorded in a result by WithStatus.
+func Status(result *mcp.CallToolResult) (codes.Code, bool) {
+	name, ok := result.Meta[StatusMetaKey].(string)
+	if !ok {
+		return codes.Unknown, false
+	}
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if c.String() == name {
+			return c, true
+		}
+	}
+	return codes.Unknown, false
+}
+
+// Names lists the tools registered on server so far, through an in-memory
+// session.
+func Names(ctx context.Context, server *mcp.Server) ([]string, error) {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer serverSession.Close()
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "toolcall"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	var names []string
+	for tool, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, tool.Name)
+	}
+	return names, nil
+}
+
+// Annotations caches the annotations of the server's tools.
+type Annotations struct {
+	mu    sync.Mutex
+	tools map[string]*mcp.ToolAnnotations
+}
+
+// NewAnnotations creates an empty Annotations cache.
+func NewAnnotations() *Annotations {
+	return &Annotations{tools: make(map[string]*mcp.ToolAnnotations)}
+}
+
+// Lookup returns the annotations of the called tool, which are nil when the
+// tool has none, and whether the tool exists. The cache is reloaded from the
+// server's tool list when a tool is not known yet, so that tools added at
+// runtime are picked up.
+func (a *Annotations) Lookup(ctx context.Context, next mcp.MethodHandler, call *mcp.CallToolRequest) (*mcp.ToolAnnotations, bool) {
+	name := call.Params.Name
+	a.mu.Lock()
+	annotations, ok := a.tools[name]
+	a.mu.Unlock()
+	if ok {
+		return annotations, true
+	}
+
+	tools := make(map[string]*mcp.ToolAnnotations)
+	params := &mcp.ListToolsParams{}
+	for {
+		res, err := next(ctx, "tools/list", &mcp.ListToolsRequest{Session: call.Session, Params: params})
+		if err != nil {
+			log.Printf("Failed to list tools for their annotations: %v", err)
+			return nil, false
+		}
+		list, ok := res.(*mcp.ListToolsResult)
+		if !ok {
+			return nil, false
+		}
+		for _, tool := range list.Tools {
+			tools[tool.Name] = tool.Annotations
+		}
+		if list.NextCursor == "" {
+			break
+		}
+		params = &mcp.ListToolsParams{Cursor: list.NextCursor}
+	}
+
+	a.mu.Lock()
+	a.tools = tools
+	a.mu.Unlock()
+	annotations, ok = tools[name]
+	return annotations, ok
+}
+
+// ReadOnly reports whether the called tool is annotated read-only. Unknown
+// tools and tools without annotations are not.
+func (a *Annotations) ReadOnly(ctx context.Context, next mcp.MethodHandler, call *mcp.CallToolRequest) bool {
+	annotations, _ := a.Lookup(ctx, next, call)
+	return annotations != nil && annotations.ReadOnlyHint
+}
@@ -0,0 +1,90 @@
+package toolcall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestComponent(t *testing.T) {
+	assert.Equal(t, "statestore", Component([]byte(`{"storeName":"statestore","key":"a"}`)))
+	assert.Equal(t, "orders", Component([]byte(`{"appID":"orders","method":"x"}`)))
+	assert.Equal(t, "pubsub", Component([]byte(`{"appID":"orders","pubsubName":"pubsub"}`)))
+	assert.Empty(t, Component([]byte(`{"key":"a"}`)))
+	assert.Empty(t, Component([]byte(`not json`)))
+}
+
+func TestStatus(t *testing.T) {
+	result := WithStatus(&mcp.CallToolResult{IsError: true}, fmt.Errorf("get state: %w", status.Error(codes.Unavailable, "connection refused")))
+	code, ok := Status(result)
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unavailable, code)
+	assert.Equal(t, "Unavailable", result.Meta[StatusMetaKey])
+
+	// Errors without a gRPC status are not recorded.
+	_, ok = Status(WithStatus(&mcp.CallToolResult{IsError: true}, errors.New("invalid key")))
+	assert.False(t, ok)
+	_, ok = Status(&mcp.CallToolResult{})
+	assert.False(t, ok)
+}
+
+func TestCall(t *testing.T) {
+	call := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "get_state"}}
+	got, ok := Call("tools/call", call)
+	assert.True(t, ok)
+	assert.Equal(t, call, got)
+
+	_, ok = Call("tools/list", &mcp.ListToolsRequest{})
+	assert.False(t, ok)
+	_, ok = Call("tools/call", &mcp.CallToolRequest{})
+	assert.False(t, ok)
+}
+
+func TestAnnotationsLookup(t *testing.T) {
+	lists := 0
+	tools := []*mcp.Tool{{Name: "get_state", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}}
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		require.Equal(t, "tools/list", method)
+		lists++
+		return &mcp.ListToolsResult{Tools: tools}, nil
+	}
+	call := func(name string) *mcp.CallToolRequest {
+		return &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: name}}
+	}
+	annotations := NewAnnotations()
+
+	assert.True(t, annotations.ReadOnly(context.Background(), next, call("get_state")))
+	assert.True(t, annotations.ReadOnly(context.Background(), next, call("get_state")))
+	assert.Equal(t, 1, lists)
+
+	// Unknown tools reload the list, so tools added at runtime are found.
+	tools = append(tools, &mcp.Tool{Name: "orders_createOrder"})
+	got, ok := annotations.Lookup(context.Background(), next, call("orders_createOrder"))
+	assert.True(t, ok)
+	assert.Nil(t, got)
+	assert.False(t, annotations.ReadOnly(context.Background(), next, call("orders_createOrder")))
+	assert.Equal(t, 2, lists)
+
+	_, ok = annotations.Lookup(context.Background(), next, call("missing"))
+	assert.False(t, ok)
+}
+
+func TestNames(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	for _, name := range []string{"get_state", "save_state"} {
+		server.AddTool(&mcp.Tool{Name: name, InputSchema: map[string]any{"type": "object"}}, func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return &mcp.CallToolResult{}, nil
+		})
+	}
+
+	names, err := Names(context.Background(), server)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"get_state", "save_state"}, names)
+}
@@ -0,0 +1,463 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dapr/durabletask-go/api/protos"
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/dapr/dapr-mcp-server/pkg/metadata"
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+const (
+	// DefaultHistoryEvents is the number of most recent events returned by default.
+	DefaultHistoryEvents = 200
+	// MaxHistoryEvents caps the number of events read for one call.
+	MaxHistoryEvents = 500
+	// historyReaders is the number of history events read concurrently.
+	historyReaders = 8
+	// DefaultPayloadLength is the length inputs, outputs and stack traces are
+	// truncated to by default.
+	DefaultPayloadLength = 256
+)
+
+// HistoryClient reads workflow history. The sidecar's workflow API does not
+// return history, so it is read from the workflow actor's state, where the
+// workflow engine keeps one protobuf-encoded event per key. This layout is
+// internal to the engine and may change between Dapr versions.
+type HistoryClient interface {
+	metadata.MetadataClient
+	GetActorState(ctx context.Context, req *dapr.GetActorStateRequest) (*dapr.GetActorStateResponse, error)
+}
+
+type GetWorkflowHistoryArgs struct {
+	InstanceID    string `json:"instanceID" jsonschema:"The ID of the workflow instance (e.g., 'order-1001')."`
+	MaxEvents     int    `json:"maxEvents,omitempty" jsonschema:"Optional number of most recent events to return (default 200, at most 500)."`
+	PayloadLength int    `json:"payloadLength,omitempty" jsonschema:"Optional length inputs, outputs and stack traces are truncated to (default 256)."`
+}
+
+type HistoryFailure struct {
+	ErrorType    string `json:"error_type"`
+	Message      string `json:"message"`
+	StackTrace   string `json:"stack_trace,omitempty"`
+	NonRetriable bool   `json:"non_retriable,omitempty"`
+}
+
+type HistoryEvent struct {
+	EventID     int32           `json:"event_id" jsonschema:"The sequence number of the event in the history."`
+	Timestamp   string          `json:"timestamp" jsonschema:"When the event was recorded (RFC 3339)."`
+	Type        string          `json:"type" jsonschema:"The event type (e.g., TaskScheduled, TaskFailed, TimerFired, EventRaised)."`
+	Name        string          `json:"name,omitempty" jsonschema:"The workflow, activity, child workflow, timer or event name."`
+	ScheduledID *int32          `json:"scheduled_id,omitempty" jsonschema:"For results and fired timers, the ID of the event that scheduled them."`
+	InstanceID  string          `json:"instance_id,omitempty" jsonschema:"The child workflow or event target instance ID."`
+	Status      string          `json:"status,omitempty" jsonschema:"The final runtime status of a completed execution."`
+	FireAt      string          `json:"fire_at,omitempty" jsonschema:"When a timer is due (RFC 3339)."`
+	Input       string          `json:"input,omitempty" jsonschema:"The input or event payload, possibly truncated."`
+	Output      string          `json:"output,omitempty" jsonschema:"The result, possibly truncated."`
+	Failure     *HistoryFailure `json:"failure,omitempty" jsonschema:"The failure details of failed activities, child workflows and executions."`
+}
+
+type WorkflowHistory struct {
+	InstanceID     string         `json:"instance_id" jsonschema:"The ID of the workflow instance."`
+	Found          bool           `json:"found" jsonschema:"Whether history was found for the instance."`
+	TotalEvents    int            `json:"total_events" jsonschema:"The number of events in the full history."`
+	SkippedEvents  int            `json:"skipped_events" jsonschema:"The number of oldest events not returned because of maxEvents."`
+	Events         []HistoryEvent `json:"events" jsonschema:"The returned events, oldest first. Replay markers (OrchestratorStarted/Completed) are omitted."`
+	ReplayMarkers  int            `json:"replay_markers" jsonschema:"The number of omitted OrchestratorStarted and OrchestratorCompleted events."`
+	PayloadLimit   int            `json:"payload_limit" jsonschema:"The length payloads were truncated to."`
+	TruncatedCount int            `json:"truncated_payloads" jsonschema:"The number of payloads that were truncated."`
+}
+
+var historyClient HistoryClient
+
+// workflowActorType returns the internal actor type hosting the workflows of
+// the sidecar's app (dapr.internal.{namespace}.{appId}.workflow). It is taken
+// from the actor types the sidecar reports, so the namespace is never guessed.
+func workflowActorType(meta *dapr.GetMetadataResponse) (string, error) {
+	suffix := "." + meta.ID + ".workflow"
+	for _, actor := range meta.ActiveActorsCount {
+		if strings.HasPrefix(actor.Type, "dapr.internal.") && strings.HasSuffix(actor.Type, suffix) {
+			return actor.Type, nil
+		}
+	}
+	return "", fmt.Errorf("app '%s' of the connected sidecar does not host workflows; history can only be read for workflows hosted by that app", meta.ID)
+}
+
+// historyKey returns the actor state key of the i-th history event.
+func historyKey(i uint64) string {
+	return fmt.Sprintf("history-%06d", i)
+}
+
+// truncator shortens payloads and counts how many it cut.
+type truncator struct {
+	limit int
+	count int
+}
+
+func (t *truncator) text(s string) string {
+	if len(s) <= t.limit {
+		return s
+	}
+	t.count++
+	cut := t.limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... (%d more bytes)", s[:cut], len(s)-cut)
+}
+
+func (t *truncator) payload(v *wrapperspb.StringValue) string {
+	if v == nil {
+		return ""
+	}
+	return t.text(v.GetValue())
+}
+
+func (t *truncator) failure(f *protos.TaskFailureDetails) *HistoryFailure {
+	if f == nil {
+		return nil
+	}
+	return &HistoryFailure{
+		ErrorType:    f.GetErrorType(),
+		Message:      t.text(f.GetErrorMessage()),
+		StackTrace:   t.payload(f.GetStackTrace()),
+		NonRetriable: f.GetIsNonRetriable(),
+	}
+}
+
+func scheduledID(id int32) *int32 {
+	return &id
+}
+
+// convertEvents turns raw history events into their structured form. Names of
+// results are resolved from the events that scheduled them when those are
+// among the given events.
+func convertEvents(events []*protos.HistoryEvent, t *truncator) ([]HistoryEvent, int) {
+	names := map[int32]string{}
+	converted := []HistoryEvent{}
+	markers := 0
+	for _, e := range events {
+		out := HistoryEvent{
+			EventID:   e.GetEventId(),
+			Timestamp: formatTimestamp(e.GetTimestamp()),
+			Type:      strings.TrimPrefix(fmt.Sprintf("%T", e.GetEventType()), "*protos.HistoryEvent_"),
+		}
+		switch {
+		case e.GetOrchestratorStarted() != nil, e.GetOrchestratorCompleted() != nil:
+			markers++
+			continue
+		case e.GetExecutionStarted() != nil:
+			ev := e.GetExecutionStarted()
+			out.Name = ev.GetName()
+			out.Input = t.payload(ev.GetInput())
+			if ev.GetParentInstance() != nil {
+				out.InstanceID = ev.GetParentInstance().GetOrchestrationInstance().GetInstanceId()
+			}
+		case e.GetExecutionCompleted() != nil:
+			ev := e.GetExecutionCompleted()
+			out.Status = strings.TrimPrefix(ev.GetOrchestrationStatus().String(), "ORCHESTRATION_STATUS_")
+			out.Output = t.payload(ev.GetResult())
+			out.Failure = t.failure(ev.GetFailureDetails())
+		case e.GetExecutionTerminated() != nil:
+			out.Output = t.payload(e.GetExecutionTerminated().GetInput())
+		case e.GetExecutionSuspended() != nil:
+			out.Input = t.payload(e.GetExecutionSuspended().GetInput())
+		case e.GetExecutionResumed() != nil:
+			out.Input = t.payload(e.GetExecutionResumed().GetInput())
+		case e.GetContinueAsNew() != nil:
+			out.Input = t.payload(e.GetContinueAsNew().GetInput())
+		case e.GetTaskScheduled() != nil:
+			ev := e.GetTaskScheduled()
+			out.Name = ev.GetName()
+			out.Input = t.payload(ev.GetInput())
+			names[e.GetEventId()] = ev.GetName()
+		case e.GetTaskCompleted() != nil:
+			ev := e.GetTaskCompleted()
+			out.ScheduledID = scheduledID(ev.GetTaskScheduledId())
+			out.Name = names[ev.GetTaskScheduledId()]
+			out.Output = t.payload(ev.GetResult())
+		case e.GetTaskFailed() != nil:
+			ev := e.GetTaskFailed()
+			out.ScheduledID = scheduledID(ev.GetTaskScheduledId())
+			out.Name = names[ev.GetTaskScheduledId()]
+			out.Failure = t.failure(ev.GetFailureDetails())
+		case e.GetSubOrchestrationInstanceCreated() != nil:
+			ev := e.GetSubOrchestrationInstanceCreated()
+			out.Name = ev.GetName()
+			out.InstanceID = ev.GetInstanceId()
+			out.Input = t.payload(ev.GetInput())
+			names[e.GetEventId()] = ev.GetName()
+		case e.GetSubOrchestrationInstanceCompleted() != nil:
+			ev := e.GetSubOrchestrationInstanceCompleted()
+			out.ScheduledID = scheduledID(ev.GetTaskScheduledId())
+			out.Name = names[ev.GetTaskScheduledId()]
+			out.Output = t.payload(ev.GetResult())
+		case e.GetSubOrchestrationInstanceFailed() != nil:
+			ev := e.GetSubOrchestrationInstanceFailed()
+			out.ScheduledID = scheduledID(ev.GetTaskScheduledId())
+			out.Name = names[ev.GetTaskScheduledId()]
+			out.Failure = t.failure(ev.GetFailureDetails())
+		case e.GetTimerCreated() != nil:
+			ev := e.GetTimerCreated()
+			out.Name = ev.GetName()
+			out.FireAt = formatTimestamp(ev.GetFireAt())
+			names[e.GetEventId()] = ev.GetName()
+		case e.GetTimerFired() != nil:
+			ev := e.GetTimerFired()
+			out.ScheduledID = scheduledID(ev.GetTimerId())
+			out.Name = names[ev.GetTimerId()]
+			out.FireAt = formatTimestamp(ev.GetFireAt())
+		case e.GetEventRaised() != nil:
+			ev := e.GetEventRaised()
+			out.Name = ev.GetName()
+			out.Input = t.payload(ev.GetInput())
+		case e.GetEventSent() != nil:
+			ev := e.GetEventSent()
+			out.Name = ev.GetName()
+			out.InstanceID = ev.GetInstanceId()
+			out.Input = t.payload(ev.GetInput())
+		}
+		converted = append(converted, out)
+	}
+	return converted, markers
+}
+
+// timeline renders the events as one line each, with the time elapsed since
+// the first event.
+func timeline(history WorkflowHistory) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "History of workflow instance '%s' (%d of %d events", history.InstanceID, len(history.Events), history.TotalEvents)
+	if history.SkippedEvents > 0 {
+		fmt.Fprintf(&b, ", %d older events skipped", history.SkippedEvents)
+	}
+	b.WriteString("):\n")
+
+	var start time.Time
+	for i, e := range history.Events {
+		ts, _ := time.Parse(time.RFC3339Nano, e.Timestamp)
+		if i == 0 {
+			start = ts
+		}
+		id := ""
+		if e.EventID >= 0 {
+			id = fmt.Sprintf("#%d", e.EventID)
+		}
+		fmt.Fprintf(&b, "+%-9s %-5s %s", ts.Sub(start).Round(time.Millisecond), id, e.Type)
+		if e.Name != "" {
+			fmt.Fprintf(&b, " '%s'", e.Name)
+		}
+		if e.ScheduledID != nil {
+			fmt.Fprintf(&b, " (from #%d)", *e.ScheduledID)
+		}
+		if e.Status != "" {
+			fmt.Fprintf(&b, " %s", e.Status)
+		}
+		if e.InstanceID != "" {
+			fmt.Fprintf(&b, " instance=%s", e.InstanceID)
+		}
+		if e.FireAt != "" {
+			fmt.Fprintf(&b, " fireAt=%s", e.FireAt)
+		}
+		if e.Input != "" {
+			fmt.Fprintf(&b, " input=%s", e.Input)
+		}
+		if e.Output != "" {
+			fmt.Fprintf(&b, " output=%s", e.Output)
+		}
+		if e.Failure != nil {
+			fmt.Fprintf(&b, " failure=%s: %s", e.Failure.ErrorType, e.Failure.Message)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// GetWorkflowHistory reads the most recent maxEvents events of a workflow
+// instance hosted by this sidecar's app.
+func GetWorkflowHistory(ctx context.Context, client HistoryClient, instanceID string, maxEvents, payloadLength int) (WorkflowHistory, error) {
+	if maxEvents <= 0 {
+		maxEvents = DefaultHistoryEvents
+	}
+	maxEvents = min(maxEvents, MaxHistoryEvents)
+	if payloadLength <= 0 {
+		payloadLength = DefaultPayloadLength
+	}
+	history := WorkflowHistory{InstanceID: instanceID, Events: []HistoryEvent{}, PayloadLimit: payloadLength}
+
+	meta, err := client.GetMetadata(ctx)
+	if err != nil {
+		return history, fmt.Errorf("failed to fetch Dapr metadata: %w", err)
+	}
+	actorType, err := workflowActorType(meta)
+	if err != nil {
+		return history, err
+	}
+
+	resp, err := client.GetActorState(ctx, &dapr.GetActorStateRequest{ActorType: actorType, ActorID: instanceID, KeyName: "metadata"})
+	if err != nil {
+		return history, fmt.Errorf("failed to read workflow state metadata: %w", err)
+	}
+	if len(resp.Data) == 0 {
+		return history, nil
+	}
+	var state protos.WorkflowStateMetadata
+	if err := proto.Unmarshal(resp.Data, &state); err != nil {
+		return history, fmt.Errorf("failed to decode workflow state metadata: %w", err)
+	}
+
+	total := state.GetHistoryLength()
+	first := uint64(0)
+	if total > uint64(maxEvents) {
+		first = total - uint64(maxEvents)
+	}
+	events, err := readEvents(ctx, client, actorType, instanceID, first, total)
+	if err != nil {
+		return history, err
+	}
+
+	t := &truncator{limit: payloadLength}
+	history.Found = true
+	history.TotalEvents = int(total)
+	history.SkippedEvents = int(first)
+	history.Events, history.ReplayMarkers = convertEvents(events, t)
+	history.TruncatedCount = t.count
+	return history, nil
+}
+
+// readEvents reads the history events in [first, total), historyReaders at a
+// time, and returns them in order. The actor API reads one key per call, so
+// the reads are spread over a bounded number of concurrent calls.
+func readEvents(ctx context.Context, client HistoryClient, actorType, instanceID string, first, total uint64) ([]*protos.HistoryEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	read := make([]*protos.HistoryEvent, total-first)
+	indexes := make(chan uint64)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	for range min(historyReaders, len(read)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				resp, err := client.GetActorState(ctx, &dapr.GetActorStateRequest{ActorType: actorType, ActorID: instanceID, KeyName: historyKey(i)})
+				if err != nil {
+					fail(fmt.Errorf("failed to read history event %d: %w", i, err))
+					continue
+				}
+				if len(resp.Data) == 0 {
+					continue
+				}
+				var event protos.HistoryEvent
+				if err := proto.Unmarshal(resp.Data, &event); err != nil {
+					fail(fmt.Errorf("failed to decode history event %d: %w", i, err))
+					continue
+				}
+				read[i-first] = &event
+			}
+		}()
+	}
+feed:
+	for i := first; i < total; i++ {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	events := make([]*protos.HistoryEvent, 0, len(read))
+	for _, event := range read {
+		if event != nil {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func getWorkflowHistoryTool(ctx context.Context, req *mcp.CallToolRequest, args GetWorkflowHistoryArgs) (
+	*mcp.CallToolResult,
+	WorkflowHistory,
+	error,
+) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "get_workflow_history")
+	defer span.End()
+	span.SetAttributes(attribute.String("dapr.operation", "get_workflow_history"))
+
+	history, err := GetWorkflowHistory(ctx, historyClient, args.InstanceID, args.MaxEvents, args.PayloadLength)
+	if err != nil {
+		log.Printf("Dapr GetWorkflowHistory failed: %v", err)
+		toolErrorMessage := fmt.Errorf("dapr GetWorkflowHistory failed: %w", err).Error()
+		return toolcall.WithStatus(&mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
+			IsError: true,
+		}, err), WorkflowHistory{}, nil
+	}
+
+	if !history.Found {
+		message := fmt.Sprintf("No history was found for workflow instance '%s'. It may never have existed, may have been purged, or may be hosted by another app.", args.InstanceID)
+		log.Println(message)
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: message}},
+		}, history, nil
+	}
+
+	log.Printf("Read %d of %d history events of workflow instance '%s'.", len(history.Events), history.TotalEvents, args.InstanceID)
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: timeline(history)}},
+	}, history, nil
+}
+
+// RegisterHistoryTools registers get_workflow_history.
+func RegisterHistoryTools(server *mcp.Server, client HistoryClient) {
+	historyClient = client
+
+	isOpenWorld := true
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "get_workflow_history",
+		Title: "Inspect Workflow History",
+		Description: "Retrieves the execution history of a Dapr Workflow instance: activities scheduled, completed and failed, timers, external events, child workflows and the execution result. Returns a compact timeline and the structured event list. Use it to explain why an instance failed, is stuck or behaved unexpectedly.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Call `get_workflow` first for the current status; use this tool to find out how it got there.\n" +
+			"2. A workflow waiting on a `TimerCreated` or for an event without a matching `TimerFired` or `EventRaised` is still waiting on it.\n" +
+			"3. Only the most recent `maxEvents` events are returned, and payloads are cut to `payloadLength`; raise them if the answer is cut off.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `instanceID`.\n" +
+			"2. **SCOPE**: Only workflows hosted by the app of the connected sidecar can be inspected. When that app is the MCP server itself, which hosts no workflows, the tool reports so.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+			OpenWorldHint:  &isOpenWorld,
+		},
+	}, getWorkflowHistoryTool)
+}
@@ -0,0 +1,196 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dapr/durabletask-go/api/protos"
+	dapr "github.com/dapr/go-sdk/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+	"github.com/dapr/dapr-mcp-server/test/testutil"
+)
+
+const testActorType = "dapr.internal.default.order-service.workflow"
+
+var historyStart = time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+func historyEvent(id int32, offset time.Duration, event *protos.HistoryEvent) *protos.HistoryEvent {
+	event.EventId = id
+	event.Timestamp = timestamppb.New(historyStart.Add(offset))
+	return event
+}
+
+// failedOrderHistory is a workflow that reserved stock, waited for an
+// approval and failed in its payment activity.
+func failedOrderHistory() []*protos.HistoryEvent {
+	return []*protos.HistoryEvent{
+		historyEvent(-1, 0, &protos.HistoryEvent{EventType: &protos.HistoryEvent_OrchestratorStarted{OrchestratorStarted: &protos.OrchestratorStartedEvent{}}}),
+		historyEvent(-1, 0, &protos.HistoryEvent{EventType: &protos.HistoryEvent_ExecutionStarted{ExecutionStarted: &protos.ExecutionStartedEvent{
+			Name: "OrderWorkflow", Input: wrapperspb.String(`{"order":"1001","notes":"` + strings.Repeat("x", 100) + `"}`),
+		}}}),
+		historyEvent(0, 0, &protos.HistoryEvent{EventType: &protos.HistoryEvent_TaskScheduled{TaskScheduled: &protos.TaskScheduledEvent{
+			Name: "ReserveStock", Input: wrapperspb.String(`"1001"`),
+		}}}),
+		historyEvent(-1, 500*time.Millisecond, &protos.HistoryEvent{EventType: &protos.HistoryEvent_TaskCompleted{TaskCompleted: &protos.TaskCompletedEvent{
+			TaskScheduledId: 0, Result: wrapperspb.String(`true`),
+		}}}),
+		historyEvent(1, time.Second, &protos.HistoryEvent{EventType: &protos.HistoryEvent_TimerCreated{TimerCreated: &protos.TimerCreatedEvent{
+			FireAt: timestamppb.New(historyStart.Add(time.Hour)), Name: proto.String("approval-timeout"),
+		}}}),
+		historyEvent(-1, 2*time.Second, &protos.HistoryEvent{EventType: &protos.HistoryEvent_EventRaised{EventRaised: &protos.EventRaisedEvent{
+			Name: "Approved", Input: wrapperspb.String(`{"by":"ops"}`),
+		}}}),
+		historyEvent(2, 2*time.Second, &protos.HistoryEvent{EventType: &protos.HistoryEvent_SubOrchestrationInstanceCreated{SubOrchestrationInstanceCreated: &protos.SubOrchestrationInstanceCreatedEvent{
+			Name: "PaymentWorkflow", InstanceId: "order-1001-payment",
+		}}}),
+		historyEvent(-1, 3*time.Second, &protos.HistoryEvent{EventType: &protos.HistoryEvent_SubOrchestrationInstanceFailed{SubOrchestrationInstanceFailed: &protos.SubOrchestrationInstanceFailedEvent{
+			TaskScheduledId: 2, FailureDetails: &protos.TaskFailureDetails{ErrorType: "PaymentError", ErrorMessage: "card declined"},
+		}}}),
+		historyEvent(-1, 3*time.Second, &protos.HistoryEvent{EventType: &protos.HistoryEvent_ExecutionCompleted{ExecutionCompleted: &protos.ExecutionCompletedEvent{
+			OrchestrationStatus: protos.OrchestrationStatus_ORCHESTRATION_STATUS_FAILED,
+			FailureDetails:      &protos.TaskFailureDetails{ErrorType: "PaymentError", ErrorMessage: "card declined", StackTrace: wrapperspb.String(strings.Repeat("frame\n", 100))},
+		}}}),
+		historyEvent(-1, 3*time.Second, &protos.HistoryEvent{EventType: &protos.HistoryEvent_OrchestratorCompleted{OrchestratorCompleted: &protos.OrchestratorCompletedEvent{}}}),
+	}
+}
+
+// mockHistory serves the events as workflow actor state of order-1001.
+func mockHistory(t *testing.T, m *mocks.MockDaprClient, events []*protos.HistoryEvent) {
+	t.Helper()
+	m.On("GetMetadata", mock.Anything).Return(&dapr.GetMetadataResponse{
+		ID:                "order-service",
+		ActiveActorsCount: []*dapr.MetadataActiveActorsCount{{Type: "cart"}, {Type: testActorType, Count: 1}},
+	}, nil)
+	meta, err := proto.Marshal(&protos.WorkflowStateMetadata{HistoryLength: uint64(len(events))})
+	require.NoError(t, err)
+	m.On("GetActorState", mock.Anything, &dapr.GetActorStateRequest{ActorType: testActorType, ActorID: "order-1001", KeyName: "metadata"}).
+		Return(&dapr.GetActorStateResponse{Data: meta}, nil)
+	for i, event := range events {
+		data, err := proto.Marshal(event)
+		require.NoError(t, err)
+		m.On("GetActorState", mock.Anything, &dapr.GetActorStateRequest{ActorType: testActorType, ActorID: "order-1001", KeyName: historyKey(uint64(i))}).
+			Return(&dapr.GetActorStateResponse{Data: data}, nil).Maybe()
+	}
+}
+
+func TestGetWorkflowHistory(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	mockHistory(t, mockClient, failedOrderHistory())
+
+	history, err := GetWorkflowHistory(context.Background(), mockClient, "order-1001", 0, 40)
+	require.NoError(t, err)
+	assert.True(t, history.Found)
+	assert.Equal(t, 10, history.TotalEvents)
+	assert.Equal(t, 0, history.SkippedEvents)
+	assert.Equal(t, 2, history.ReplayMarkers)
+	require.Len(t, history.Events, 8)
+
+	started := history.Events[0]
+	assert.Equal(t, "ExecutionStarted", started.Type)
+	assert.Equal(t, "OrderWorkflow", started.Name)
+	assert.True(t, strings.HasPrefix(started.Input, `{"order":"1001","notes":"xxxxxxxxxxxxxxx...`))
+	assert.Contains(t, started.Input, "(87 more bytes)")
+
+	completed := history.Events[2]
+	assert.Equal(t, "TaskCompleted", completed.Type)
+	assert.Equal(t, "ReserveStock", completed.Name, "result names are resolved from the scheduling event")
+	require.NotNil(t, completed.ScheduledID)
+	assert.Equal(t, int32(0), *completed.ScheduledID)
+	assert.Equal(t, "true", completed.Output)
+
+	assert.Equal(t, "approval-timeout", history.Events[3].Name)
+	assert.Equal(t, "2025-01-01T10:00:00Z", history.Events[3].FireAt)
+
+	failed := history.Events[6]
+	assert.Equal(t, "SubOrchestrationInstanceFailed", failed.Type)
+	assert.Equal(t, "PaymentWorkflow", failed.Name)
+	assert.Equal(t, "card declined", failed.Failure.Message)
+
+	result := history.Events[7]
+	assert.Equal(t, "FAILED", result.Status)
+	assert.Contains(t, result.Failure.StackTrace, "more bytes)")
+	assert.Equal(t, 2, history.TruncatedCount)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetWorkflowHistoryMaxEvents(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	mockHistory(t, mockClient, failedOrderHistory())
+
+	history, err := GetWorkflowHistory(context.Background(), mockClient, "order-1001", 3, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 7, history.SkippedEvents)
+	require.Len(t, history.Events, 2)
+	assert.Equal(t, "SubOrchestrationInstanceFailed", history.Events[0].Type)
+	assert.Empty(t, history.Events[0].Name, "the scheduling event was skipped")
+	assert.Equal(t, DefaultPayloadLength, history.PayloadLimit)
+	mockClient.AssertNotCalled(t, "GetActorState", mock.Anything, &dapr.GetActorStateRequest{ActorType: testActorType, ActorID: "order-1001", KeyName: historyKey(0)})
+}
+
+func TestGetWorkflowHistoryTool(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	mockHistory(t, mockClient, failedOrderHistory())
+	mockClient.On("GetActorState", mock.Anything, &dapr.GetActorStateRequest{ActorType: testActorType, ActorID: "missing", KeyName: "metadata"}).
+		Return(&dapr.GetActorStateResponse{}, nil)
+	mockClient.On("GetActorState", mock.Anything, &dapr.GetActorStateRequest{ActorType: testActorType, ActorID: "broken", KeyName: "metadata"}).
+		Return(nil, errors.New("actor runtime not ready"))
+	historyClient = mockClient
+
+	result, history, err := getWorkflowHistoryTool(context.Background(), &mcp.CallToolRequest{}, GetWorkflowHistoryArgs{InstanceID: "order-1001"})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	text := testutil.ResultText(t, result)
+	assert.Contains(t, text, "History of workflow instance 'order-1001' (8 of 10 events):")
+	assert.Contains(t, text, "+500ms           TaskCompleted 'ReserveStock' (from #0) output=true")
+	assert.Contains(t, text, "SubOrchestrationInstanceCreated 'PaymentWorkflow' instance=order-1001-payment")
+	assert.Contains(t, text, "ExecutionCompleted FAILED failure=PaymentError: card declined")
+	assert.Len(t, history.Events, 8)
+
+	result, history, err = getWorkflowHistoryTool(context.Background(), &mcp.CallToolRequest{}, GetWorkflowHistoryArgs{InstanceID: "missing"})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.False(t, history.Found)
+	assert.Contains(t, testutil.ResultText(t, result), "No history was found")
+
+	result, _, err = getWorkflowHistoryTool(context.Background(), &mcp.CallToolRequest{}, GetWorkflowHistoryArgs{InstanceID: "broken"})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, testutil.ResultText(t, result), "failed to read workflow state metadata: actor runtime not ready")
+}
+
+func TestWorkflowActorType(t *testing.T) {
+	actorType, err := workflowActorType(&dapr.GetMetadataResponse{
+		ID: "order-service",
+		ActiveActorsCount: []*dapr.MetadataActiveActorsCount{
+			{Type: "dapr.internal.production.order-service.activity"},
+			{Type: "dapr.internal.production.order-service.workflow"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "dapr.internal.production.order-service.workflow", actorType)
+
+	_, err = workflowActorType(&dapr.GetMetadataResponse{ID: "dapr-mcp-server"})
+	assert.ErrorContains(t, err, "app 'dapr-mcp-server' of the connected sidecar does not host workflows")
+}
+
+func TestGetWorkflowHistoryReadError(t *testing.T) {
+	mockClient := new(mocks.MockDaprClient)
+	events := failedOrderHistory()
+	mockClient.On("GetActorState", mock.Anything, &dapr.GetActorStateRequest{ActorType: testActorType, ActorID: "order-1001", KeyName: historyKey(4)}).
+		Return(nil, errors.New("actor state store unavailable"))
+	mockHistory(t, mockClient, events)
+
+	_, err := GetWorkflowHistory(context.Background(), mockClient, "order-1001", 0, 0)
+	assert.ErrorContains(t, err, "failed to read history event 4: actor state store unavailable")
+}
@@ -0,0 +1,404 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/dapr/durabletask-go/api"
+	"github.com/dapr/durabletask-go/api/protos"
+	daprwf "github.com/dapr/durabletask-go/workflow"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/dapr/dapr-mcp-server/pkg/toolcall"
+)
+
+// WorkflowClient defines the interface for workflow management operations. It
+// is satisfied by the durabletask-go workflow client recommended by the SDK.
+type WorkflowClient interface {
+	ScheduleWorkflow(ctx context.Context, name string, opts ...daprwf.NewWorkflowOptions) (string, error)
+	FetchWorkflowMetadata(ctx context.Context, id string, opts ...daprwf.FetchWorkflowMetadataOptions) (*daprwf.WorkflowMetadata, error)
+	RaiseEvent(ctx context.Context, id, eventName string, opts ...daprwf.RaiseEventOptions) error
+	SuspendWorkflow(ctx context.Context, id, reason string) error
+	ResumeWorkflow(ctx context.Context, id, reason string) error
+	TerminateWorkflow(ctx context.Context, id string, opts ...daprwf.TerminateOptions) error
+	PurgeWorkflowState(ctx context.Context, id string, opts ...daprwf.PurgeOptions) error
+}
+
+// NewClient creates a workflow client on the sidecar's gRPC connection.
+func NewClient(cc grpc.ClientConnInterface) WorkflowClient {
+	return daprwf.NewClient(cc)
+}
+
+type StartWorkflowArgs struct {
+	WorkflowName string `json:"workflowName" jsonschema:"The name the workflow is registered with in the app (e.g., 'OrderProcessingWorkflow')."`
+	InstanceID   string `json:"instanceID,omitempty" jsonschema:"Optional unique ID for the new instance (e.g., 'order-1001'). A random ID is generated when omitted."`
+	Input        any    `json:"input,omitempty" jsonschema:"Optional input passed to the workflow, serialized as JSON."`
+	StartTime    string `json:"startTime,omitempty" jsonschema:"Optional RFC 3339 time at which the workflow should start (e.g., '2025-01-01T09:00:00Z')."`
+}
+
+type WorkflowInstanceArgs struct {
+	InstanceID string `json:"instanceID" jsonschema:"The ID of the workflow instance (e.g., 'order-1001')."`
+}
+
+type RaiseWorkflowEventArgs struct {
+	InstanceID string `json:"instanceID" jsonschema:"The ID of the workflow instance waiting for the event."`
+	EventName  string `json:"eventName" jsonschema:"The name of the external event the workflow waits for (e.g., 'ApprovalReceived')."`
+	EventData  any    `json:"eventData,omitempty" jsonschema:"Optional event payload, serialized as JSON."`
+}
+
+type SuspendWorkflowArgs struct {
+	InstanceID string `json:"instanceID" jsonschema:"The ID of the workflow instance."`
+	Reason     string `json:"reason,omitempty" jsonschema:"Optional reason recorded in the workflow history."`
+}
+
+type TerminateWorkflowArgs struct {
+	InstanceID string `json:"instanceID" jsonschema:"The ID of the workflow instance to terminate."`
+	Output     any    `json:"output,omitempty" jsonschema:"Optional output recorded for the terminated instance, serialized as JSON."`
+	Recursive  bool   `json:"recursive,omitempty" jsonschema:"If true, also terminate the child workflows started by this instance."`
+}
+
+type PurgeWorkflowArgs struct {
+	InstanceID string `json:"instanceID" jsonschema:"The ID of the completed, failed or terminated workflow instance to purge."`
+	Recursive  bool   `json:"recursive,omitempty" jsonschema:"If true, also purge the child workflows started by this instance."`
+}
+
+var workflowClient WorkflowClient
+
+func workflowError(operation string, err error) (*mcp.CallToolResult, any, error) {
+	log.Printf("Dapr %s failed: %v", operation, err)
+	toolErrorMessage := fmt.Errorf("dapr %s failed: %w", operation, err).Error()
+	return toolcall.WithStatus(&mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: toolErrorMessage}},
+		IsError: true,
+	}, err), nil, nil
+}
+
+func invalidArgumentResult(err error) (*mcp.CallToolResult, any, error) {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}},
+		IsError: true,
+	}, nil, nil
+}
+
+// instanceResult reports the outcome of a management call on an instance.
+func instanceResult(message, instanceID string, extra map[string]interface{}) (*mcp.CallToolResult, any, error) {
+	log.Println(message)
+	structuredResult := map[string]interface{}{
+		"instance_id": instanceID,
+	}
+	for k, v := range extra {
+		structuredResult[k] = v
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message}},
+	}, structuredResult, nil
+}
+
+// decodePayload returns a JSON payload as a value, or as a string when it is
+// not valid JSON.
+func decodePayload(payload *wrapperspb.StringValue) any {
+	var value any
+	if err := json.Unmarshal([]byte(payload.GetValue()), &value); err == nil {
+		return value
+	}
+	return payload.GetValue()
+}
+
+func formatTimestamp(ts *timestamppb.Timestamp) string {
+	return ts.AsTime().UTC().Format(time.RFC3339Nano)
+}
+
+// describeInstance converts workflow metadata into the structured result of
+// get_workflow.
+func describeInstance(meta *daprwf.WorkflowMetadata) map[string]interface{} {
+	m := (*protos.OrchestrationMetadata)(meta)
+	result := map[string]interface{}{
+		"instance_id":     m.GetInstanceId(),
+		"name":            m.GetName(),
+		"runtime_status":  meta.String(),
+		"created_at":      formatTimestamp(m.GetCreatedAt()),
+		"last_updated_at": formatTimestamp(m.GetLastUpdatedAt()),
+		"found":           true,
+	}
+	if m.GetCompletedAt() != nil {
+		result["completed_at"] = formatTimestamp(m.GetCompletedAt())
+	}
+	if m.GetParentInstanceId() != "" {
+		result["parent_instance_id"] = m.GetParentInstanceId()
+	}
+	if m.GetInput() != nil {
+		result["input"] = decodePayload(m.GetInput())
+	}
+	if m.GetOutput() != nil {
+		result["output"] = decodePayload(m.GetOutput())
+	}
+	if m.GetCustomStatus() != nil {
+		result["custom_status"] = decodePayload(m.GetCustomStatus())
+	}
+	if failure := m.GetFailureDetails(); failure != nil {
+		result["failure"] = map[string]interface{}{
+			"error_type": failure.GetErrorType(),
+			"message":    failure.GetErrorMessage(),
+		}
+	}
+	return result
+}
+
+func startWorkflowTool(ctx context.Context, req *mcp.CallToolRequest, args StartWorkflowArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "start_workflow")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("dapr.operation", "start_workflow"),
+		attribute.String("dapr.workflow_name", args.WorkflowName),
+	)
+
+	opts := []daprwf.NewWorkflowOptions{}
+	if args.InstanceID != "" {
+		opts = append(opts, daprwf.WithInstanceID(args.InstanceID))
+	}
+	if args.Input != nil {
+		opts = append(opts, daprwf.WithInput(args.Input))
+	}
+	if args.StartTime != "" {
+		startTime, err := time.Parse(time.RFC3339, args.StartTime)
+		if err != nil {
+			return invalidArgumentResult(fmt.Errorf("invalid startTime '%s': %w", args.StartTime, err))
+		}
+		opts = append(opts, daprwf.WithStartTime(startTime))
+	}
+
+	instanceID, err := workflowClient.ScheduleWorkflow(ctx, args.WorkflowName, opts...)
+	if err != nil {
+		return workflowError("ScheduleWorkflow", err)
+	}
+
+	message := fmt.Sprintf("Successfully started workflow '%s' with instance ID '%s'.", args.WorkflowName, instanceID)
+	extra := map[string]interface{}{"workflow_name": args.WorkflowName}
+	if args.StartTime != "" {
+		message = fmt.Sprintf("Successfully scheduled workflow '%s' with instance ID '%s' to start at %s.", args.WorkflowName, instanceID, args.StartTime)
+		extra["start_time"] = args.StartTime
+	}
+	return instanceResult(message, instanceID, extra)
+}
+
+func getWorkflowTool(ctx context.Context, req *mcp.CallToolRequest, args WorkflowInstanceArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "get_workflow")
+	defer span.End()
+	span.SetAttributes(attribute.String("dapr.operation", "get_workflow"))
+
+	meta, err := workflowClient.FetchWorkflowMetadata(ctx, args.InstanceID, daprwf.WithFetchPayloads(true))
+	if errors.Is(err, api.ErrInstanceNotFound) {
+		return instanceResult(fmt.Sprintf("Workflow instance '%s' was not found. It may never have existed or may have been purged.", args.InstanceID),
+			args.InstanceID, map[string]interface{}{"found": false})
+	}
+	if err != nil {
+		return workflowError("FetchWorkflowMetadata", err)
+	}
+
+	structuredResult := describeInstance(meta)
+	message := fmt.Sprintf("Workflow instance '%s' of workflow '%s' is %s.", args.InstanceID, meta.Name, meta.String())
+	if failure, ok := structuredResult["failure"].(map[string]interface{}); ok {
+		message += fmt.Sprintf(" Failure: %s: %s", failure["error_type"], failure["message"])
+	}
+	log.Println(message)
+
+	data, _ := json.MarshalIndent(structuredResult, "", "  ")
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message + "\n\nInstance:\n" + string(data)}},
+	}, structuredResult, nil
+}
+
+func raiseWorkflowEventTool(ctx context.Context, req *mcp.CallToolRequest, args RaiseWorkflowEventArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "raise_workflow_event")
+	defer span.End()
+	span.SetAttributes(attribute.String("dapr.operation", "raise_workflow_event"))
+
+	opts := []daprwf.RaiseEventOptions{}
+	if args.EventData != nil {
+		opts = append(opts, daprwf.WithEventPayload(args.EventData))
+	}
+	if err := workflowClient.RaiseEvent(ctx, args.InstanceID, args.EventName, opts...); err != nil {
+		return workflowError("RaiseEvent", err)
+	}
+	return instanceResult(fmt.Sprintf("Successfully raised event '%s' on workflow instance '%s'.", args.EventName, args.InstanceID),
+		args.InstanceID, map[string]interface{}{"event_name": args.EventName})
+}
+
+func pauseWorkflowTool(ctx context.Context, req *mcp.CallToolRequest, args SuspendWorkflowArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "pause_workflow")
+	defer span.End()
+	span.SetAttributes(attribute.String("dapr.operation", "pause_workflow"))
+
+	if err := workflowClient.SuspendWorkflow(ctx, args.InstanceID, args.Reason); err != nil {
+		return workflowError("SuspendWorkflow", err)
+	}
+	return instanceResult(fmt.Sprintf("Successfully paused workflow instance '%s'.", args.InstanceID),
+		args.InstanceID, map[string]interface{}{"action": "paused"})
+}
+
+func resumeWorkflowTool(ctx context.Context, req *mcp.CallToolRequest, args SuspendWorkflowArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "resume_workflow")
+	defer span.End()
+	span.SetAttributes(attribute.String("dapr.operation", "resume_workflow"))
+
+	if err := workflowClient.ResumeWorkflow(ctx, args.InstanceID, args.Reason); err != nil {
+		return workflowError("ResumeWorkflow", err)
+	}
+	return instanceResult(fmt.Sprintf("Successfully resumed workflow instance '%s'.", args.InstanceID),
+		args.InstanceID, map[string]interface{}{"action": "resumed"})
+}
+
+func terminateWorkflowTool(ctx context.Context, req *mcp.CallToolRequest, args TerminateWorkflowArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "terminate_workflow")
+	defer span.End()
+	span.SetAttributes(attribute.String("dapr.operation", "terminate_workflow"))
+
+	opts := []daprwf.TerminateOptions{daprwf.WithRecursiveTerminate(args.Recursive)}
+	if args.Output != nil {
+		opts = append(opts, daprwf.WithOutput(args.Output))
+	}
+	if err := workflowClient.TerminateWorkflow(ctx, args.InstanceID, opts...); err != nil {
+		return workflowError("TerminateWorkflow", err)
+	}
+	return instanceResult(fmt.Sprintf("Successfully terminated workflow instance '%s'.", args.InstanceID),
+		args.InstanceID, map[string]interface{}{"action": "terminated", "recursive": args.Recursive})
+}
+
+func purgeWorkflowTool(ctx context.Context, req *mcp.CallToolRequest, args PurgeWorkflowArgs) (*mcp.CallToolResult, any, error) {
+	ctx, span := otel.Tracer("dapr-mcp-server").Start(ctx, "purge_workflow")
+	defer span.End()
+	span.SetAttributes(attribute.String("dapr.operation", "purge_workflow"))
+
+	err := workflowClient.PurgeWorkflowState(ctx, args.InstanceID, daprwf.WithRecursivePurge(args.Recursive))
+	if errors.Is(err, api.ErrInstanceNotFound) {
+		return workflowError("PurgeWorkflowState", fmt.Errorf("workflow instance '%s' was not found or is still running", args.InstanceID))
+	}
+	if err != nil {
+		return workflowError("PurgeWorkflowState", err)
+	}
+	return instanceResult(fmt.Sprintf("Successfully purged workflow instance '%s'.", args.InstanceID),
+		args.InstanceID, map[string]interface{}{"action": "purged", "recursive": args.Recursive})
+}
+
+func RegisterTools(server *mcp.Server, client WorkflowClient) {
+	workflowClient = client
+
+	isDestructive := true
+	notDestructive := false
+	isOpenWorld := true
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "start_workflow",
+		Title: "Start Workflow Instance",
+		Description: "Starts a new instance of a Dapr Workflow registered by an app. **This is a SIDE-EFFECT action that is NOT IDEMPOTENT.** Returns the instance ID used by every other workflow tool.\n\n" +
+			"**SCOPE**: Workflow calls go to the app of the connected sidecar, usually the MCP server itself. Only workflows registered by that app can be started; workflows of other apps cannot be reached.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Provide `instanceID` when the user names the instance (e.g., an order number); starting an ID that is already running fails.\n" +
+			"2. Use `get_workflow` afterwards to follow its status and read its output.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `workflowName`.\n" +
+			"2. **NEVER INVENT**: You must NOT invent workflow names; ask the user if unsure.\n" +
+			"3. **START TIME**: `startTime` must be an RFC 3339 time.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: &notDestructive,
+			IdempotentHint:  false,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, startWorkflowTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "get_workflow",
+		Title: "Get Workflow Status",
+		Description: "Retrieves the status of a Dapr Workflow instance with its input, output, custom status and failure details. Only instances of the connected sidecar's app are visible.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. `runtime_status` is one of RUNNING, COMPLETED, CONTINUED_AS_NEW, FAILED, CANCELED, TERMINATED, PENDING or SUSPENDED.\n" +
+			"2. `found: false` means the instance never existed, was purged, or belongs to another app.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `instanceID`.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+			OpenWorldHint:  &isOpenWorld,
+		},
+	}, getWorkflowTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "raise_workflow_event",
+		Title: "Raise Workflow Event",
+		Description: "Sends an external event to a running Dapr Workflow instance that waits for it (e.g., an approval). Only instances of the connected sidecar's app can be reached. **This is a SIDE-EFFECT action that is NOT IDEMPOTENT.**\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `instanceID` and `eventName`.\n" +
+			"2. **NEVER INVENT**: The event name must match the name the workflow waits for; ask the user if unsure.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: &notDestructive,
+			IdempotentHint:  false,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, raiseWorkflowEventTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "pause_workflow",
+		Title: "Pause Workflow Instance",
+		Description: "Suspends a running Dapr Workflow instance of the connected sidecar's app. It stops processing events until `resume_workflow` is called; events raised meanwhile are kept.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `instanceID`.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: &notDestructive,
+			IdempotentHint:  true,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, pauseWorkflowTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "resume_workflow",
+		Title: "Resume Workflow Instance",
+		Description: "Resumes a Dapr Workflow instance of the connected sidecar's app paused with `pause_workflow`.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `instanceID`.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: &notDestructive,
+			IdempotentHint:  true,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, resumeWorkflowTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "terminate_workflow",
+		Title: "Terminate Workflow Instance",
+		Description: "Terminates a running Dapr Workflow instance of the connected sidecar's app. **This is a DESTRUCTIVE action.** The instance stops immediately and cannot be resumed.\n\n" +
+			"**GUIDANCE:**\n" +
+			"1. Prefer `pause_workflow` when the user may want to continue later.\n" +
+			"2. Set `recursive` to also terminate the child workflows it started.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `instanceID`.\n" +
+			"2. **CONFIRMATION**: Confirm the instance ID with the user before terminating.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: &isDestructive,
+			IdempotentHint:  true,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, terminateWorkflowTool)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:  "purge_workflow",
+		Title: "Purge Workflow Instance",
+		Description: "Deletes the state and history of a completed, failed or terminated Dapr Workflow instance of the connected sidecar's app. **This is a DESTRUCTIVE action.** Running instances cannot be purged.\n\n" +
+			"**ARGUMENT RULES:**\n" +
+			"1. **REQUIRED INPUTS**: You MUST provide `instanceID`.\n" +
+			"2. **CONFIRMATION**: Confirm the instance ID with the user before purging.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: &isDestructive,
+			IdempotentHint:  true,
+			OpenWorldHint:   &isOpenWorld,
+		},
+	}, purgeWorkflowTool)
+}
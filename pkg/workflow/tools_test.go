@@ -0,0 +1,257 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dapr/durabletask-go/api"
+	"github.com/dapr/durabletask-go/api/protos"
+	daprwf "github.com/dapr/durabletask-go/workflow"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/dapr/dapr-mcp-server/test/mocks"
+	"github.com/dapr/dapr-mcp-server/test/testutil"
+)
+
+var _ WorkflowClient = (*mocks.MockWorkflowClient)(nil)
+
+func TestStartWorkflowTool(t *testing.T) {
+	mockClient := new(mocks.MockWorkflowClient)
+	workflowClient = mockClient
+
+	var req protos.CreateInstanceRequest
+	mockClient.On("ScheduleWorkflow", mock.Anything, "OrderWorkflow", mock.Anything).
+		Run(func(args mock.Arguments) {
+			for _, opt := range args.Get(2).([]daprwf.NewWorkflowOptions) {
+				require.NoError(t, opt(&req))
+			}
+		}).
+		Return("order-1", nil)
+
+	result, structured, err := startWorkflowTool(context.Background(), &mcp.CallToolRequest{}, StartWorkflowArgs{
+		WorkflowName: "OrderWorkflow",
+		InstanceID:   "order-1",
+		Input:        map[string]any{"item": "book"},
+		StartTime:    "2025-01-01T09:00:00Z",
+	})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, testutil.ResultText(t, result), "instance ID 'order-1' to start at 2025-01-01T09:00:00Z")
+	assert.Equal(t, "order-1", structured.(map[string]interface{})["instance_id"])
+
+	assert.Equal(t, "order-1", req.GetInstanceId())
+	assert.JSONEq(t, `{"item":"book"}`, req.GetInput().GetValue())
+	assert.Equal(t, time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC), req.GetScheduledStartTimestamp().AsTime())
+	mockClient.AssertExpectations(t)
+}
+
+func TestStartWorkflowToolErrors(t *testing.T) {
+	mockClient := new(mocks.MockWorkflowClient)
+	workflowClient = mockClient
+
+	result, _, err := startWorkflowTool(context.Background(), &mcp.CallToolRequest{}, StartWorkflowArgs{WorkflowName: "OrderWorkflow", StartTime: "tomorrow"})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	text := testutil.ResultText(t, result)
+	assert.True(t, strings.HasPrefix(text, "invalid startTime 'tomorrow'"), text)
+	assert.Nil(t, result.Meta, "argument errors carry no sidecar status")
+
+	mockClient.On("ScheduleWorkflow", mock.Anything, "Missing", mock.Anything).Return("", errors.New("workflow not registered"))
+	result, _, err = startWorkflowTool(context.Background(), &mcp.CallToolRequest{}, StartWorkflowArgs{WorkflowName: "Missing"})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, testutil.ResultText(t, result), "dapr ScheduleWorkflow failed: workflow not registered")
+}
+
+func TestGetWorkflowTool(t *testing.T) {
+	created := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		meta        *daprwf.WorkflowMetadata
+		err         error
+		wantErr     bool
+		wantContent string
+		check       func(*testing.T, map[string]interface{})
+	}{
+		{
+			name: "completed instance",
+			meta: &daprwf.WorkflowMetadata{
+				InstanceId:    "order-1",
+				Name:          "OrderWorkflow",
+				RuntimeStatus: api.RUNTIME_STATUS_COMPLETED,
+				CreatedAt:     timestamppb.New(created),
+				LastUpdatedAt: timestamppb.New(created.Add(time.Minute)),
+				CompletedAt:   timestamppb.New(created.Add(time.Minute)),
+				Input:         wrapperspb.String(`{"item":"book"}`),
+				Output:        wrapperspb.String(`"shipped"`),
+			},
+			wantContent: "Workflow instance 'order-1' of workflow 'OrderWorkflow' is COMPLETED.",
+			check: func(t *testing.T, result map[string]interface{}) {
+				assert.Equal(t, true, result["found"])
+				assert.Equal(t, map[string]any{"item": "book"}, result["input"])
+				assert.Equal(t, "shipped", result["output"])
+				assert.Equal(t, "2025-01-01T09:01:00Z", result["completed_at"])
+			},
+		},
+		{
+			name: "failed instance",
+			meta: &daprwf.WorkflowMetadata{
+				InstanceId:     "order-2",
+				Name:           "OrderWorkflow",
+				RuntimeStatus:  api.RUNTIME_STATUS_FAILED,
+				CreatedAt:      timestamppb.New(created),
+				LastUpdatedAt:  timestamppb.New(created),
+				FailureDetails: &protos.TaskFailureDetails{ErrorType: "TaskFailedError", ErrorMessage: "payment declined"},
+			},
+			wantContent: "is FAILED. Failure: TaskFailedError: payment declined",
+			check: func(t *testing.T, result map[string]interface{}) {
+				assert.Equal(t, "FAILED", result["runtime_status"])
+				assert.NotContains(t, result, "completed_at")
+			},
+		},
+		{
+			name:        "instance not found",
+			err:         api.ErrInstanceNotFound,
+			wantContent: "was not found",
+			check: func(t *testing.T, result map[string]interface{}) {
+				assert.Equal(t, false, result["found"])
+			},
+		},
+		{
+			name:        "API error",
+			err:         errors.New("connection refused"),
+			wantErr:     true,
+			wantContent: "dapr FetchWorkflowMetadata failed: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(mocks.MockWorkflowClient)
+			workflowClient = mockClient
+			if tt.meta != nil {
+				mockClient.On("FetchWorkflowMetadata", mock.Anything, "order-1", mock.Anything).Return(tt.meta, nil)
+			} else {
+				mockClient.On("FetchWorkflowMetadata", mock.Anything, "order-1", mock.Anything).Return(nil, tt.err)
+			}
+
+			result, structured, err := getWorkflowTool(context.Background(), &mcp.CallToolRequest{}, WorkflowInstanceArgs{InstanceID: "order-1"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantErr, result.IsError)
+			assert.Contains(t, testutil.ResultText(t, result), tt.wantContent)
+			if tt.check != nil {
+				tt.check(t, structured.(map[string]interface{}))
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRaiseWorkflowEventTool(t *testing.T) {
+	mockClient := new(mocks.MockWorkflowClient)
+	workflowClient = mockClient
+
+	var req protos.RaiseEventRequest
+	mockClient.On("RaiseEvent", mock.Anything, "order-1", "ApprovalReceived", mock.Anything).
+		Run(func(args mock.Arguments) {
+			for _, opt := range args.Get(3).([]daprwf.RaiseEventOptions) {
+				require.NoError(t, opt(&req))
+			}
+		}).
+		Return(nil)
+
+	result, _, err := raiseWorkflowEventTool(context.Background(), &mcp.CallToolRequest{}, RaiseWorkflowEventArgs{
+		InstanceID: "order-1",
+		EventName:  "ApprovalReceived",
+		EventData:  map[string]any{"approved": true},
+	})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, testutil.ResultText(t, result), "raised event 'ApprovalReceived'")
+	assert.JSONEq(t, `{"approved":true}`, req.GetInput().GetValue())
+	mockClient.AssertExpectations(t)
+}
+
+func TestPauseAndResumeWorkflowTools(t *testing.T) {
+	mockClient := new(mocks.MockWorkflowClient)
+	workflowClient = mockClient
+	mockClient.On("SuspendWorkflow", mock.Anything, "order-1", "investigating").Return(nil)
+	mockClient.On("ResumeWorkflow", mock.Anything, "order-1", "").Return(errors.New("instance is not suspended"))
+
+	result, _, err := pauseWorkflowTool(context.Background(), &mcp.CallToolRequest{}, SuspendWorkflowArgs{InstanceID: "order-1", Reason: "investigating"})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, testutil.ResultText(t, result), "paused workflow instance 'order-1'")
+
+	result, _, err = resumeWorkflowTool(context.Background(), &mcp.CallToolRequest{}, SuspendWorkflowArgs{InstanceID: "order-1"})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, testutil.ResultText(t, result), "dapr ResumeWorkflow failed: instance is not suspended")
+	mockClient.AssertExpectations(t)
+}
+
+func TestTerminateWorkflowTool(t *testing.T) {
+	mockClient := new(mocks.MockWorkflowClient)
+	workflowClient = mockClient
+
+	req := protos.TerminateRequest{Recursive: true}
+	mockClient.On("TerminateWorkflow", mock.Anything, "order-1", mock.Anything).
+		Run(func(args mock.Arguments) {
+			for _, opt := range args.Get(2).([]daprwf.TerminateOptions) {
+				require.NoError(t, opt(&req))
+			}
+		}).
+		Return(nil)
+
+	result, _, err := terminateWorkflowTool(context.Background(), &mcp.CallToolRequest{}, TerminateWorkflowArgs{InstanceID: "order-1", Output: "cancelled by operator"})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, testutil.ResultText(t, result), "terminated workflow instance 'order-1'")
+	assert.False(t, req.GetRecursive(), "child workflows are only terminated when asked")
+	assert.Equal(t, `"cancelled by operator"`, req.GetOutput().GetValue())
+	mockClient.AssertExpectations(t)
+}
+
+func TestPurgeWorkflowTool(t *testing.T) {
+	mockClient := new(mocks.MockWorkflowClient)
+	workflowClient = mockClient
+
+	var req protos.PurgeInstancesRequest
+	mockClient.On("PurgeWorkflowState", mock.Anything, "order-1", mock.Anything).
+		Run(func(args mock.Arguments) {
+			for _, opt := range args.Get(2).([]daprwf.PurgeOptions) {
+				require.NoError(t, opt(&req))
+			}
+		}).
+		Return(nil)
+	mockClient.On("PurgeWorkflowState", mock.Anything, "running", mock.Anything).Return(api.ErrInstanceNotFound)
+
+	result, structured, err := purgeWorkflowTool(context.Background(), &mcp.CallToolRequest{}, PurgeWorkflowArgs{InstanceID: "order-1", Recursive: true})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.True(t, req.GetRecursive())
+	assert.Equal(t, "purged", structured.(map[string]interface{})["action"])
+
+	result, _, err = purgeWorkflowTool(context.Background(), &mcp.CallToolRequest{}, PurgeWorkflowArgs{InstanceID: "running"})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Contains(t, testutil.ResultText(t, result), "'running' was not found or is still running")
+	mockClient.AssertExpectations(t)
+}
+
+func TestRegisterTools(t *testing.T) {
+	mockClient := new(mocks.MockWorkflowClient)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v1.0.0"}, nil)
+
+	RegisterTools(server, mockClient)
+
+	assert.Equal(t, mockClient, workflowClient)
+}
@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/dapr/dapr-mcp-server/pkg/sidecar"
+)
+
+// MockInvokeClient is a mock implementation of the service invocation client.
+type MockInvokeClient struct {
+	mock.Mock
+}
+
+// InvokeService mocks the InvokeService method.
+func (m *MockInvokeClient) InvokeService(ctx context.Context, req *sidecar.InvokeRequest) (*sidecar.InvokeResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sidecar.InvokeResponse), args.Error(1)
+}
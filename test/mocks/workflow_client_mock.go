@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/dapr/durabletask-go/workflow"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWorkflowClient is a mock implementation of the workflow client. The
+// variadic options are passed to Called as a single slice argument.
+type MockWorkflowClient struct {
+	mock.Mock
+}
+
+// ScheduleWorkflow mocks the ScheduleWorkflow method.
+func (m *MockWorkflowClient) ScheduleWorkflow(ctx context.Context, name string, opts ...workflow.NewWorkflowOptions) (string, error) {
+	args := m.Called(ctx, name, opts)
+	return args.String(0), args.Error(1)
+}
+
+// FetchWorkflowMetadata mocks the FetchWorkflowMetadata method.
+func (m *MockWorkflowClient) FetchWorkflowMetadata(ctx context.Context, id string, opts ...workflow.FetchWorkflowMetadataOptions) (*workflow.WorkflowMetadata, error) {
+	args := m.Called(ctx, id, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*workflow.WorkflowMetadata), args.Error(1)
+}
+
+// RaiseEvent mocks the RaiseEvent method.
+func (m *MockWorkflowClient) RaiseEvent(ctx context.Context, id, eventName string, opts ...workflow.RaiseEventOptions) error {
+	args := m.Called(ctx, id, eventName, opts)
+	return args.Error(0)
+}
+
+// SuspendWorkflow mocks the SuspendWorkflow method.
+func (m *MockWorkflowClient) SuspendWorkflow(ctx context.Context, id, reason string) error {
+	args := m.Called(ctx, id, reason)
+	return args.Error(0)
+}
+
+// ResumeWorkflow mocks the ResumeWorkflow method.
+func (m *MockWorkflowClient) ResumeWorkflow(ctx context.Context, id, reason string) error {
+	args := m.Called(ctx, id, reason)
+	return args.Error(0)
+}
+
+// TerminateWorkflow mocks the TerminateWorkflow method.
+func (m *MockWorkflowClient) TerminateWorkflow(ctx context.Context, id string, opts ...workflow.TerminateOptions) error {
+	args := m.Called(ctx, id, opts)
+	return args.Error(0)
+}
+
+// PurgeWorkflowState mocks the PurgeWorkflowState method.
+func (m *MockWorkflowClient) PurgeWorkflowState(ctx context.Context, id string, opts ...workflow.PurgeOptions) error {
+	args := m.Called(ctx, id, opts)
+	return args.Error(0)
+}
@@ -0,0 +1,19 @@
+// Package testutil provides helpers shared by the tool tests.
+package testutil
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+// ResultText returns the text of a tool result, which must hold exactly one
+// text content.
+func ResultText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	require.Len(t, result.Content, 1)
+	text, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	return text.Text
+}